@@ -0,0 +1,135 @@
+package marketmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	slinkymmtypes "github.com/skip-mev/slinky/x/marketmap/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+// detectTimeout bounds the queries used to detect the version of a chain when creating a client.
+const detectTimeout = 10 * time.Second
+
+// ErrNoMarketMapModule is returned when a node serves neither the connect nor the slinky x/marketmap query service.
+var ErrNoMarketMapModule = errors.New("node serves neither the connect nor the slinky x/marketmap query service")
+
+// detectedVersions caches the detected version of each gRPC address, so that a node is only probed once.
+var detectedVersions = struct {
+	sync.Mutex
+	versions map[string]config.Version
+}{versions: make(map[string]config.Version)}
+
+// ChainInfo is the chain id and x/marketmap module of a chain, as served by its node.
+type ChainInfo struct {
+	ChainID string         `json:"chain_id"`
+	Version config.Version `json:"version"`
+	Params  mmtypes.Params `json:"params"`
+}
+
+// DetectVersion detects which x/marketmap module the node serves by querying the params of the connect query
+// service, then of the slinky query service. A service that is not registered on the node fails with
+// codes.Unimplemented; any other error is returned.
+func DetectVersion(ctx context.Context, cc grpc.ClientConnInterface) (config.Version, mmtypes.Params, error) {
+	connectRes, err := mmtypes.NewQueryClient(cc).Params(ctx, &mmtypes.ParamsRequest{})
+	if err == nil {
+		return config.VersionConnect, connectRes.Params, nil
+	}
+	if status.Code(err) != codes.Unimplemented {
+		return "", mmtypes.Params{}, fmt.Errorf("failed to query connect x/marketmap params: %w", err)
+	}
+
+	slinkyRes, err := slinkymmtypes.NewQueryClient(cc).Params(ctx, &slinkymmtypes.ParamsRequest{})
+	if err == nil {
+		return config.VersionSlinky, mmtypes.Params{
+			MarketAuthorities: slinkyRes.Params.MarketAuthorities,
+			Admin:             slinkyRes.Params.Admin,
+		}, nil
+	}
+	if status.Code(err) != codes.Unimplemented {
+		return "", mmtypes.Params{}, fmt.Errorf("failed to query slinky x/marketmap params: %w", err)
+	}
+
+	return "", mmtypes.Params{}, ErrNoMarketMapModule
+}
+
+// GetChainInfo queries the chain id, x/marketmap version and x/marketmap params of the node.
+func GetChainInfo(ctx context.Context, cc grpc.ClientConnInterface) (ChainInfo, error) {
+	nodeInfo, err := cmtservice.NewServiceClient(cc).GetNodeInfo(ctx, &cmtservice.GetNodeInfoRequest{})
+	if err != nil {
+		return ChainInfo{}, fmt.Errorf("failed to query node info: %w", err)
+	}
+
+	version, params, err := DetectVersion(ctx, cc)
+	if err != nil {
+		return ChainInfo{}, err
+	}
+
+	info := ChainInfo{Version: version, Params: params}
+	if nodeInfo.DefaultNodeInfo != nil {
+		info.ChainID = nodeInfo.DefaultNodeInfo.Network
+	}
+	return info, nil
+}
+
+// ResolveVersion returns the version of the chain. If the configured version is auto, the version detected from the
+// node is returned. Otherwise the configured version is returned, with a warning if the node serves another version.
+// Detections are cached by gRPC address.
+func ResolveVersion(
+	ctx context.Context,
+	logger *zap.Logger,
+	cfg config.ChainConfig,
+	cc grpc.ClientConnInterface,
+) (config.Version, error) {
+	detected, err := cachedDetectVersion(ctx, cfg.GRPCAddress, cc)
+
+	if cfg.Version == config.VersionAuto {
+		if err != nil {
+			return "", fmt.Errorf("failed to detect chain version: %w", err)
+		}
+		logger.Info("detected chain version", zap.String("version", string(detected)))
+		return detected, nil
+	}
+
+	switch {
+	case err != nil:
+		logger.Debug("failed to detect chain version, using configured version",
+			zap.String("version", string(cfg.Version)), zap.Error(err))
+	case detected != cfg.Version:
+		logger.Warn("configured chain version does not match the x/marketmap module served by the node",
+			zap.String("configured", string(cfg.Version)), zap.String("detected", string(detected)),
+			zap.String("grpc_address", cfg.GRPCAddress))
+	}
+	return cfg.Version, nil
+}
+
+// cachedDetectVersion returns the cached version of the address, or detects and caches it.
+func cachedDetectVersion(ctx context.Context, address string, cc grpc.ClientConnInterface) (config.Version, error) {
+	detectedVersions.Lock()
+	defer detectedVersions.Unlock()
+
+	if version, found := detectedVersions.versions[address]; found {
+		return version, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, detectTimeout)
+	defer cancel()
+
+	version, _, err := DetectVersion(ctx, cc)
+	if err != nil {
+		return "", err
+	}
+
+	detectedVersions.versions[address] = version
+	return version, nil
+}
@@ -0,0 +1,142 @@
+package marketmap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cometbft/cometbft/proto/tendermint/p2p"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	slinkymmtypes "github.com/skip-mev/slinky/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/skip-mev/connect-mmu/client/marketmap"
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+// nodeConn serves the x/marketmap params query of the version, failing other x/marketmap queries as unimplemented.
+type nodeConn struct {
+	grpc.ClientConnInterface
+	version config.Version
+	err     error
+	calls   int
+}
+
+func (c *nodeConn) Invoke(_ context.Context, method string, _, reply any, _ ...grpc.CallOption) error {
+	c.calls++
+	if c.err != nil {
+		return c.err
+	}
+
+	switch {
+	case method == "/cosmos.base.tendermint.v1beta1.Service/GetNodeInfo":
+		reply.(*cmtservice.GetNodeInfoResponse).DefaultNodeInfo = &p2p.DefaultNodeInfo{Network: "chain-1"}
+	case method == "/connect.marketmap.v2.Query/Params" && c.version == config.VersionConnect:
+		reply.(*mmtypes.ParamsResponse).Params = mmtypes.Params{MarketAuthorities: []string{"authority"}, Admin: "admin"}
+	case method == "/slinky.marketmap.v1.Query/Params" && c.version == config.VersionSlinky:
+		reply.(*slinkymmtypes.ParamsResponse).Params = slinkymmtypes.Params{MarketAuthorities: []string{"authority"}, Admin: "admin"}
+	default:
+		return status.Errorf(codes.Unimplemented, "unknown service for method %s", method)
+	}
+	return nil
+}
+
+func TestDetectVersion(t *testing.T) {
+	params := mmtypes.Params{MarketAuthorities: []string{"authority"}, Admin: "admin"}
+
+	tests := []struct {
+		name        string
+		conn        *nodeConn
+		wantVersion config.Version
+		wantErr     string
+	}{
+		{
+			name:        "connect",
+			conn:        &nodeConn{version: config.VersionConnect},
+			wantVersion: config.VersionConnect,
+		},
+		{
+			name:        "slinky",
+			conn:        &nodeConn{version: config.VersionSlinky},
+			wantVersion: config.VersionSlinky,
+		},
+		{
+			name:    "no x/marketmap module",
+			conn:    &nodeConn{},
+			wantErr: marketmap.ErrNoMarketMapModule.Error(),
+		},
+		{
+			name:    "unavailable node",
+			conn:    &nodeConn{err: status.Error(codes.Unavailable, "connection refused")},
+			wantErr: "connection refused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, gotParams, err := marketmap.DetectVersion(context.Background(), tt.conn)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantVersion, version)
+			require.Equal(t, params, gotParams)
+		})
+	}
+}
+
+func TestGetChainInfo(t *testing.T) {
+	info, err := marketmap.GetChainInfo(context.Background(), &nodeConn{version: config.VersionSlinky})
+	require.NoError(t, err)
+	require.Equal(t, marketmap.ChainInfo{
+		ChainID: "chain-1",
+		Version: config.VersionSlinky,
+		Params:  mmtypes.Params{MarketAuthorities: []string{"authority"}, Admin: "admin"},
+	}, info)
+}
+
+func TestResolveVersion(t *testing.T) {
+	t.Run("detect the version if auto", func(t *testing.T) {
+		cfg := config.ChainConfig{GRPCAddress: "auto:9090", Version: config.VersionAuto}
+		conn := &nodeConn{version: config.VersionSlinky}
+
+		version, err := marketmap.ResolveVersion(context.Background(), zaptest.NewLogger(t), cfg, conn)
+		require.NoError(t, err)
+		require.Equal(t, config.VersionSlinky, version)
+
+		// the detection is cached by address
+		calls := conn.calls
+		version, err = marketmap.ResolveVersion(context.Background(), zaptest.NewLogger(t), cfg, conn)
+		require.NoError(t, err)
+		require.Equal(t, config.VersionSlinky, version)
+		require.Equal(t, calls, conn.calls)
+	})
+
+	t.Run("fail to detect the version if auto", func(t *testing.T) {
+		cfg := config.ChainConfig{GRPCAddress: "unavailable:9090", Version: config.VersionAuto}
+		conn := &nodeConn{err: status.Error(codes.Unavailable, "connection refused")}
+
+		_, err := marketmap.ResolveVersion(context.Background(), zaptest.NewLogger(t), cfg, conn)
+		require.ErrorContains(t, err, "failed to detect chain version")
+	})
+
+	t.Run("keep the configured version", func(t *testing.T) {
+		cfg := config.ChainConfig{GRPCAddress: "mismatch:9090", Version: config.VersionConnect}
+
+		version, err := marketmap.ResolveVersion(context.Background(), zaptest.NewLogger(t), cfg,
+			&nodeConn{version: config.VersionSlinky})
+		require.NoError(t, err)
+		require.Equal(t, config.VersionConnect, version)
+
+		cfg.GRPCAddress = "configured-unavailable:9090"
+		version, err = marketmap.ResolveVersion(context.Background(), zaptest.NewLogger(t), cfg,
+			&nodeConn{err: status.Error(codes.Unavailable, "connection refused")})
+		require.NoError(t, err)
+		require.Equal(t, config.VersionConnect, version)
+	})
+}
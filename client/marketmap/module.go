@@ -18,6 +18,9 @@ var (
 	_ Client = &ConnectModuleMarketMapClient{}
 )
 
+// NewClientFromChainConfig creates the Client of the x/marketmap module of the chain. The node is probed to detect
+// which module it serves: the detected version is used if the configured version is auto, and a mismatch with the
+// configured version is logged otherwise.
 func NewClientFromChainConfig(logger *zap.Logger, cfg config.ChainConfig) (Client, error) {
 	cc, err := grpc.NewClient(cfg.GRPCAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -25,14 +28,19 @@ func NewClientFromChainConfig(logger *zap.Logger, cfg config.ChainConfig) (Clien
 		return nil, err
 	}
 
+	version, err := ResolveVersion(context.Background(), logger, cfg, cc)
+	if err != nil {
+		return nil, err
+	}
+
 	var client Client
-	switch cfg.Version {
+	switch version {
 	case config.VersionSlinky:
 		client = NewSlinkyModuleMarketMapClient(slinkymmtypes.NewQueryClient(cc), logger)
 	case config.VersionConnect:
 		client = NewConnectModuleMarketMapClient(mmtypes.NewQueryClient(cc), logger)
 	default:
-		return nil, fmt.Errorf("unsupported chain version: %s", cfg.Version)
+		return nil, fmt.Errorf("unsupported chain version: %s", version)
 	}
 
 	return client, nil
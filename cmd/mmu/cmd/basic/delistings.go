@@ -0,0 +1,129 @@
+package basic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/client/marketmap"
+	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/delistings"
+	"github.com/skip-mev/connect-mmu/lib/file"
+	"github.com/skip-mev/connect-mmu/oracle"
+	"github.com/skip-mev/connect-mmu/store/provider"
+	"github.com/skip-mev/connect-mmu/upsert"
+)
+
+func DelistingsCmd() *cobra.Command {
+	var flags delistingsCmdFlags
+
+	cmd := &cobra.Command{
+		Use:   "delistings",
+		Short: "detect on-chain provider configs that no longer match the latest provider data",
+		Long: "compares every provider config of the on-chain market map against the latest provider data and classifies " +
+			"each unmatched config as delisted, trading halted or below threshold. outputs a report and the upserts that " +
+			"remove the unmatched configs while keeping every market at its min provider count.",
+		Example: "mmu delistings --config config.json --provider-data provider-data.json --delistings-out delistings.json --upserts-out delisting-upserts.json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			logger := logging.Logger(ctx)
+
+			cfg, err := config.ReadConfig(flags.configPath)
+			if err != nil {
+				return fmt.Errorf("failed to read config at %s: %w", flags.configPath, err)
+			}
+
+			if cfg.Chain == nil {
+				return errors.New("chain configuration missing from mmu config")
+			}
+
+			report, upserts, err := DelistingsFromConfig(ctx, logger, cfg, flags.providerDataPath)
+			if err != nil {
+				return err
+			}
+
+			if err := file.WriteJSONToFile(report, flags.delistingsOutPath); err != nil {
+				return fmt.Errorf("failed to write delistings report: %w", err)
+			}
+			logger.Info("delistings report written to file", zap.String("file", flags.delistingsOutPath))
+
+			if err := file.WriteJSONToFile(upsert.Output{Upserts: upserts}, flags.upsertsOutPath); err != nil {
+				return fmt.Errorf("failed to write upserts: %w", err)
+			}
+			logger.Info("upserts written to file", zap.String("file", flags.upsertsOutPath))
+
+			return nil
+		},
+	}
+
+	delistingsCmdConfigureFlags(cmd, &flags)
+
+	return cmd
+}
+
+type delistingsCmdFlags struct {
+	configPath        string
+	providerDataPath  string
+	delistingsOutPath string
+	upsertsOutPath    string
+}
+
+func delistingsCmdConfigureFlags(cmd *cobra.Command, flags *delistingsCmdFlags) {
+	cmd.Flags().StringVar(&flags.configPath, ConfigPathFlag, ConfigPathDefault, ConfigPathDescription)
+	cmd.Flags().StringVar(&flags.providerDataPath, ProviderDataPathFlag, ProviderDataPathDefault, ProviderDataPathDescription)
+
+	cmd.Flags().StringVar(&flags.delistingsOutPath, DelistingsOutPathFlag, DelistingsOutPathDefault, DelistingsOutPathDescription)
+	cmd.Flags().StringVar(&flags.upsertsOutPath, UpsertsOutPathFlag, DelistingUpsertsOutPathDefault, UpsertsOutPathDescription)
+}
+
+// DelistingsFromConfig diffs the on-chain market map of the configured chain against the provider data at
+// providerPath. The thresholds of the generate config are used to detect markets below threshold, if configured.
+func DelistingsFromConfig(
+	ctx context.Context,
+	logger *zap.Logger,
+	cfg config.Config,
+	providerPath string,
+) (delistings.Report, []mmtypes.Market, error) {
+	providerStore, err := provider.NewMemoryStoreFromFile(providerPath)
+	if err != nil {
+		return delistings.Report{}, nil, err
+	}
+
+	mmClient, err := marketmap.NewClientFromChainConfig(logger, *cfg.Chain)
+	if err != nil {
+		return delistings.Report{}, nil, fmt.Errorf("failed to create MarketMap client from chain config: %w", err)
+	}
+
+	onChainMarketMap, err := mmClient.GetMarketMap(ctx)
+	if err != nil {
+		return delistings.Report{}, nil, fmt.Errorf("failed to get marketmap: %w", err)
+	}
+
+	logger.Info("successfully retrieved current market map", zap.Int("markets", len(onChainMarketMap.Markets)))
+
+	rows, err := providerStore.GetProviderMarkets(ctx, provider.GetFilteredProviderMarketsParams{
+		ProviderNames: oracle.ProvidersInUse(onChainMarketMap),
+	})
+	if err != nil {
+		return delistings.Report{}, nil, fmt.Errorf("failed to get provider markets: %w", err)
+	}
+
+	report, upserts := delistings.Detect(cfg.Generate, onChainMarketMap, rows)
+	if len(report.UnindexedProviders) > 0 {
+		logger.Warn("providers without provider data are not checked", zap.Strings("providers", report.UnindexedProviders))
+	}
+	for _, market := range report.FlaggedMarkets {
+		logger.Warn("market would drop below its min provider count - some unmatched providers are kept",
+			zap.String("market", market.Market), zap.Strings("kept", market.Kept))
+	}
+	logger.Info("detected delistings", zap.Int("findings", len(report.Findings)),
+		zap.Int("flagged markets", len(report.FlaggedMarkets)), zap.Int("upserts", len(upserts)))
+
+	return report, upserts, nil
+}
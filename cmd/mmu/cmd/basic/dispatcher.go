@@ -1,9 +1,14 @@
 package basic
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -11,10 +16,10 @@ import (
 	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
 	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/dispatcher"
-	"github.com/skip-mev/connect-mmu/dispatcher/transaction/generator"
 	"github.com/skip-mev/connect-mmu/lib/file"
 	"github.com/skip-mev/connect-mmu/signing"
 	"github.com/skip-mev/connect-mmu/signing/simulate"
+	"github.com/skip-mev/connect-mmu/upsert"
 )
 
 // DispatchCmd returns a command to DispatchCmd market upserts.
@@ -43,46 +48,42 @@ func DispatchCmd(registry *signing.Registry) *cobra.Command {
 				return errors.New("chain configuration missing from mmu config")
 			}
 
-			upserts, err := file.ReadJSONIntoFile[[]mmtypes.Market](flags.upsertsPath)
-			if err != nil {
-				return fmt.Errorf("failed to read upserts file: %w", err)
+			opts := DispatchOptions{
+				SimulateAddress:           flags.simulateAddress,
+				Simulate:                  flags.simulate,
+				Verify:                    flags.verify,
+				BatchPlanOutPath:          flags.batchPlanOutPath,
+				TxPlanOutPath:             flags.txPlanOutPath,
+				TxPlanSummaryOutPath:      flags.txPlanSummaryOutPath,
+				VerificationReportOutPath: flags.verificationReportOutPath,
 			}
 
-			msgs, err := generator.ConvertUpsertsToMessages(logger, cfg.Dispatch.TxConfig, cfg.Chain.Version, upserts)
-			if err != nil {
-				return fmt.Errorf("failed to convert upserts to messages: %w", err)
-			}
+			if flags.fromPlanPath != "" {
+				signer, dp, err := newDispatcher(logger, registry, *cfg.Dispatch, *cfg.Chain, opts.SimulateAddress)
+				if err != nil {
+					return err
+				}
+				defer closeDispatcher(logger, dp)
 
-			logger.Info("creating signer", zap.String("signer_type", cfg.Dispatch.SigningConfig.Type))
+				txPlan, err := submitFromPlan(cmd.Context(), logger, *cfg.Chain, signer, dp, flags.fromPlanPath)
+				if err != nil {
+					return err
+				}
 
-			signerConfig := cfg.Dispatch.SigningConfig
-			if flags.simulateAddress != "" {
-				signerConfig = config.SigningConfig{
-					Type:   simulate.TypeName,
-					Config: simulate.SigningAgentConfig{Address: flags.simulateAddress},
+				if !flags.verify {
+					return nil
 				}
-			}
 
-			signer, err := registry.CreateSigner(signerConfig, *cfg.Chain)
-			if err != nil {
-				return fmt.Errorf("failed to create signer: %w", err)
+				return VerifyUpserts(cmd.Context(), logger, *cfg.Chain, txPlan.Upserts, flags.verificationReportOutPath)
 			}
 
-			dp, err := dispatcher.New(*cfg.Dispatch, *cfg.Chain, signer, logger)
+			output, err := file.ReadJSONIntoFile[upsert.Output](flags.upsertsPath)
 			if err != nil {
-				return fmt.Errorf("failed to create dispatcher: %w", err)
-			}
-
-			txs, err := dp.GenerateTransactions(cmd.Context(), msgs)
-			if err != nil {
-				return err
-			}
-
-			if flags.simulate {
-				return nil
+				return fmt.Errorf("failed to read upserts file: %w", err)
 			}
 
-			return dp.SubmitTransactions(cmd.Context(), txs)
+			return DispatchUpserts(cmd.Context(), logger, cmd.OutOrStdout(), registry, *cfg.Dispatch, *cfg.Chain,
+				output.Upserts, opts)
 		},
 	}
 
@@ -92,10 +93,18 @@ func DispatchCmd(registry *signing.Registry) *cobra.Command {
 }
 
 type dispatchCmdFlags struct {
-	configPath      string
-	upsertsPath     string
-	simulate        bool
-	simulateAddress string
+	configPath       string
+	upsertsPath      string
+	simulate         bool
+	simulateAddress  string
+	batchPlanOutPath string
+
+	fromPlanPath         string
+	txPlanOutPath        string
+	txPlanSummaryOutPath string
+
+	verify                    bool
+	verificationReportOutPath string
 }
 
 func dispatchCmdConfigureFlags(cmd *cobra.Command, flags *dispatchCmdFlags) {
@@ -103,4 +112,199 @@ func dispatchCmdConfigureFlags(cmd *cobra.Command, flags *dispatchCmdFlags) {
 	cmd.Flags().StringVar(&flags.upsertsPath, UpsertsPathFlag, UpsertsPathDefault, UpsertsPathDescription)
 	cmd.Flags().BoolVar(&flags.simulate, SimulateFlag, SimulateDefault, SimulateDescription)
 	cmd.Flags().StringVar(&flags.simulateAddress, SimulateAddressFlag, SimulateAddressDefault, SimulateAddressDescription)
+	cmd.Flags().StringVar(&flags.batchPlanOutPath, BatchPlanOutPathFlag, BatchPlanOutPathDefault, BatchPlanOutPathDescription)
+	cmd.Flags().StringVar(&flags.fromPlanPath, FromPlanFlag, FromPlanDefault, FromPlanDescription)
+	cmd.Flags().StringVar(&flags.txPlanOutPath, TxPlanOutPathFlag, TxPlanOutPathDefault, TxPlanOutPathDescription)
+	cmd.Flags().StringVar(&flags.txPlanSummaryOutPath, TxPlanSummaryOutPathFlag, TxPlanSummaryOutPathDefault,
+		TxPlanSummaryOutPathDescription)
+	cmd.MarkFlagsMutuallyExclusive(FromPlanFlag, SimulateFlag)
+	cmd.MarkFlagsMutuallyExclusive(FromPlanFlag, SimulateAddressFlag)
+	cmd.Flags().BoolVar(&flags.verify, VerifyFlag, VerifyDefault, VerifyDescription)
+	cmd.Flags().StringVar(&flags.verificationReportOutPath, VerificationReportOutPathFlag,
+		VerificationReportOutPathDefault, VerificationReportOutPathDescription)
+}
+
+// signingAccount returns the account of the signer.
+func signingAccount(ctx context.Context, signer signing.SigningAgent) (*authtypes.BaseAccount, error) {
+	acc, err := signer.GetSigningAccount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing account: %w", err)
+	}
+
+	baseAcc, ok := acc.(*authtypes.BaseAccount)
+	if !ok {
+		return nil, fmt.Errorf("expected BaseAccount but got %T", acc)
+	}
+
+	return baseAcc, nil
+}
+
+// submitFromPlan submits the signed transactions of the transaction plan at path as they are, after checking that
+// they were signed by the signer for its current account number and sequence on the chain.
+func submitFromPlan(
+	ctx context.Context,
+	logger *zap.Logger,
+	chainCfg config.ChainConfig,
+	signer signing.SigningAgent,
+	dp *dispatcher.Dispatcher,
+	path string,
+) (dispatcher.TxPlan, error) {
+	txPlan, err := file.ReadJSONIntoFile[dispatcher.TxPlan](path)
+	if err != nil {
+		return dispatcher.TxPlan{}, fmt.Errorf("failed to read transaction plan: %w", err)
+	}
+
+	acc, err := signingAccount(ctx, signer)
+	if err != nil {
+		return dispatcher.TxPlan{}, err
+	}
+
+	if err := txPlan.Validate(chainCfg, acc.Address, acc.AccountNumber, acc.Sequence); err != nil {
+		return dispatcher.TxPlan{}, fmt.Errorf("transaction plan cannot be submitted: %w", err)
+	}
+
+	logger.Info("submitting transaction plan", zap.String("path", path),
+		zap.Int("transactions", len(txPlan.Transactions)), zap.Uint64("sequence", acc.Sequence))
+
+	if err := dp.SubmitSigned(ctx, txPlan.Txs()); err != nil {
+		return dispatcher.TxPlan{}, err
+	}
+
+	return txPlan, nil
+}
+
+// writeTxPlan writes the transaction plan to planPath, and its summary to summaryPath and w.
+func writeTxPlan(w io.Writer, logger *zap.Logger, txPlan dispatcher.TxPlan, planPath, summaryPath string) error {
+	if err := file.WriteJSONToFile(txPlan, planPath); err != nil {
+		return fmt.Errorf("failed to write transaction plan: %w", err)
+	}
+	logger.Info("wrote transaction plan", zap.String("path", planPath),
+		zap.Int("transactions", len(txPlan.Transactions)))
+
+	summary := &strings.Builder{}
+	if err := dispatcher.WriteTxPlanText(summary, txPlan); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(summaryPath, []byte(summary.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write transaction plan summary: %w", err)
+	}
+	logger.Info("wrote transaction plan summary", zap.String("path", summaryPath))
+
+	_, err := io.WriteString(w, summary.String())
+	return err
+}
+
+// DispatchOptions are the options of DispatchUpserts.
+type DispatchOptions struct {
+	// SimulateAddress replaces the configured signer with a simulation signer for the address, if set.
+	SimulateAddress string
+	// Simulate writes the transaction plan instead of submitting the transactions.
+	Simulate bool
+	// Verify verifies the on-chain market map against the upserts after they are submitted.
+	Verify bool
+
+	BatchPlanOutPath          string
+	TxPlanOutPath             string
+	TxPlanSummaryOutPath      string
+	VerificationReportOutPath string
+}
+
+// DispatchUpserts plans the upserts into batches, then signs and submits a transaction for each batch to the chain.
+// In simulate mode, the transaction plan is written and its summary is printed to w instead.
+func DispatchUpserts(
+	ctx context.Context,
+	logger *zap.Logger,
+	w io.Writer,
+	registry *signing.Registry,
+	dispatchCfg config.DispatchConfig,
+	chainCfg config.ChainConfig,
+	upserts []mmtypes.Market,
+	opts DispatchOptions,
+) error {
+	signer, dp, err := newDispatcher(logger, registry, dispatchCfg, chainCfg, opts.SimulateAddress)
+	if err != nil {
+		return err
+	}
+	defer closeDispatcher(logger, dp)
+
+	plan, err := dp.PlanBatches(ctx, upserts)
+	if err != nil {
+		return err
+	}
+
+	if err := file.WriteJSONToFile(plan, opts.BatchPlanOutPath); err != nil {
+		return fmt.Errorf("failed to write batch plan: %w", err)
+	}
+	logger.Info("wrote batch plan", zap.String("path", opts.BatchPlanOutPath),
+		zap.Int("batches", len(plan.Batches)))
+
+	// the sequence of the signer before signing, recorded in the tx plan of transactions that are not signed
+	acc, err := signingAccount(ctx, signer)
+	if err != nil {
+		return err
+	}
+
+	txs, err := dp.GenerateTransactionsFromPlan(ctx, plan)
+	if err != nil {
+		return err
+	}
+
+	if opts.Simulate {
+		txPlan, err := dispatcher.NewTxPlan(chainCfg, acc.Address, acc.Sequence, plan, txs)
+		if err != nil {
+			return fmt.Errorf("failed to create transaction plan: %w", err)
+		}
+
+		return writeTxPlan(w, logger, txPlan, opts.TxPlanOutPath, opts.TxPlanSummaryOutPath)
+	}
+
+	if err := dp.SubmitPlan(ctx, plan, txs); err != nil {
+		return err
+	}
+
+	if !opts.Verify {
+		return nil
+	}
+
+	return VerifyUpserts(ctx, logger, chainCfg, upserts, opts.VerificationReportOutPath)
+}
+
+// newDispatcher creates the signer of the dispatch config, or a simulation signer of the simulateAddress if set, and
+// a dispatcher that signs with it.
+func newDispatcher(
+	logger *zap.Logger,
+	registry *signing.Registry,
+	dispatchCfg config.DispatchConfig,
+	chainCfg config.ChainConfig,
+	simulateAddress string,
+) (signing.SigningAgent, *dispatcher.Dispatcher, error) {
+	logger.Info("creating signer", zap.String("signer_type", dispatchCfg.SigningConfig.Type))
+
+	signerConfig := dispatchCfg.SigningConfig
+	if simulateAddress != "" {
+		signerConfig = config.SigningConfig{
+			Type:   simulate.TypeName,
+			Config: simulate.SigningAgentConfig{Address: simulateAddress},
+		}
+	}
+
+	signer, err := registry.CreateSigner(signerConfig, chainCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create signer: %w", err)
+	}
+
+	dp, err := dispatcher.New(dispatchCfg, chainCfg, signer, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create dispatcher: %w", err)
+	}
+
+	return signer, dp, nil
+}
+
+// closeDispatcher closes the dispatcher once a dispatch finishes, logging any error.
+func closeDispatcher(logger *zap.Logger, dp *dispatcher.Dispatcher) {
+	if err := dp.Close(); err != nil {
+		logger.Warn("failed to close dispatcher", zap.Error(err))
+	}
 }
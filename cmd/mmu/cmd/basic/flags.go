@@ -42,6 +42,9 @@ const (
 	WarnOnInvalidMarketMapDefault     = false
 	WarnOnInvalidMarketMapDescription = "warn then the on-chain market map is invalid instead of failing"
 
+	ValidationReportsFlag        = "validation-reports"
+	ValidationReportsDescription = "paths to validation reports of previous runs, oldest first, used to enable markets if auto_enable is configured"
+
 	// dispatch
 	UpsertsPathFlag        = "upserts"
 	UpsertsPathDefault     = "./tmp/upserts.json"
@@ -54,6 +57,14 @@ const (
 	SimulateAddressFlag        = "simulate-address"
 	SimulateAddressDefault     = ""
 	SimulateAddressDescription = "bech32 encoded address to simulate transaction without submitting"
+
+	FromPlanFlag        = "from-plan"
+	FromPlanDefault     = ""
+	FromPlanDescription = "path to a transaction plan written with --simulate, whose signed transactions are submitted as they are"
+
+	VerifyFlag        = "verify"
+	VerifyDefault     = true
+	VerifyDescription = "verify that the on-chain market map matches the upserts after they are submitted"
 )
 
 // Outputs
@@ -63,6 +74,10 @@ const (
 	ProviderDataOutPathDefault     = ProviderDataPathDefault
 	ProviderDataOutPathDescription = "path to output indexed markets and providers"
 
+	AssetAliasSuggestionsOutPathFlag        = "asset-alias-suggestions-out"
+	AssetAliasSuggestionsOutPathDefault     = ""
+	AssetAliasSuggestionsOutPathDescription = "path to output asset aliases suggested from CoinMarketCap data that are not yet configured"
+
 	// generate
 	MarketMapOutPathGeneratedFlag         = "generated-market-map-out"
 	MarketMapOutPathGeneratedDefault      = MarketMapGeneratedDefault
@@ -72,6 +87,10 @@ const (
 	MarketMapRemovalsOutPathDefault     = "./tmp/generated-market-map-removals.json"
 	MarketMapRemovalsOutPathDescription = "path to output markets removed from market map"
 
+	MarketMapRemovalsSummaryOutPathFlag        = "generated-market-map-removals-summary-out"
+	MarketMapRemovalsSummaryOutPathDefault     = "./tmp/generated-market-map-removals-summary.json"
+	MarketMapRemovalsSummaryOutPathDescription = "path to output a summary of removals grouped by reason code and provider"
+
 	// override
 	MarketMapOutPathOverrideFlag        = "override-market-map-out"
 	MarketMapOutPathOverrideDefault     = MarketMapOverrideDefault
@@ -81,4 +100,29 @@ const (
 	UpsertsOutPathFlag        = "upserts-out"
 	UpsertsOutPathDefault     = UpsertsPathDefault
 	UpsertsOutPathDescription = "path to output markets to be updated or inserted"
+
+	// delistings
+	DelistingsOutPathFlag        = "delistings-out"
+	DelistingsOutPathDefault     = "./tmp/delistings.json"
+	DelistingsOutPathDescription = "path to output the report of on-chain provider configs that no longer match the provider data"
+
+	DelistingUpsertsOutPathDefault = "./tmp/delisting-upserts.json"
+
+	// dispatch
+	BatchPlanOutPathFlag        = "batch-plan-out"
+	BatchPlanOutPathDefault     = "./tmp/batch-plan.json"
+	BatchPlanOutPathDescription = "path to output the markets, gas and fee of each transaction before signing"
+
+	TxPlanOutPathFlag        = "tx-plan-out"
+	TxPlanOutPathDefault     = "./tmp/tx-plan.json"
+	TxPlanOutPathDescription = "path to output the messages, gas, fee, sequence and bytes of each transaction in simulate mode"
+
+	TxPlanSummaryOutPathFlag        = "tx-plan-summary-out"
+	TxPlanSummaryOutPathDefault     = "./tmp/tx-plan-summary.txt"
+	TxPlanSummaryOutPathDescription = "path to output a human-readable summary of the transaction plan in simulate mode"
+
+	// verify
+	VerificationReportOutPathFlag        = "verification-report-out"
+	VerificationReportOutPathDefault     = "./tmp/verification-report.json"
+	VerificationReportOutPathDescription = "path to output the field by field comparison of the upserts against the on-chain market map"
 )
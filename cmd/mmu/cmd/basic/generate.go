@@ -14,11 +14,12 @@ import (
 	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/diffs"
 	"github.com/skip-mev/connect-mmu/generator"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
 	"github.com/skip-mev/connect-mmu/generator/types"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
-func GenerateCmd() *cobra.Command {
+func GenerateCmd(transforms *transformer.Registry) *cobra.Command {
 	var flags generateCmdFlags
 
 	cmd := &cobra.Command{
@@ -43,7 +44,7 @@ func GenerateCmd() *cobra.Command {
 
 			logger.Info("successfully read config", zap.String("path", flags.configPath))
 
-			mm, removalReasons, err := GenerateFromConfig(ctx, logger, *cfg.Generate, flags.providerDataPath)
+			mm, removalReasons, err := GenerateFromConfig(ctx, logger, transforms, *cfg.Generate, flags.providerDataPath)
 			if err != nil {
 				logger.Error("failed to generate marketmap", zap.Error(err))
 				return err
@@ -64,6 +65,13 @@ func GenerateCmd() *cobra.Command {
 				}
 			}
 
+			if flags.marketMapRemovalsSummaryOutPath != "" {
+				logger.Info("writing removal summary", zap.String("file", flags.marketMapRemovalsSummaryOutPath))
+				if err := diffs.WriteRemovalSummaryToFile(flags.marketMapRemovalsSummaryOutPath, removalReasons); err != nil {
+					return fmt.Errorf("failed to write removal summary to file: %w", err)
+				}
+			}
+
 			return nil
 		},
 	}
@@ -78,6 +86,8 @@ type generateCmdFlags struct {
 	providerDataPath         string
 	marketMapOutPath         string
 	marketMapRemovalsOutPath string
+
+	marketMapRemovalsSummaryOutPath string
 }
 
 func generateCmdConfigureFlags(cmd *cobra.Command, flags *generateCmdFlags) {
@@ -86,11 +96,13 @@ func generateCmdConfigureFlags(cmd *cobra.Command, flags *generateCmdFlags) {
 
 	cmd.Flags().StringVar(&flags.marketMapOutPath, MarketMapOutPathGeneratedFlag, MarketMapOutPathGeneratedDefault, MarketMapOutPathGenderatedDescription)
 	cmd.Flags().StringVar(&flags.marketMapRemovalsOutPath, MarketMapRemovalsOutPathFlag, MarketMapRemovalsOutPathDefault, MarketMapRemovalsOutPathDescription)
+	cmd.Flags().StringVar(&flags.marketMapRemovalsSummaryOutPath, MarketMapRemovalsSummaryOutPathFlag, MarketMapRemovalsSummaryOutPathDefault, MarketMapRemovalsSummaryOutPathDescription)
 }
 
 func GenerateFromConfig(
 	ctx context.Context,
 	logger *zap.Logger,
+	transforms *transformer.Registry,
 	cfg config.GenerateConfig,
 	providerPath string,
 ) (mmtypes.MarketMap, types.RemovalReasons, error) {
@@ -99,7 +111,11 @@ func GenerateFromConfig(
 		return mmtypes.MarketMap{}, nil, err
 	}
 
-	g := generator.New(logger, providerStore)
+	g, err := generator.NewFromConfig(logger, providerStore, transforms, cfg)
+	if err != nil {
+		return mmtypes.MarketMap{}, nil, err
+	}
+
 	mm, removalReasons, err := g.GenerateMarketMap(ctx, cfg)
 	if err != nil {
 		return mmtypes.MarketMap{}, nil, err
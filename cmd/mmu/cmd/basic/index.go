@@ -5,9 +5,11 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
 	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/file"
 	indexer "github.com/skip-mev/connect-mmu/market-indexer"
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
@@ -52,6 +54,22 @@ func IndexCmd() *cobra.Command {
 				}
 			}
 
+			if flags.assetAliasSuggestionsOutPath != "" {
+				var existing []config.AssetAliasConfig
+				if cfg.Generate != nil {
+					existing = cfg.Generate.AssetAliases
+					if existing == nil {
+						existing = config.DefaultAssetAliases()
+					}
+				}
+
+				suggestions := idx.AssetAliasSuggestions(existing)
+				logger.Info("writing asset alias suggestions", zap.Int("count", len(suggestions)))
+				if err := file.WriteJSONToFile(suggestions, flags.assetAliasSuggestionsOutPath); err != nil {
+					return fmt.Errorf("failed to write asset alias suggestions: %w", err)
+				}
+			}
+
 			return nil
 		},
 	}
@@ -62,12 +80,16 @@ func IndexCmd() *cobra.Command {
 }
 
 type indexCmdFlags struct {
-	configPath          string
-	providerDataOutPath string
+	configPath                   string
+	providerDataOutPath          string
+	assetAliasSuggestionsOutPath string
 }
 
 func indexCmdConfigureFlags(cmd *cobra.Command, flags *indexCmdFlags) {
 	cmd.Flags().StringVar(&flags.configPath, ConfigPathFlag, ConfigPathDefault, ConfigPathDescription)
 
 	cmd.Flags().StringVar(&flags.providerDataOutPath, ProviderDataOutPathFlag, ProviderDataOutPathDefault, ProviderDataOutPathDescription)
+
+	cmd.Flags().StringVar(&flags.assetAliasSuggestionsOutPath, AssetAliasSuggestionsOutPathFlag, AssetAliasSuggestionsOutPathDefault,
+		AssetAliasSuggestionsOutPathDescription)
 }
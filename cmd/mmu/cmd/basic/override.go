@@ -10,7 +10,6 @@ import (
 
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 
-	"github.com/skip-mev/connect-mmu/client/dydx"
 	marketmapclient "github.com/skip-mev/connect-mmu/client/marketmap"
 	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
 	"github.com/skip-mev/connect-mmu/config"
@@ -18,7 +17,7 @@ import (
 	"github.com/skip-mev/connect-mmu/override/update"
 )
 
-func OverrideCmd() *cobra.Command {
+func OverrideCmd(overrides *override.Registry) *cobra.Command {
 	var flags overrideCmdFlags
 
 	cmd := &cobra.Command{
@@ -31,7 +30,7 @@ func OverrideCmd() *cobra.Command {
 
 			logger := logging.Logger(ctx)
 
-			cfg, err := config.ReadConfig(flags.configPath)
+			cfg, err := config.ReadConfigWithChainTypes(flags.configPath, overrides)
 			if err != nil {
 				return fmt.Errorf("failed to read chain config file: %w", err)
 			}
@@ -53,6 +52,7 @@ func OverrideCmd() *cobra.Command {
 			overriddenMarketMap, err := OverrideMarketsFromConfig(
 				ctx,
 				logger,
+				overrides,
 				*cfg.Chain,
 				fileMarketMap,
 				flags.updateEnabled,
@@ -104,9 +104,12 @@ func overrideCmdConfigureFlags(cmd *cobra.Command, flags *overrideCmdFlags) {
 	cmd.Flags().StringVar(&flags.marketMapOutPath, MarketMapOutPathOverrideFlag, MarketMapOutPathOverrideDefault, MarketMapOutPathOverrideDescription)
 }
 
+// OverrideMarketsFromConfig overrides the generated market map with the on-chain market map of the chain, using the
+// MarketMapOverride registered in overrides for the chain type.
 func OverrideMarketsFromConfig(
 	ctx context.Context,
 	logger *zap.Logger,
+	overrides *override.Registry,
 	cfg config.ChainConfig,
 	generated mmtypes.MarketMap,
 	updateEnabled, overwriteProviders, existingOnly, disableDeFiMarketMerging bool,
@@ -127,13 +130,9 @@ func OverrideMarketsFromConfig(
 	logger.Info("successfully got on chain marketmap", zap.Int("num markets", len(onChainMarketMap.Markets)))
 
 	// create override method based on config
-	marketOverride := override.NewCoreOverride()
-	if cfg.DYDX {
-		marketOverride, err = override.NewDyDxOverride(dydx.NewHTTPClient(cfg.RESTAddress))
-		if err != nil {
-			logger.Error("failed to create dydx override", zap.Error(err))
-			return mmtypes.MarketMap{}, err
-		}
+	marketOverride, err := MarketMapOverrideFromConfig(logger, overrides, cfg)
+	if err != nil {
+		return mmtypes.MarketMap{}, err
 	}
 
 	overriddenMarketMap, err := override.Override(
@@ -156,3 +155,18 @@ func OverrideMarketsFromConfig(
 
 	return overriddenMarketMap, nil
 }
+
+// MarketMapOverrideFromConfig creates the MarketMapOverride for the chain described by the given config, from the
+// override registered in overrides for its chain type.
+func MarketMapOverrideFromConfig(
+	logger *zap.Logger,
+	overrides *override.Registry,
+	cfg config.ChainConfig,
+) (override.MarketMapOverride, error) {
+	marketOverride, err := overrides.CreateOverride(cfg)
+	if err != nil {
+		logger.Error("failed to create override", zap.String("type", cfg.ChainType()), zap.Error(err))
+		return nil, err
+	}
+	return marketOverride, nil
+}
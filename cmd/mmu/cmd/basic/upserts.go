@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -14,7 +15,10 @@ import (
 	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
 	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/lib/file"
+	"github.com/skip-mev/connect-mmu/lib/tickerformat"
 	"github.com/skip-mev/connect-mmu/upsert"
+	"github.com/skip-mev/connect-mmu/upsert/autoenable"
+	validatortypes "github.com/skip-mev/connect-mmu/validator/types"
 )
 
 func UpsertsCmd() *cobra.Command {
@@ -48,6 +52,16 @@ func UpsertsCmd() *cobra.Command {
 				return errors.New("chain configuration missing from mmu config")
 			}
 
+			generatedMM, decisions, err := AutoEnableFromReports(
+				logger,
+				*cfg.Upsert,
+				generatedMM,
+				flags.validationReportPaths,
+			)
+			if err != nil {
+				return err
+			}
+
 			upserts, err := UpsertsFromConfigs(
 				cmd.Context(),
 				logger,
@@ -60,7 +74,7 @@ func UpsertsCmd() *cobra.Command {
 				return fmt.Errorf("failed to read upsert config at %s: %w", flags.configPath, err)
 			}
 
-			err = file.WriteJSONToFile(upserts, flags.upsertsOutPath)
+			err = file.WriteJSONToFile(upsert.Output{Upserts: upserts, Decisions: decisions}, flags.upsertsOutPath)
 			if err != nil {
 				return fmt.Errorf("failed to write upserts: %w", err)
 			}
@@ -80,16 +94,58 @@ type upsertsCmdFlags struct {
 	marketMapPath          string
 	upsertsOutPath         string
 	warnOnInvalidMarketMap bool
+
+	validationReportPaths []string
 }
 
 func upsertsCmdConfigureFlags(cmd *cobra.Command, flags *upsertsCmdFlags) {
 	cmd.Flags().StringVar(&flags.configPath, ConfigPathFlag, ConfigPathDefault, ConfigPathDescription)
 	cmd.Flags().StringVar(&flags.marketMapPath, MarketMapOverrideFlag, MarketMapOverrideDefault, MarketMapOverrideDescription)
 	cmd.Flags().BoolVar(&flags.warnOnInvalidMarketMap, WarnOnInvalidMarketMapFlag, WarnOnInvalidMarketMapDefault, WarnOnInvalidMarketMapDescription)
+	cmd.Flags().StringSliceVar(&flags.validationReportPaths, ValidationReportsFlag, nil, ValidationReportsDescription)
 
 	cmd.Flags().StringVar(&flags.upsertsOutPath, UpsertsOutPathFlag, UpsertsOutPathDefault, UpsertsOutPathDescription)
 }
 
+// AutoEnableFromReports enables markets of the market map and removes failing providers based on the validation
+// reports at reportPaths, ordered oldest first, if auto enabling is configured. Each decision is logged and returned,
+// so it can be written to the upserts output.
+func AutoEnableFromReports(
+	logger *zap.Logger,
+	cfg config.UpsertConfig,
+	mm mmtypes.MarketMap,
+	reportPaths []string,
+) (mmtypes.MarketMap, []autoenable.Decision, error) {
+	if cfg.AutoEnable == nil {
+		if len(reportPaths) > 0 {
+			logger.Warn("ignoring validation reports - auto_enable is not configured")
+		}
+		return mm, nil, nil
+	}
+
+	if len(reportPaths) == 0 {
+		logger.Warn("auto_enable is configured but no validation reports were passed - markets are left as generated")
+		return mm, nil, nil
+	}
+
+	runs := make([]validatortypes.Reports, 0, len(reportPaths))
+	for _, path := range reportPaths {
+		reports, err := file.ReadJSONIntoFile[validatortypes.Reports](path)
+		if err != nil {
+			return mmtypes.MarketMap{}, nil, fmt.Errorf("failed to read validation reports at %s: %w", path, err)
+		}
+		runs = append(runs, reports)
+	}
+
+	mm, decisions := autoenable.Apply(*cfg.AutoEnable, mm, runs)
+	for _, decision := range decisions {
+		logger.Info("auto enable decision", zap.String("market", decision.Market), zap.String("provider", decision.Provider),
+			zap.String("action", decision.Action), zap.String("reason", decision.Reason))
+	}
+
+	return mm, decisions, nil
+}
+
 func UpsertsFromConfigs(
 	ctx context.Context,
 	logger *zap.Logger,
@@ -111,6 +167,22 @@ func UpsertsFromConfigs(
 		}
 	}
 
+	if violations := tickerformat.ValidateMarketMap(generatedMarketMap); len(violations) > 0 {
+		if warnOnInvalidMarketMap {
+			for _, violation := range violations {
+				logger.Warn("invalid off-chain ticker in generated marketmap", zap.String("market", violation.Market),
+					zap.String("provider", violation.Provider), zap.String("reason", violation.Reason))
+			}
+		} else {
+			reasons := make([]string, 0, len(violations))
+			for _, violation := range violations {
+				reasons = append(reasons, violation.String())
+			}
+			return nil, fmt.Errorf("generated marketmap has %d invalid off-chain tickers:\n%s",
+				len(violations), strings.Join(reasons, "\n"))
+		}
+	}
+
 	onChainMarketMap, err := mmClient.GetMarketMap(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get marketmap: %w", err)
@@ -0,0 +1,103 @@
+package basic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/client/marketmap"
+	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/file"
+	"github.com/skip-mev/connect-mmu/upsert"
+	"github.com/skip-mev/connect-mmu/verify"
+)
+
+func VerifyCmd() *cobra.Command {
+	var flags verifyCmdFlags
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "verify that the on-chain market map matches the upserts",
+		Long: "queries the on-chain market map and compares every upserted market field by field against it. outputs a " +
+			"pass/fail report and exits with a non-zero code if any market does not match.",
+		Example: "mmu verify --config config.json --upserts upserts.json --verification-report-out verification-report.json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			logger := logging.Logger(ctx)
+
+			cfg, err := config.ReadConfig(flags.configPath)
+			if err != nil {
+				return fmt.Errorf("failed to read config at %s: %w", flags.configPath, err)
+			}
+
+			if cfg.Chain == nil {
+				return errors.New("chain configuration missing from mmu config")
+			}
+
+			output, err := file.ReadJSONIntoFile[upsert.Output](flags.upsertsPath)
+			if err != nil {
+				return fmt.Errorf("failed to read upserts file: %w", err)
+			}
+
+			return VerifyUpserts(ctx, logger, *cfg.Chain, output.Upserts, flags.verificationReportOutPath)
+		},
+	}
+
+	verifyCmdConfigureFlags(cmd, &flags)
+
+	return cmd
+}
+
+type verifyCmdFlags struct {
+	configPath                string
+	upsertsPath               string
+	verificationReportOutPath string
+}
+
+func verifyCmdConfigureFlags(cmd *cobra.Command, flags *verifyCmdFlags) {
+	cmd.Flags().StringVar(&flags.configPath, ConfigPathFlag, ConfigPathDefault, ConfigPathDescription)
+	cmd.Flags().StringVar(&flags.upsertsPath, UpsertsPathFlag, UpsertsPathDefault, UpsertsPathDescription)
+	cmd.Flags().StringVar(&flags.verificationReportOutPath, VerificationReportOutPathFlag,
+		VerificationReportOutPathDefault, VerificationReportOutPathDescription)
+}
+
+// VerifyUpserts compares the upserts against the on-chain market map of the chain and writes the report to reportPath.
+// It returns an error if any upserted market does not match.
+func VerifyUpserts(
+	ctx context.Context,
+	logger *zap.Logger,
+	chainCfg config.ChainConfig,
+	upserts []mmtypes.Market,
+	reportPath string,
+) error {
+	mmClient, err := marketmap.NewClientFromChainConfig(logger, chainCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create MarketMap client from chain config: %w", err)
+	}
+
+	onChainMarketMap, err := mmClient.GetMarketMap(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get marketmap: %w", err)
+	}
+
+	report := verify.Verify(upserts, onChainMarketMap)
+	if err := file.WriteJSONToFile(report, reportPath); err != nil {
+		return fmt.Errorf("failed to write verification report: %w", err)
+	}
+	logger.Info("verification report written to file", zap.String("file", reportPath))
+
+	if !report.Passed {
+		logger.Error("on-chain market map does not match the upserts", zap.Int("checked", report.Checked),
+			zap.Int("failed", report.Failed), zap.Strings("markets", report.FailedMarkets()))
+		return fmt.Errorf("verification failed for %d of %d markets", report.Failed, report.Checked)
+	}
+
+	logger.Info("on-chain market map matches the upserts", zap.Int("checked", report.Checked))
+	return nil
+}
@@ -4,4 +4,12 @@ const (
 	WriteIntermediateFlag        = "write-intermediate"
 	WriteIntermediateDefault     = false
 	WriteIntermediateDescription = "should write all intermediate stage output files"
+
+	OutDirFlag        = "out-dir"
+	OutDirDefault     = "./tmp/chains"
+	OutDirDescription = "directory to output the generated market map to, and the output of each target to a subdirectory named after the target"
+
+	DispatchFlag        = "dispatch"
+	DispatchDefault     = false
+	DispatchDescription = "dispatch the upserts of each target after they are generated"
 )
@@ -14,10 +14,13 @@ import (
 	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
 	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/diffs"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
 	"github.com/skip-mev/connect-mmu/lib/file"
+	"github.com/skip-mev/connect-mmu/override"
+	"github.com/skip-mev/connect-mmu/upsert"
 )
 
-func GenerateUpsertsCmd() *cobra.Command {
+func GenerateUpsertsCmd(overrides *override.Registry, transforms *transformer.Registry) *cobra.Command {
 	var flags generateUpsertsFlags
 
 	cmd := &cobra.Command{
@@ -26,7 +29,7 @@ func GenerateUpsertsCmd() *cobra.Command {
 		Example: "mmu generate-upserts --config config.json --provider-data provider-data.json --upserts-out upserts.json",
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return generateUpserts(cmd.Context(), flags)
+			return generateUpserts(cmd.Context(), overrides, transforms, flags)
 		},
 	}
 
@@ -43,13 +46,15 @@ type generateUpsertsFlags struct {
 	existingOnly             bool
 	disableDeFiMarketMerging bool
 
-	generatedMarketMapOutPath         string
-	generatedMarketMapRemovalsOutPath string
-	overrideMarketMapOutPath          string
-	upsertsOutPath                    string
+	generatedMarketMapOutPath                string
+	generatedMarketMapRemovalsOutPath        string
+	generatedMarketMapRemovalsSummaryOutPath string
+	overrideMarketMapOutPath                 string
+	upsertsOutPath                           string
 
 	writeIntermediate      bool
 	warnOnInvalidMarketMap bool
+	validationReportPaths  []string
 }
 
 func generateUpsertsConfigureFlags(cmd *cobra.Command, flags *generateUpsertsFlags) {
@@ -59,21 +64,28 @@ func generateUpsertsConfigureFlags(cmd *cobra.Command, flags *generateUpsertsFla
 	cmd.Flags().BoolVar(&flags.overwriteProviders, basic.OverwriteProvidersFlag, basic.OverwriteProvidersDefault, basic.OverwriteProvidersDescription)
 	cmd.Flags().BoolVar(&flags.existingOnly, basic.ExistingOnlyFlag, basic.ExistingOnlyDefault, basic.ExistingOnlyDescription)
 	cmd.Flags().BoolVar(&flags.warnOnInvalidMarketMap, basic.WarnOnInvalidMarketMapFlag, basic.WarnOnInvalidMarketMapDefault, basic.WarnOnInvalidMarketMapDescription)
+	cmd.Flags().StringSliceVar(&flags.validationReportPaths, basic.ValidationReportsFlag, nil, basic.ValidationReportsDescription)
 	cmd.Flags().BoolVar(&flags.disableDeFiMarketMerging, basic.DisableDeFiMarketMerging, basic.DisableDeFiMarketMergingDefault, basic.DisableDeFiMarketMergingDescription)
 
 	cmd.Flags().StringVar(&flags.generatedMarketMapOutPath, basic.MarketMapOutPathGeneratedFlag, basic.MarketMapOutPathGeneratedDefault, basic.MarketMapOutPathGenderatedDescription)
 	cmd.Flags().StringVar(&flags.generatedMarketMapRemovalsOutPath, basic.MarketMapRemovalsOutPathFlag, basic.MarketMapRemovalsOutPathDefault, basic.MarketMapRemovalsOutPathDescription)
+	cmd.Flags().StringVar(&flags.generatedMarketMapRemovalsSummaryOutPath, basic.MarketMapRemovalsSummaryOutPathFlag, basic.MarketMapRemovalsSummaryOutPathDefault, basic.MarketMapRemovalsSummaryOutPathDescription)
 	cmd.Flags().StringVar(&flags.overrideMarketMapOutPath, basic.MarketMapOutPathOverrideFlag, basic.MarketMapOutPathOverrideDefault, basic.MarketMapOutPathOverrideDescription)
 	cmd.Flags().StringVar(&flags.upsertsOutPath, basic.UpsertsOutPathFlag, basic.UpsertsOutPathDefault, basic.UpsertsOutPathDescription)
 
 	cmd.Flags().BoolVar(&flags.writeIntermediate, WriteIntermediateFlag, WriteIntermediateDefault, WriteIntermediateDescription)
 }
 
-func generateUpserts(ctx context.Context, flags generateUpsertsFlags) error {
+func generateUpserts(
+	ctx context.Context,
+	overrides *override.Registry,
+	transforms *transformer.Registry,
+	flags generateUpsertsFlags,
+) error {
 	logger := logging.Logger(ctx)
 	defer logger.Sync()
 
-	cfg, err := config.ReadConfig(flags.configPath)
+	cfg, err := config.ReadConfigWithChainTypes(flags.configPath, overrides)
 	if err != nil {
 		return fmt.Errorf("failed to read config at %s: %w", flags.configPath, err)
 	}
@@ -85,7 +97,7 @@ func generateUpserts(ctx context.Context, flags generateUpsertsFlags) error {
 		return errors.New("generate configuration missing from mmu config")
 	}
 
-	generated, removalReasons, err := basic.GenerateFromConfig(ctx, logger, *cfg.Generate, flags.providerDataPath)
+	generated, removalReasons, err := basic.GenerateFromConfig(ctx, logger, transforms, *cfg.Generate, flags.providerDataPath)
 	if err != nil {
 		logger.Error("failed to generate marketmap", zap.Error(err))
 		return err
@@ -101,6 +113,11 @@ func generateUpserts(ctx context.Context, flags generateUpsertsFlags) error {
 		if err := diffs.WriteRemovalReasonsToFile(flags.generatedMarketMapRemovalsOutPath, removalReasons); err != nil {
 			return fmt.Errorf("failed to write removals to file: %w", err)
 		}
+
+		logger.Info("writing removal summary", zap.String("file", flags.generatedMarketMapRemovalsSummaryOutPath))
+		if err := diffs.WriteRemovalSummaryToFile(flags.generatedMarketMapRemovalsSummaryOutPath, removalReasons); err != nil {
+			return fmt.Errorf("failed to write removal summary to file: %w", err)
+		}
 	}
 
 	// OVERRIDE
@@ -111,6 +128,7 @@ func generateUpserts(ctx context.Context, flags generateUpsertsFlags) error {
 	overriddenMarketMap, err := basic.OverrideMarketsFromConfig(
 		ctx,
 		logger,
+		overrides,
 		*cfg.Chain,
 		generated,
 		flags.updateEnabled,
@@ -138,6 +156,16 @@ func generateUpserts(ctx context.Context, flags generateUpsertsFlags) error {
 		return errors.New("upsert configuration missing from mmu config")
 	}
 
+	overriddenMarketMap, decisions, err := basic.AutoEnableFromReports(
+		logger,
+		*cfg.Upsert,
+		overriddenMarketMap,
+		flags.validationReportPaths,
+	)
+	if err != nil {
+		return err
+	}
+
 	upserts, err := basic.UpsertsFromConfigs(
 		ctx,
 		logger,
@@ -150,7 +178,7 @@ func generateUpserts(ctx context.Context, flags generateUpsertsFlags) error {
 		return err
 	}
 
-	err = file.WriteJSONToFile(upserts, flags.upsertsOutPath)
+	err = file.WriteJSONToFile(upsert.Output{Upserts: upserts, Decisions: decisions}, flags.upsertsOutPath)
 	if err != nil {
 		return fmt.Errorf("failed to write upserts: %w", err)
 	}
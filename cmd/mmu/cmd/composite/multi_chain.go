@@ -0,0 +1,322 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd/basic"
+	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/diffs"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
+	"github.com/skip-mev/connect-mmu/lib/file"
+	"github.com/skip-mev/connect-mmu/override"
+	"github.com/skip-mev/connect-mmu/signing"
+	"github.com/skip-mev/connect-mmu/upsert"
+)
+
+// output files of the multi-chain command. The generated market map is written to the output directory, and the
+// files of each target to the directory of the target.
+const (
+	generatedMarketMapFile       = "generated-market-map.json"
+	generatedRemovalsFile        = "generated-market-map-removals.json"
+	generatedRemovalsSummaryFile = "generated-market-map-removals-summary.json"
+	targetSummaryFile            = "targets.json"
+	overrideMarketMapFile        = "override-market-map.json"
+	upsertsFile                  = "upserts.json"
+	batchPlanFile                = "batch-plan.json"
+	txPlanFile                   = "tx-plan.json"
+	txPlanSummaryFile            = "tx-plan-summary.txt"
+	verificationReportFile       = "verification-report.json"
+)
+
+const outputDirPerm = 0o755
+
+func MultiChainCmd(
+	registry *signing.Registry,
+	overrides *override.Registry,
+	transforms *transformer.Registry,
+) *cobra.Command {
+	var flags multiChainFlags
+
+	cmd := &cobra.Command{
+		Use:   "multi-chain",
+		Short: "generate a market map once and fan it out to the upserts and dispatches of multiple chains",
+		Long: "generates a market map from a set of market data once, then overrides it with the on-chain market map, " +
+			"generates upserts and optionally dispatches them for each target of the config. the output of each target " +
+			"is written to its own directory, and a failing target does not stop the others.",
+		Example: "mmu multi-chain --config config.json --provider-data provider-data.json --out-dir ./tmp/chains --dispatch --simulate",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return multiChain(cmd.Context(), cmd.OutOrStdout(), registry, overrides, transforms, flags)
+		},
+	}
+
+	multiChainConfigureFlags(cmd, &flags)
+
+	return cmd
+}
+
+type multiChainFlags struct {
+	configPath               string
+	providerDataPath         string
+	outDir                   string
+	updateEnabled            bool
+	overwriteProviders       bool
+	existingOnly             bool
+	disableDeFiMarketMerging bool
+	warnOnInvalidMarketMap   bool
+	validationReportPaths    []string
+
+	dispatch bool
+	simulate bool
+	verify   bool
+}
+
+func multiChainConfigureFlags(cmd *cobra.Command, flags *multiChainFlags) {
+	cmd.Flags().StringVar(&flags.configPath, basic.ConfigPathFlag, basic.ConfigPathDefault, basic.ConfigPathDescription)
+	cmd.Flags().StringVar(&flags.providerDataPath, basic.ProviderDataPathFlag, basic.ProviderDataPathDefault, basic.ProviderDataPathDescription)
+	cmd.Flags().StringVar(&flags.outDir, OutDirFlag, OutDirDefault, OutDirDescription)
+	cmd.Flags().BoolVar(&flags.updateEnabled, basic.UpdateEnabledFlag, basic.UpdateEnabledDefault, basic.UpdateEnabledDescription)
+	cmd.Flags().BoolVar(&flags.overwriteProviders, basic.OverwriteProvidersFlag, basic.OverwriteProvidersDefault, basic.OverwriteProvidersDescription)
+	cmd.Flags().BoolVar(&flags.existingOnly, basic.ExistingOnlyFlag, basic.ExistingOnlyDefault, basic.ExistingOnlyDescription)
+	cmd.Flags().BoolVar(&flags.disableDeFiMarketMerging, basic.DisableDeFiMarketMerging, basic.DisableDeFiMarketMergingDefault, basic.DisableDeFiMarketMergingDescription)
+	cmd.Flags().BoolVar(&flags.warnOnInvalidMarketMap, basic.WarnOnInvalidMarketMapFlag, basic.WarnOnInvalidMarketMapDefault, basic.WarnOnInvalidMarketMapDescription)
+	cmd.Flags().StringSliceVar(&flags.validationReportPaths, basic.ValidationReportsFlag, nil, basic.ValidationReportsDescription)
+
+	cmd.Flags().BoolVar(&flags.dispatch, DispatchFlag, DispatchDefault, DispatchDescription)
+	cmd.Flags().BoolVar(&flags.simulate, basic.SimulateFlag, basic.SimulateDefault, basic.SimulateDescription)
+	cmd.Flags().BoolVar(&flags.verify, basic.VerifyFlag, basic.VerifyDefault, basic.VerifyDescription)
+}
+
+// TargetResult is the outcome of a target of the multi-chain command.
+type TargetResult struct {
+	Name       string `json:"name"`
+	ChainID    string `json:"chain_id"`
+	OutDir     string `json:"out_dir"`
+	Upserts    int    `json:"upserts"`
+	Dispatched bool   `json:"dispatched"`
+	Error      string `json:"error,omitempty"`
+}
+
+func multiChain(
+	ctx context.Context,
+	w io.Writer,
+	registry *signing.Registry,
+	overrides *override.Registry,
+	transforms *transformer.Registry,
+	flags multiChainFlags,
+) error {
+	logger := logging.Logger(ctx)
+	defer logger.Sync()
+
+	cfg, err := config.ReadConfigWithChainTypes(flags.configPath, overrides)
+	if err != nil {
+		return fmt.Errorf("failed to read config at %s: %w", flags.configPath, err)
+	}
+
+	if cfg.Generate == nil {
+		return errors.New("generate configuration missing from mmu config")
+	}
+
+	targets := cfg.ResolveTargets()
+	if len(targets) == 0 {
+		return errors.New("no targets or chain configured in mmu config")
+	}
+
+	if err := os.MkdirAll(flags.outDir, outputDirPerm); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// GENERATE once for all targets
+	generated, removalReasons, err := basic.GenerateFromConfig(ctx, logger, transforms, *cfg.Generate, flags.providerDataPath)
+	if err != nil {
+		logger.Error("failed to generate marketmap", zap.Error(err))
+		return err
+	}
+
+	if err := mmtypes.WriteMarketMapToFile(generated, filepath.Join(flags.outDir, generatedMarketMapFile)); err != nil {
+		return fmt.Errorf("failed to write generated market map: %w", err)
+	}
+	if err := diffs.WriteRemovalReasonsToFile(filepath.Join(flags.outDir, generatedRemovalsFile), removalReasons); err != nil {
+		return fmt.Errorf("failed to write removals to file: %w", err)
+	}
+	if err := diffs.WriteRemovalSummaryToFile(filepath.Join(flags.outDir, generatedRemovalsSummaryFile), removalReasons); err != nil {
+		return fmt.Errorf("failed to write removal summary to file: %w", err)
+	}
+
+	// fan out to each target, isolating the failures of a target from the others
+	results := make([]TargetResult, 0, len(targets))
+	failed := 0
+	for _, target := range targets {
+		targetLogger := logger.With(zap.String("target", target.Name), zap.String("chain_id", target.Chain.ChainID))
+		result := runTarget(ctx, targetLogger, w, registry, overrides, flags, target, generated)
+		if result.Error != "" {
+			failed++
+			targetLogger.Error("target failed", zap.String("error", result.Error))
+		} else {
+			targetLogger.Info("target succeeded", zap.Int("upserts", result.Upserts),
+				zap.Bool("dispatched", result.Dispatched))
+		}
+		results = append(results, result)
+	}
+
+	summaryPath := filepath.Join(flags.outDir, targetSummaryFile)
+	if err := file.WriteJSONToFile(results, summaryPath); err != nil {
+		return fmt.Errorf("failed to write target summary: %w", err)
+	}
+	logger.Info("target summary written to file", zap.String("file", summaryPath))
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d targets failed, see %s", failed, len(targets), summaryPath)
+	}
+	return nil
+}
+
+// runTarget overrides the generated market map with the on-chain market map of the target, generates its upserts
+// and dispatches them if configured. The generated market map is copied, so that targets do not affect each other.
+func runTarget(
+	ctx context.Context,
+	logger *zap.Logger,
+	w io.Writer,
+	registry *signing.Registry,
+	overrides *override.Registry,
+	flags multiChainFlags,
+	target config.TargetConfig,
+	generated mmtypes.MarketMap,
+) TargetResult {
+	outDir := filepath.Join(flags.outDir, target.Name)
+	result := TargetResult{Name: target.Name, ChainID: target.Chain.ChainID, OutDir: outDir}
+
+	upserts, err := targetUpserts(ctx, logger, overrides, flags, target, generated, outDir)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Upserts = len(upserts)
+
+	if !flags.dispatch {
+		return result
+	}
+
+	if target.Dispatch == nil {
+		result.Error = "dispatch configuration missing for target"
+		return result
+	}
+
+	if len(upserts) == 0 {
+		logger.Info("no upserts to dispatch")
+		return result
+	}
+
+	err = basic.DispatchUpserts(ctx, logger, w, registry, *target.Dispatch, target.Chain, upserts, basic.DispatchOptions{
+		Simulate:                  flags.simulate,
+		Verify:                    flags.verify,
+		BatchPlanOutPath:          filepath.Join(outDir, batchPlanFile),
+		TxPlanOutPath:             filepath.Join(outDir, txPlanFile),
+		TxPlanSummaryOutPath:      filepath.Join(outDir, txPlanSummaryFile),
+		VerificationReportOutPath: filepath.Join(outDir, verificationReportFile),
+	})
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to dispatch: %s", err)
+		return result
+	}
+	result.Dispatched = !flags.simulate
+
+	return result
+}
+
+// targetUpserts generates the upserts of the target from a copy of the generated market map, writing the overridden
+// market map and the upserts to outDir.
+func targetUpserts(
+	ctx context.Context,
+	logger *zap.Logger,
+	overrides *override.Registry,
+	flags multiChainFlags,
+	target config.TargetConfig,
+	generated mmtypes.MarketMap,
+	outDir string,
+) ([]mmtypes.Market, error) {
+	if target.Upsert == nil {
+		return nil, errors.New("upsert configuration missing for target")
+	}
+
+	if err := os.MkdirAll(outDir, outputDirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	marketMap, err := copyMarketMap(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	overridden, err := basic.OverrideMarketsFromConfig(
+		ctx,
+		logger,
+		overrides,
+		target.Chain,
+		marketMap,
+		flags.updateEnabled,
+		flags.overwriteProviders,
+		flags.existingOnly,
+		flags.disableDeFiMarketMerging,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to override market map: %w", err)
+	}
+
+	if err := mmtypes.WriteMarketMapToFile(overridden, filepath.Join(outDir, overrideMarketMapFile)); err != nil {
+		return nil, fmt.Errorf("failed to write overridden market map: %w", err)
+	}
+
+	overridden, decisions, err := basic.AutoEnableFromReports(
+		logger,
+		*target.Upsert,
+		overridden,
+		flags.validationReportPaths,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	upserts, err := basic.UpsertsFromConfigs(ctx, logger, overridden, target.Chain, *target.Upsert,
+		flags.warnOnInvalidMarketMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate upserts: %w", err)
+	}
+
+	output := upsert.Output{Upserts: upserts, Decisions: decisions}
+	if err := file.WriteJSONToFile(output, filepath.Join(outDir, upsertsFile)); err != nil {
+		return nil, fmt.Errorf("failed to write upserts: %w", err)
+	}
+	logger.Info("upserts written to file", zap.String("file", filepath.Join(outDir, upsertsFile)),
+		zap.Int("upserts", len(upserts)))
+
+	return upserts, nil
+}
+
+// copyMarketMap returns a deep copy of the market map.
+func copyMarketMap(mm mmtypes.MarketMap) (mmtypes.MarketMap, error) {
+	bz, err := mm.Marshal()
+	if err != nil {
+		return mmtypes.MarketMap{}, fmt.Errorf("failed to copy market map: %w", err)
+	}
+
+	var out mmtypes.MarketMap
+	if err := out.Unmarshal(bz); err != nil {
+		return mmtypes.MarketMap{}, fmt.Errorf("failed to copy market map: %w", err)
+	}
+	if out.Markets == nil {
+		out.Markets = make(map[string]mmtypes.Market)
+	}
+
+	return out, nil
+}
@@ -7,10 +7,16 @@ import (
 	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd/composite"
 	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd/utils"
 	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
+	"github.com/skip-mev/connect-mmu/override"
 	"github.com/skip-mev/connect-mmu/signing"
 )
 
-func RootCmd(registry *signing.Registry) *cobra.Command {
+func RootCmd(
+	registry *signing.Registry,
+	overrides *override.Registry,
+	transforms *transformer.Registry,
+) *cobra.Command {
 	var logLevel string
 	rootCmd := &cobra.Command{
 		Use:   "mmu",
@@ -27,10 +33,12 @@ func RootCmd(registry *signing.Registry) *cobra.Command {
 	// Basic Commands
 	rootCmd.AddCommand(
 		basic.IndexCmd(),
-		basic.GenerateCmd(),
-		basic.OverrideCmd(),
+		basic.GenerateCmd(transforms),
+		basic.OverrideCmd(overrides),
 		basic.UpsertsCmd(),
 		basic.DispatchCmd(registry),
+		basic.DelistingsCmd(),
+		basic.VerifyCmd(),
 	)
 
 	// Utility Commands
@@ -38,11 +46,15 @@ func RootCmd(registry *signing.Registry) *cobra.Command {
 		utils.ConfigInitCmd(),
 		utils.DiffCmd(),
 		utils.ValidateCmd(),
+		utils.ExplainCmd(overrides, transforms),
+		utils.OracleConfigCmd(),
+		utils.ChainInfoCmd(),
 	)
 
 	// Composite Commands
 	rootCmd.AddCommand(
-		composite.GenerateUpsertsCmd(),
+		composite.GenerateUpsertsCmd(overrides, transforms),
+		composite.MultiChainCmd(registry, overrides, transforms),
 	)
 
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Set the logging level (debug, info, warn, error, dpanic, panic, fatal)")
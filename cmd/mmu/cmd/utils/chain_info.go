@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/skip-mev/connect-mmu/client/marketmap"
+	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd/basic"
+	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+func ChainInfoCmd() *cobra.Command {
+	var flags chainInfoCmdFlags
+
+	cmd := &cobra.Command{
+		Use:   "chain-info",
+		Short: "detect the x/marketmap module of the configured chain",
+		Long: "queries the node of the configured chain for its chain id, and detects whether it serves the connect or " +
+			"the slinky x/marketmap module. prints them along with the market authorities and admin of the module, and " +
+			"warns if they do not match the chain config.",
+		Example: "mmu chain-info --config config.json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			logger := logging.Logger(ctx)
+
+			cfg, err := config.ReadConfig(flags.configPath)
+			if err != nil {
+				return fmt.Errorf("failed to read config at %s: %w", flags.configPath, err)
+			}
+
+			if cfg.Chain == nil {
+				return errors.New("chain configuration missing from mmu config")
+			}
+
+			cc, err := grpc.NewClient(cfg.Chain.GRPCAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				return fmt.Errorf("failed to create chain gRPC client: %w", err)
+			}
+
+			info, err := marketmap.GetChainInfo(ctx, cc)
+			if err != nil {
+				return err
+			}
+
+			if info.ChainID != cfg.Chain.ChainID {
+				logger.Warn("configured chain id does not match the node", zap.String("configured", cfg.Chain.ChainID),
+					zap.String("node", info.ChainID))
+			}
+			if cfg.Chain.Version != config.VersionAuto && info.Version != cfg.Chain.Version {
+				logger.Warn("configured chain version does not match the x/marketmap module served by the node",
+					zap.String("configured", string(cfg.Chain.Version)), zap.String("detected", string(info.Version)))
+			}
+
+			bz, err := json.MarshalIndent(info, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+			return err
+		},
+	}
+
+	chainInfoCmdConfigureFlags(cmd, &flags)
+
+	return cmd
+}
+
+type chainInfoCmdFlags struct {
+	configPath string
+}
+
+func chainInfoCmdConfigureFlags(cmd *cobra.Command, flags *chainInfoCmdFlags) {
+	cmd.Flags().StringVar(&flags.configPath, basic.ConfigPathFlag, basic.ConfigPathDefault, basic.ConfigPathDescription)
+}
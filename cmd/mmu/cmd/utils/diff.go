@@ -12,7 +12,6 @@ import (
 
 	"github.com/josephburnett/jd/v2"
 	marketmaptypes "github.com/skip-mev/connect/v2/x/marketmap/types"
-	"github.com/skip-mev/connect/v2/x/marketmap/types/tickermetadata"
 	slinkymarketmaptypes "github.com/skip-mev/slinky/x/marketmap/types"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -21,6 +20,8 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/skip-mev/connect-mmu/client/marketmap"
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator/types"
 )
 
 var netsToRPC = map[string]string{
@@ -76,6 +77,9 @@ func DiffCmd() *cobra.Command {
 				}
 			}
 
+			// count tiers before new markets are split out so that all generated markets are counted.
+			tierCounts := countTiers(generatedMarketMap)
+
 			sortProviderConfigs(generatedMarketMap)
 			sortProviderConfigs(chainMM)
 			// if we're not showing ref price change, we mute the ref/liq changes by setting them to zero.
@@ -125,6 +129,9 @@ func DiffCmd() *cobra.Command {
 					b.WriteString(colorGreen)
 					b.WriteString("+ ")
 					b.WriteString(newMarket.Ticker.String())
+					if tier := types.MarketTier(newMarket); tier != "" {
+						b.WriteString(fmt.Sprintf(" [tier: %s]", tier))
+					}
 					b.WriteString("\n")
 					b.WriteString(colorDefault)
 					bz, err := json.Marshal(newMarket)
@@ -140,9 +147,15 @@ func DiffCmd() *cobra.Command {
 				fmt.Printf("\n\n=== NO NEW MARKETS ADDED ===\n\n")
 			}
 
+			tiersString := renderTierCounts(tierCounts)
+			if tiersString != "" {
+				fmt.Println(tiersString)
+			}
+
 			if flags.outputPath != "" {
 				bz := bytes.NewBuffer([]byte(theDiff))
 				bz.WriteString(newMarketsString)
+				bz.WriteString(tiersString)
 				err = os.WriteFile(flags.outputPath+".txt", bz.Bytes(), 0o600)
 				if err != nil {
 					return fmt.Errorf("failed to write diff to file: %w", err)
@@ -180,19 +193,28 @@ func diffCmdConfigureFlags(cmd *cobra.Command, flags *diffCmdFlags) {
 	cmd.Flags().BoolVar(&flags.showRefPriceChanges, flagShowReferencePriceChanges, false, "show changes in reference price and liquidity")
 	cmd.Flags().StringVarP(&flags.networkName, flagNetwork, flagNetworkShort, "", "blockchain network to query i.e. dydx-testnet")
 	cmd.Flags().StringVarP(&flags.networkURL, flagNetworkRaw, flagNetworkRawShort, "", "raw blockchain gRPC network URL to query. i.e. dydx-testnet-grpc.polkachu.com")
-	cmd.Flags().BoolVar(&flags.useSlinkyAPI, flagSlinkyAPI, false, "use the slinky API to query the marketmap")
+	cmd.Flags().BoolVar(&flags.useSlinkyAPI, flagSlinkyAPI, false, "use the slinky API to query the marketmap, instead of detecting the API served by the node")
 
 	cmd.MarkFlagsOneRequired(flagNetwork, flagNetworkRaw)
 	cmd.MarkFlagsMutuallyExclusive(flagNetwork, flagNetworkRaw)
 }
 
-// getMarketMap fetches the marketmap from the chain.
+// getMarketMap fetches the marketmap from the chain, using the slinky API if useSlinky is set, and the API served by
+// the node otherwise.
 func getMarketMap(ctx context.Context, grpcURL string, useSlinky bool) (marketmaptypes.MarketMap, error) {
 	c, err := grpc.NewClient(grpcURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		return marketmaptypes.MarketMap{}, err
 	}
 
+	if !useSlinky {
+		version, _, err := marketmap.DetectVersion(ctx, c)
+		if err != nil {
+			return marketmaptypes.MarketMap{}, fmt.Errorf("failed to detect chain version: %w", err)
+		}
+		useSlinky = version == config.VersionSlinky
+	}
+
 	var client marketmap.Client
 	if useSlinky {
 		client = marketmap.NewSlinkyModuleMarketMapClient(slinkymarketmaptypes.NewQueryClient(c), zap.NewNop())
@@ -217,22 +239,51 @@ func sortProviderConfigs(mm marketmaptypes.MarketMap) {
 func muteRefPriceAndVolumeChanges(mm marketmaptypes.MarketMap) {
 	for name, market := range mm.Markets {
 		if market.Ticker.Metadata_JSON != "" {
-			dydx, err := tickermetadata.DyDxFromJSONString(market.Ticker.Metadata_JSON)
+			md, err := types.TickerMetadataFromJSON(market.Ticker.Metadata_JSON)
 			if err != nil {
 				continue // lets just not fail.
 			}
-			dydx.ReferencePrice = 0
-			dydx.Liquidity = 0
-			dydxBz, err := json.Marshal(dydx)
+			md.ReferencePrice = 0
+			md.Liquidity = 0
+			mdBz, err := json.Marshal(md)
 			if err != nil {
 				panic(err)
 			}
-			market.Ticker.Metadata_JSON = string(dydxBz)
+			market.Ticker.Metadata_JSON = string(mdBz)
 			mm.Markets[name] = market
 		}
 	}
 }
 
+// countTiers returns the number of markets per tier recorded in the ticker metadata.
+// Markets without a tier are not counted.
+func countTiers(mm marketmaptypes.MarketMap) map[string]int {
+	counts := make(map[string]int)
+	for _, market := range mm.Markets {
+		if tier := types.MarketTier(market); tier != "" {
+			counts[tier]++
+		}
+	}
+	return counts
+}
+
+// renderTierCounts renders the number of markets per tier. An empty string is returned if no market has a tier.
+func renderTierCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return ""
+	}
+
+	tiers := maps.Keys(counts)
+	sort.Strings(tiers)
+
+	b := bytes.NewBuffer(nil)
+	b.WriteString("\n\n=== MARKETS PER TIER ===\n\n")
+	for _, tier := range tiers {
+		b.WriteString(fmt.Sprintf("%s: %d\n", tier, counts[tier]))
+	}
+	return b.String()
+}
+
 func removeNewMarkets(generated, onchain marketmaptypes.MarketMap) []marketmaptypes.Market {
 	newMarkets := make([]marketmaptypes.Market, 0)
 	for name, market := range generated.Markets {
@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/client/marketmap"
+	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd/basic"
+	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/explain"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
+	"github.com/skip-mev/connect-mmu/override"
+	"github.com/skip-mev/connect-mmu/override/update"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	explainFormatText = "text"
+	explainFormatJSON = "json"
+)
+
+func ExplainCmd(overrides *override.Registry, transforms *transformer.Registry) *cobra.Command {
+	var flags explainCmdFlags
+
+	cmd := &cobra.Command{
+		Use:   "explain <TICKER>",
+		Short: "trace a market through every stage of generation, override and upserts",
+		Long: "replays market map generation with instrumentation and shows the provider rows, the state of the market " +
+			"after every transform, the override decision and whether an upsert would be emitted for the given ticker.",
+		Example: "mmu explain BTC/USD --config config.json --provider-data provider-data.json --provider kraken_ws --format json",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			logger := logging.Logger(ctx)
+
+			if flags.format != explainFormatText && flags.format != explainFormatJSON {
+				return fmt.Errorf("invalid format %q: must be one of (%s, %s)", flags.format, explainFormatText, explainFormatJSON)
+			}
+
+			ticker, err := connecttypes.CurrencyPairFromString(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid ticker %q: %w", args[0], err)
+			}
+
+			cfg, err := config.ReadConfigWithChainTypes(flags.configPath, overrides)
+			if err != nil {
+				return fmt.Errorf("failed to read in config at %s: %w", flags.configPath, err)
+			}
+
+			if cfg.Generate == nil {
+				return errors.New("generate configuration missing from mmu config")
+			}
+
+			providerStore, err := provider.NewMemoryStoreFromFile(flags.providerDataPath)
+			if err != nil {
+				return fmt.Errorf("failed to read provider data at %s: %w", flags.providerDataPath, err)
+			}
+
+			e, generated, err := explain.Generate(ctx, logger, providerStore, transforms, *cfg.Generate, explain.Options{
+				Ticker:   ticker,
+				Provider: flags.provider,
+			})
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case flags.offline:
+				logger.Info("offline: skipping override and upserts")
+			case cfg.Chain == nil:
+				logger.Info("chain configuration missing from mmu config: skipping override and upserts")
+			default:
+				mmClient, err := marketmap.NewClientFromChainConfig(logger, *cfg.Chain)
+				if err != nil {
+					return fmt.Errorf("failed to create marketmap client: %w", err)
+				}
+
+				onChainMarketMap, err := mmClient.GetMarketMap(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to get marketmap from chain: %w", err)
+				}
+
+				marketOverride, err := basic.MarketMapOverrideFromConfig(logger, overrides, *cfg.Chain)
+				if err != nil {
+					return err
+				}
+
+				overridden, err := e.TraceOverride(ctx, logger, marketOverride, onChainMarketMap, generated, update.Options{
+					UpdateEnabled:            flags.updateEnabled,
+					OverwriteProviders:       flags.overwriteProviders,
+					ExistingOnly:             flags.existingOnly,
+					DisableDeFiMarketMerging: flags.disableDeFiMarketMerging,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to override marketmap: %w", err)
+				}
+
+				if err := e.TraceUpserts(logger, onChainMarketMap, overridden); err != nil {
+					return err
+				}
+			}
+
+			var out io.Writer = cmd.OutOrStdout()
+			if flags.outputPath != "" {
+				f, err := os.Create(flags.outputPath)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			if flags.format == explainFormatJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(e)
+			}
+
+			logger.Debug("writing explanation", zap.String("ticker", e.Ticker))
+			return explain.WriteText(out, e)
+		},
+	}
+
+	explainCmdConfigureFlags(cmd, &flags)
+
+	return cmd
+}
+
+type explainCmdFlags struct {
+	configPath               string
+	providerDataPath         string
+	provider                 string
+	format                   string
+	outputPath               string
+	offline                  bool
+	updateEnabled            bool
+	overwriteProviders       bool
+	existingOnly             bool
+	disableDeFiMarketMerging bool
+}
+
+func explainCmdConfigureFlags(cmd *cobra.Command, flags *explainCmdFlags) {
+	const (
+		flagProvider = "provider"
+		flagFormat   = "format"
+		flagOffline  = "offline"
+	)
+
+	cmd.Flags().StringVar(&flags.configPath, basic.ConfigPathFlag, basic.ConfigPathDefault, basic.ConfigPathDescription)
+	cmd.Flags().StringVar(&flags.providerDataPath, basic.ProviderDataPathFlag, basic.ProviderDataPathDefault, basic.ProviderDataPathDescription)
+	cmd.Flags().StringVar(&flags.provider, flagProvider, "", "only trace feeds and removals of this provider")
+	cmd.Flags().StringVar(&flags.format, flagFormat, explainFormatText, "output format (text, json)")
+	cmd.Flags().StringVar(&flags.outputPath, flagOutput, "", "writes the explanation to a file instead of stdout")
+	cmd.Flags().BoolVar(&flags.offline, flagOffline, false, "skip the override and upsert steps which require the on-chain market map")
+
+	cmd.Flags().BoolVar(&flags.updateEnabled, basic.UpdateEnabledFlag, basic.UpdateEnabledDefault, basic.UpdateEnabledDescription)
+	cmd.Flags().BoolVar(&flags.overwriteProviders, basic.OverwriteProvidersFlag, basic.OverwriteProvidersDefault, basic.OverwriteProvidersDescription)
+	cmd.Flags().BoolVar(&flags.existingOnly, basic.ExistingOnlyFlag, basic.ExistingOnlyDefault, basic.ExistingOnlyDescription)
+	cmd.Flags().BoolVar(&flags.disableDeFiMarketMerging, basic.DisableDeFiMarketMerging, basic.DisableDeFiMarketMergingDefault, basic.DisableDeFiMarketMergingDescription)
+}
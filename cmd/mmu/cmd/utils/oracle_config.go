@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/client/marketmap"
+	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd/basic"
+	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/lib/file"
+	"github.com/skip-mev/connect-mmu/oracle"
+)
+
+const flagOracleConfigOut = "oracle-config-out"
+
+func OracleConfigCmd() *cobra.Command {
+	var flags oracleConfigCmdFlags
+
+	cmd := &cobra.Command{
+		Use:   "oracle-config",
+		Short: "derive a Connect oracle config for the providers of a market map",
+		Long: "derives a Connect oracle config covering exactly the providers used by a generated or on-chain market map. " +
+			"providers are configured from the templates in the oracle section of the config, with API keys read from " +
+			"the environment variables named in the templates. if no market map is given, the on-chain market map is used.",
+		Example: "oracle-config --config config.json --market-map generated-market-map.json --oracle-config-out oracle.json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			logger := logging.Logger(cmd.Context())
+
+			cfg, err := config.ReadConfig(flags.configPath)
+			if err != nil {
+				return fmt.Errorf("failed to read config at %s: %w", flags.configPath, err)
+			}
+
+			var mm mmtypes.MarketMap
+			if flags.marketMapPath != "" {
+				mm, err = mmtypes.ReadMarketMapFromFile(flags.marketMapPath)
+				if err != nil {
+					return fmt.Errorf("unable to read marketmap: %w", err)
+				}
+			} else {
+				mm, err = getChainMarketMap(cmd.Context(), logger, cfg)
+				if err != nil {
+					return err
+				}
+			}
+
+			oracleCfg, err := oracleConfigFromMarketMap(logger, cfg, mm)
+			if err != nil {
+				return err
+			}
+
+			if err := file.WriteJSONToFile(oracleCfg, flags.outputPath); err != nil {
+				return fmt.Errorf("failed to write oracle config: %w", err)
+			}
+			logger.Info("oracle config written to file", zap.String("file", flags.outputPath),
+				zap.Int("providers", len(oracleCfg.Providers)))
+
+			return nil
+		},
+	}
+
+	oracleConfigCmdConfigureFlags(cmd, &flags)
+
+	return cmd
+}
+
+type oracleConfigCmdFlags struct {
+	configPath    string
+	marketMapPath string
+	outputPath    string
+}
+
+func oracleConfigCmdConfigureFlags(cmd *cobra.Command, flags *oracleConfigCmdFlags) {
+	cmd.Flags().StringVar(&flags.configPath, basic.ConfigPathFlag, basic.ConfigPathDefault, basic.ConfigPathDescription)
+	cmd.Flags().StringVar(&flags.marketMapPath, flagMarketmap, "", "path to a market map. if empty, the on-chain market map is used")
+	cmd.Flags().StringVar(&flags.outputPath, flagOracleConfigOut, "oracle.json", "the output path for the oracle config")
+}
+
+// getChainMarketMap fetches the market map of the chain configured in the config.
+func getChainMarketMap(ctx context.Context, logger *zap.Logger, cfg config.Config) (mmtypes.MarketMap, error) {
+	if cfg.Chain == nil {
+		return mmtypes.MarketMap{}, errors.New("chain configuration missing from mmu config")
+	}
+
+	mmClient, err := marketmap.NewClientFromChainConfig(logger, *cfg.Chain)
+	if err != nil {
+		return mmtypes.MarketMap{}, fmt.Errorf("failed to create MarketMap client from chain config: %w", err)
+	}
+
+	mm, err := mmClient.GetMarketMap(ctx)
+	if err != nil {
+		return mmtypes.MarketMap{}, fmt.Errorf("failed to get marketmap: %w", err)
+	}
+
+	return mm, nil
+}
+
+// oracleConfigFromMarketMap derives the oracle config of the market map from the oracle section of the config,
+// reading API keys from the environment. Providers without a template and missing API keys are logged.
+func oracleConfigFromMarketMap(logger *zap.Logger, cfg config.Config, mm mmtypes.MarketMap) (oracle.Config, error) {
+	if cfg.Oracle == nil {
+		return oracle.Config{}, errors.New("oracle configuration missing from mmu config")
+	}
+
+	res := oracle.FromMarketMap(mm, *cfg.Oracle, os.LookupEnv)
+	if len(res.Untemplated) > 0 {
+		logger.Info("providers without an oracle template use the connect defaults", zap.Strings("providers", res.Untemplated))
+	}
+	if len(res.MissingAPIKeys) > 0 {
+		logger.Warn("api key environment variables are not set", zap.Strings("variables", res.MissingAPIKeys))
+	}
+
+	return res.Config, nil
+}
@@ -14,7 +14,10 @@ import (
 	"github.com/skip-mev/connect/v2/x/marketmap/types"
 	"github.com/spf13/cobra"
 
+	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd/basic"
 	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd/utils/validate"
+	"github.com/skip-mev/connect-mmu/cmd/mmu/logging"
+	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/lib/file"
 	"github.com/skip-mev/connect-mmu/validator"
 	validatortypes "github.com/skip-mev/connect-mmu/validator/types"
@@ -100,14 +103,24 @@ func ValidateCmd() *cobra.Command {
 
 			cmd.Printf("using %s %s", connectBin, string(verOut))
 
+			oracleConfigPath := flags.oracleConfig
+			if oracleConfigPath == "" && flags.configPath != "" {
+				oracleConfigPath, err = writeOracleConfigToTempFile(cmd, flags.configPath, mm)
+				if err != nil {
+					return err
+				}
+				defer os.Remove(oracleConfigPath)
+				cmd.Printf("using oracle config derived from %s\n", flags.configPath)
+			}
+
 			connectCmd := []string{connectBin, "--market-config-path", f.Name(), "--log-std-out-level", "debug"}
-			if flags.oracleConfig != "" {
-				_, err := os.Stat(flags.oracleConfig)
+			if oracleConfigPath != "" {
+				_, err := os.Stat(oracleConfigPath)
 				if err != nil {
-					return fmt.Errorf("failed to find oracle config file %s: %w", flags.oracleConfig, err)
+					return fmt.Errorf("failed to find oracle config file %s: %w", oracleConfigPath, err)
 				}
 
-				connectCmd = append(connectCmd, "--oracle-config", flags.oracleConfig)
+				connectCmd = append(connectCmd, "--oracle-config", oracleConfigPath)
 			}
 			const (
 				redirect = "2>&1"
@@ -203,6 +216,8 @@ type validateCmdFlags struct {
 	startDelay time.Duration
 	// oracleConfig is the oracle config to pass to the connect instance. this is useful when providers require API keys.
 	oracleConfig string
+	// configPath is the mmu config to derive the oracle config from if no oracle config is passed.
+	configPath string
 	// healthFile is the file path to output the health report to
 	healthFile string
 	// enableAll is an option to enable all markets before running validation.
@@ -238,6 +253,7 @@ func validateCmdConfigureFlags(cmd *cobra.Command, flags *validateCmdFlags) {
 	cmd.Flags().DurationVar(&flags.duration, flagDuration, 5*time.Minute, "the amount of time the process will run before exiting")
 	cmd.Flags().StringVar(&flags.marketmapPath, flagMarketmap, "", "optional path to marketmap file to output potential anomalies such as missing reports")
 	cmd.Flags().StringVar(&flags.oracleConfig, flagOracleConfig, "", "use this flag to pass in an oracle config to connect. this is useful if your markets require API keys")
+	cmd.Flags().StringVar(&flags.configPath, basic.ConfigPathFlag, "", "path to a market map updater config to derive the oracle config from. only used if no oracle config is passed")
 	cmd.Flags().BoolVar(&flags.writeToStdErr, flagWriteToStdError, true, "write the results as an error to std error")
 	cmd.Flags().StringVar(&flags.connectVersion, flagConnectVersion, "", "DOCKER ONLY: the connect version to run the validation on. if empty, the latest will be used. examples: 1.0.12, 2.0.0")
 	cmd.Flags().StringVar(&flags.cmcAPIKey, flagCMCAPIKey, "", "coinmarketcap API key that will be used to get reference prices to check provider prices against")
@@ -251,6 +267,7 @@ func validateCmdConfigureFlags(cmd *cobra.Command, flags *validateCmdFlags) {
 
 	cmd.MarkFlagRequired(flagMarketmap)
 	cmd.MarkFlagsMutuallyExclusive(flagEnableMarkets, flagEnableOnly, flagEnableAll)
+	cmd.MarkFlagsMutuallyExclusive(flagOracleConfig, basic.ConfigPathFlag)
 }
 
 // generateErrorFromReport will generate an error based on failing and missing reports.
@@ -335,6 +352,38 @@ func readHealthFile(fileName string) (validatortypes.MarketHealth, error) {
 	return health, nil
 }
 
+// writeOracleConfigToTempFile derives the oracle config of the market map from the mmu config at configPath and
+// writes it to a temp file, returning its path.
+func writeOracleConfigToTempFile(cmd *cobra.Command, configPath string, mm types.MarketMap) (string, error) {
+	cfg, err := config.ReadConfig(configPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read config at %s: %w", configPath, err)
+	}
+
+	oracleCfg, err := oracleConfigFromMarketMap(logging.Logger(cmd.Context()), cfg, mm)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp("", "oracle.*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file for oracle config: %w", err)
+	}
+	defer f.Close()
+
+	bz, err := json.Marshal(oracleCfg)
+	if err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to marshal oracle config: %w", err)
+	}
+	if _, err := f.Write(bz); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("error writing to %s: %w", f.Name(), err)
+	}
+
+	return f.Name(), nil
+}
+
 func writeMarketMapToTempFile(mm types.MarketMap) (*os.File, error) {
 	f, err := os.CreateTemp("", "all_enabled_marketmap.*.json")
 	if err != nil {
@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/skip-mev/connect-mmu/cmd/mmu/cmd"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
+	"github.com/skip-mev/connect-mmu/override"
 	"github.com/skip-mev/connect-mmu/signing"
 	"github.com/skip-mev/connect-mmu/signing/local"
 	"github.com/skip-mev/connect-mmu/signing/simulate"
@@ -19,7 +21,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	if err := cmd.RootCmd(r).Execute(); err != nil {
+	if err := cmd.RootCmd(r, override.NewDefaultRegistry(), transformer.NewDefaultRegistry()).Execute(); err != nil {
 		os.Exit(1)
 	}
 }
@@ -4,11 +4,22 @@ import (
 	"fmt"
 )
 
+const (
+	// ChainTypeCore is the type of chains whose market maps are overridden without chain-specific rules.
+	ChainTypeCore = "core"
+	// ChainTypeDYDX is the type of dydx chains, whose cross-margined perpetual markets are never changed.
+	ChainTypeDYDX = "dydx"
+)
+
 // ChainConfig is a configuration for a chain.
 type ChainConfig struct {
 	// RPCAddress is the address of the chain's RPC server
 	RPCAddress string `json:"rpc_address"`
 
+	// RPCAddresses are additional addresses of the chain's RPC servers that are failed over to, in order, when the
+	// RPCAddress is unavailable.
+	RPCAddresses []string `json:"rpc_addresses,omitempty"`
+
 	// GRPCAddress is the address of the chain's GRPC server
 	GRPCAddress string `json:"grpc_address"`
 
@@ -21,9 +32,19 @@ type ChainConfig struct {
 	// DYDX is a bool that indicates if the chain is a dydx chain
 	DYDX bool `json:"dydx"`
 
-	// Version is the version of Connect (slinky or connect) this chain uses.
+	// Type is the type of the chain, which selects the MarketMapOverride applied to its market map. Defaults to
+	// ChainTypeDYDX if DYDX is set, and ChainTypeCore otherwise.
+	Type string `json:"type,omitempty"`
+
+	// Override is the config of the MarketMapOverride of the chain type, if it takes one.
+	Override any `json:"override,omitempty"`
+
+	// Version is the version of Connect (slinky or connect) this chain uses, or auto to detect it from the node.
 	Version Version `json:"version"`
 
+	// MessageMode is the set of marketmap messages upserts are dispatched with. Defaults to MessageModeUpsert.
+	MessageMode MessageMode `json:"message_mode,omitempty"`
+
 	// Prefix is the address prefix the chain uses (ex: cosmos).
 	Prefix string `json:"prefix"`
 }
@@ -45,7 +66,15 @@ func (c *ChainConfig) Validate() error {
 	}
 
 	if !IsValidVersion(c.Version) {
-		return fmt.Errorf("version must be one of (%s, %s)", VersionConnect, VersionSlinky)
+		return fmt.Errorf("version must be one of (%s, %s, %s)", VersionConnect, VersionSlinky, VersionAuto)
+	}
+
+	if !IsValidMessageMode(c.MessageMode) {
+		return fmt.Errorf("message_mode must be one of (%s, %s)", MessageModeUpsert, MessageModeCreateUpdate)
+	}
+
+	if c.DYDX && c.Type != "" && c.Type != ChainTypeDYDX {
+		return NewErrInvalidChainConfig(fmt.Errorf("invalid chain config: dydx is set but type is %s", c.Type))
 	}
 
 	if c.ChainID == "" {
@@ -56,9 +85,62 @@ func (c *ChainConfig) Validate() error {
 		return fmt.Errorf("invalid chain config: prefix is empty, %s", c.Prefix)
 	}
 
+	for _, address := range c.RPCAddresses {
+		if address == "" {
+			return NewErrInvalidChainConfig(fmt.Errorf("invalid chain config: rpc_addresses contains an empty address"))
+		}
+	}
+
 	return nil
 }
 
+// ChainTypes validates chain configs against the chain types that are registered, ex. the MarketMapOverrides
+// registered by chain type.
+type ChainTypes interface {
+	// ValidateChainType checks that the type of the chain is registered and that its override config is valid.
+	ValidateChainType(chainCfg ChainConfig) error
+}
+
+// ValidateChainType checks the chain config, and that its type and override config are valid for the registered
+// chain types.
+func (c *ChainConfig) ValidateChainType(chainTypes ChainTypes) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	if err := chainTypes.ValidateChainType(*c); err != nil {
+		return NewErrInvalidChainConfig(err)
+	}
+
+	return nil
+}
+
+// ChainType returns the type of the chain, defaulting to ChainTypeDYDX for dydx chains and ChainTypeCore otherwise.
+func (c *ChainConfig) ChainType() string {
+	switch {
+	case c.Type != "":
+		return c.Type
+	case c.DYDX:
+		return ChainTypeDYDX
+	default:
+		return ChainTypeCore
+	}
+}
+
+// RPCEndpoints returns the RPCAddress followed by the unique RPCAddresses.
+func (c *ChainConfig) RPCEndpoints() []string {
+	endpoints := []string{c.RPCAddress}
+	seen := map[string]struct{}{c.RPCAddress: {}}
+	for _, address := range c.RPCAddresses {
+		if _, found := seen[address]; found {
+			continue
+		}
+		seen[address] = struct{}{}
+		endpoints = append(endpoints, address)
+	}
+	return endpoints
+}
+
 // ErrInvalidChainConfig is an error that occurs when the chain config is invalid.
 type ErrInvalidChainConfig struct {
 	err error
@@ -65,6 +65,44 @@ func TestChainConfig_ValidateBasic(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid auto chain version",
+			config: config.ChainConfig{
+				RPCAddress:  "http://rpc.example.com",
+				GRPCAddress: "http://grpc.example.com",
+				RESTAddress: "http://rest.example.com",
+				ChainID:     "foo",
+				Version:     config.VersionAuto,
+				Prefix:      "bar",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid create_update message mode",
+			config: config.ChainConfig{
+				RPCAddress:  "http://rpc.example.com",
+				GRPCAddress: "http://grpc.example.com",
+				RESTAddress: "http://rest.example.com",
+				ChainID:     "foo",
+				Version:     config.VersionSlinky,
+				MessageMode: config.MessageModeCreateUpdate,
+				Prefix:      "bar",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid message mode",
+			config: config.ChainConfig{
+				RPCAddress:  "http://rpc.example.com",
+				GRPCAddress: "http://grpc.example.com",
+				RESTAddress: "http://rest.example.com",
+				ChainID:     "foo",
+				Version:     config.VersionConnect,
+				MessageMode: "create",
+				Prefix:      "bar",
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid DYDX chain config - missing GRPC address",
 			config: config.ChainConfig{
@@ -104,6 +142,33 @@ func TestChainConfig_ValidateBasic(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid chain type",
+			config: config.ChainConfig{
+				RPCAddress:  "http://rpc.example.com",
+				GRPCAddress: "http://grpc.example.com",
+				RESTAddress: "http://rest.example.com",
+				ChainID:     "foo",
+				Type:        "protected_markets",
+				Version:     config.VersionSlinky,
+				Prefix:      "bar",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid DYDX chain config - conflicting chain type",
+			config: config.ChainConfig{
+				RPCAddress:  "http://rpc.example.com",
+				GRPCAddress: "http://grpc.example.com",
+				RESTAddress: "http://rest.example.com",
+				ChainID:     "foo",
+				DYDX:        true,
+				Type:        config.ChainTypeCore,
+				Version:     config.VersionSlinky,
+				Prefix:      "bar",
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid missing chain id",
 			config: config.ChainConfig{
@@ -116,6 +181,19 @@ func TestChainConfig_ValidateBasic(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid empty failover rpc address",
+			config: config.ChainConfig{
+				RPCAddress:   "http://rpc.example.com",
+				RPCAddresses: []string{"http://rpc2.example.com", ""},
+				GRPCAddress:  "http://grpc.example.com",
+				RESTAddress:  "http://rest.example.com",
+				ChainID:      "foo",
+				Version:      config.VersionSlinky,
+				Prefix:       "bar",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -129,3 +207,45 @@ func TestChainConfig_ValidateBasic(t *testing.T) {
 		})
 	}
 }
+
+func TestChainConfig_RPCEndpoints(t *testing.T) {
+	cfg := config.ChainConfig{
+		RPCAddress:   "http://rpc1.example.com",
+		RPCAddresses: []string{"http://rpc2.example.com", "http://rpc1.example.com", "http://rpc3.example.com"},
+	}
+
+	require.Equal(t, []string{
+		"http://rpc1.example.com",
+		"http://rpc2.example.com",
+		"http://rpc3.example.com",
+	}, cfg.RPCEndpoints())
+}
+
+func TestChainConfig_ChainType(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.ChainConfig
+		want   string
+	}{
+		{
+			name: "default to core",
+			want: config.ChainTypeCore,
+		},
+		{
+			name:   "default to dydx for dydx chains",
+			config: config.ChainConfig{DYDX: true},
+			want:   config.ChainTypeDYDX,
+		},
+		{
+			name:   "configured type",
+			config: config.ChainConfig{Type: "neutron"},
+			want:   "neutron",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.config.ChainType())
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+// AuthzConfig is the configuration for dispatching on behalf of the market map authority using an x/authz grant.
+// If set, the upserts keep the Granter as their authority and are wrapped in a MsgExec signed by the signing key,
+// which must have been granted MsgUpsertMarkets by the Granter.
+type AuthzConfig struct {
+	// Granter is the bech32 address of the market map authority that granted MsgUpsertMarkets to the signing key.
+	Granter string `json:"granter"`
+}
+
+func (c *AuthzConfig) ValidateBasic() error {
+	if c.Granter == "" {
+		return fmt.Errorf("granter must be set")
+	}
+
+	if _, _, err := bech32.DecodeAndConvert(c.Granter); err != nil {
+		return fmt.Errorf("invalid granter address %s: %w", c.Granter, err)
+	}
+
+	return nil
+}
@@ -14,6 +14,9 @@ type DispatchConfig struct {
 
 	// SubmitterConfig is the configuration that the transaction submitter expects.
 	SubmitterConfig SubmitterConfig `json:"submitter"`
+
+	// AuthzConfig is the configuration for dispatching on behalf of the market map authority using an x/authz grant.
+	AuthzConfig *AuthzConfig `json:"authz,omitempty"`
 }
 
 func DefaultDispatchConfig() DispatchConfig {
@@ -40,5 +43,11 @@ func (c *DispatchConfig) Validate() error {
 		return fmt.Errorf("invalid signing config: %w", err)
 	}
 
+	if c.AuthzConfig != nil {
+		if err := c.AuthzConfig.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid authz config: %w", err)
+		}
+	}
+
 	return nil
 }
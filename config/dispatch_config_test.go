@@ -81,6 +81,36 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid - authz",
+			config: config.DispatchConfig{
+				TxConfig: config.TransactionConfig{
+					MaxBytesPerTx: 1,
+					MaxGas:        1,
+					GasAdjustment: 1.5,
+					MinGasPrice:   sdk.NewDecCoin("stake", math.NewInt(100)),
+				},
+				SigningConfig:   dummySigningConfig,
+				SubmitterConfig: config.DefaultSubmitterConfig(),
+				AuthzConfig:     &config.AuthzConfig{Granter: "dydx10d07y265gmmuvt4z0w9aw880jnsr700jnmapky"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid authz granter - fail",
+			config: config.DispatchConfig{
+				TxConfig: config.TransactionConfig{
+					MaxBytesPerTx: 1,
+					MaxGas:        1,
+					GasAdjustment: 1.5,
+					MinGasPrice:   sdk.NewDecCoin("stake", math.NewInt(100)),
+				},
+				SigningConfig:   dummySigningConfig,
+				SubmitterConfig: config.DefaultSubmitterConfig(),
+				AuthzConfig:     &config.AuthzConfig{Granter: "not-an-address"},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
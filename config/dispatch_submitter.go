@@ -8,6 +8,7 @@ import (
 const (
 	DefaultPollingFrequency = time.Second * 10
 	DefaultPollingDuration  = time.Minute * 5
+	DefaultMaxResigns       = 3
 )
 
 // SubmitterConfig is the configuration for a transaction submitter.
@@ -17,12 +18,21 @@ type SubmitterConfig struct {
 
 	// PollingDuration is the total duration the submitter polls for transaction results.
 	PollingDuration time.Duration `json:"polling_duration"`
+
+	// Pipelined broadcasts the transactions of consecutive sequences back to back and then waits for their
+	// inclusion, instead of waiting for the inclusion of each transaction before broadcasting the next.
+	Pipelined bool `json:"pipelined,omitempty"`
+
+	// MaxResigns is the number of times the remaining transactions are re-signed after a sequence mismatch in
+	// pipelined mode.
+	MaxResigns int `json:"max_resigns,omitempty"`
 }
 
 func DefaultSubmitterConfig() SubmitterConfig {
 	return SubmitterConfig{
 		PollingFrequency: DefaultPollingFrequency,
 		PollingDuration:  DefaultPollingDuration,
+		MaxResigns:       DefaultMaxResigns,
 	}
 }
 
@@ -35,5 +45,9 @@ func (c *SubmitterConfig) ValidateBasic() error {
 		return fmt.Errorf("polling_duration must be greater than zero")
 	}
 
+	if c.MaxResigns < 0 {
+		return fmt.Errorf("max_resigns must be non-negative")
+	}
+
 	return nil
 }
@@ -39,6 +39,16 @@ func TestSubmitterConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative max resigns is invalid",
+			cfg: config.SubmitterConfig{
+				PollingFrequency: config.DefaultPollingFrequency,
+				PollingDuration:  config.DefaultPollingDuration,
+				Pipelined:        true,
+				MaxResigns:       -1,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2,9 +2,20 @@ package config
 
 import (
 	"errors"
+	"fmt"
 
 	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+const (
+	// GasPriceSourceStatic pays fees at the MinGasPrice.
+	GasPriceSourceStatic = "static"
+	// GasPriceSourceFeeMarket pays fees at the gas price of the chain's x/feemarket module.
+	GasPriceSourceFeeMarket = "feemarket"
+	// GasPriceSourceNode pays fees at the min-gas-prices of the node.
+	GasPriceSourceNode = "node"
 )
 
 // TransactionConfig is the necessary configuration for a MarketUpdateTransactionProvider.
@@ -20,6 +31,17 @@ type TransactionConfig struct {
 
 	// MinGasPrice is the min gas prices used for the transaction
 	MinGasPrice sdk.DecCoin `json:"min_gas_price"`
+
+	// GasPriceSource is where the gas price is discovered: static, feemarket or node. Discovered gas prices are
+	// floored at the MinGasPrice, and must be of its denom. Defaults to static.
+	GasPriceSource string `json:"gas_price_source,omitempty"`
+
+	// FeeGranter is the bech32 address of the x/feegrant granter that pays the fees of the transactions.
+	FeeGranter string `json:"fee_granter,omitempty"`
+
+	// MaxTotalFee is the maximum total fee of all transactions of a dispatch. Dispatch is aborted before any
+	// transaction is broadcast if the fees exceed it. Unlimited if empty.
+	MaxTotalFee sdk.Coins `json:"max_total_fee,omitempty"`
 }
 
 func DefaultTxConfig() TransactionConfig {
@@ -52,6 +74,22 @@ func (c *TransactionConfig) ValidateBasic() error {
 		return ErrInvalidGasAdjustment
 	}
 
+	switch c.GasPriceSource {
+	case "", GasPriceSourceStatic, GasPriceSourceFeeMarket, GasPriceSourceNode:
+	default:
+		return ErrInvalidGasPriceSource
+	}
+
+	if c.FeeGranter != "" {
+		if _, _, err := bech32.DecodeAndConvert(c.FeeGranter); err != nil {
+			return ErrInvalidFeeGranter
+		}
+	}
+
+	if !c.MaxTotalFee.IsValid() {
+		return ErrInvalidMaxTotalFee
+	}
+
 	return nil
 }
 
@@ -66,4 +104,14 @@ var (
 	ErrInvalidTxFee = errors.New("tx fee must be greater than or equal to 0")
 
 	ErrInvalidGasAdjustment = errors.New("gas adjustment must be greater than or equal to 1")
+
+	// ErrInvalidGasPriceSource is thrown when the gas price source is unknown.
+	ErrInvalidGasPriceSource = fmt.Errorf("gas price source must be one of %s, %s or %s",
+		GasPriceSourceStatic, GasPriceSourceFeeMarket, GasPriceSourceNode)
+
+	// ErrInvalidFeeGranter is thrown when the fee granter is not a bech32 address.
+	ErrInvalidFeeGranter = errors.New("fee granter must be a bech32 address")
+
+	// ErrInvalidMaxTotalFee is thrown when the max total fee is not a valid set of coins.
+	ErrInvalidMaxTotalFee = errors.New("max total fee must be valid coins")
 )
@@ -58,6 +58,52 @@ func TestTransactionConfig_ValidateBasic(t *testing.T) {
 			},
 			err: config.ErrInvalidGasAdjustment,
 		},
+		{
+			name: "invalid gas price source",
+			cfg: config.TransactionConfig{
+				MaxBytesPerTx:  1,
+				MaxGas:         1,
+				GasAdjustment:  1.5,
+				MinGasPrice:    sdk.NewDecCoin("stake", math.NewInt(100)),
+				GasPriceSource: "oracle",
+			},
+			err: config.ErrInvalidGasPriceSource,
+		},
+		{
+			name: "invalid fee granter",
+			cfg: config.TransactionConfig{
+				MaxBytesPerTx: 1,
+				MaxGas:        1,
+				GasAdjustment: 1.5,
+				MinGasPrice:   sdk.NewDecCoin("stake", math.NewInt(100)),
+				FeeGranter:    "granter",
+			},
+			err: config.ErrInvalidFeeGranter,
+		},
+		{
+			name: "invalid max total fee",
+			cfg: config.TransactionConfig{
+				MaxBytesPerTx: 1,
+				MaxGas:        1,
+				GasAdjustment: 1.5,
+				MinGasPrice:   sdk.NewDecCoin("stake", math.NewInt(100)),
+				MaxTotalFee:   sdk.Coins{sdk.Coin{Denom: "stake", Amount: math.NewInt(-1)}},
+			},
+			err: config.ErrInvalidMaxTotalFee,
+		},
+		{
+			name: "valid with fee options",
+			cfg: config.TransactionConfig{
+				MaxBytesPerTx:  1,
+				MaxGas:         1,
+				GasAdjustment:  1.5,
+				MinGasPrice:    sdk.NewDecCoin("stake", math.NewInt(100)),
+				GasPriceSource: config.GasPriceSourceFeeMarket,
+				FeeGranter:     "dydx10d07y265gmmuvt4z0w9aw880jnsr700jnmapky",
+				MaxTotalFee:    sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)),
+			},
+			err: nil,
+		},
 		{
 			name: "valid",
 			cfg: config.TransactionConfig{
@@ -41,6 +41,10 @@ type ProviderConfig struct {
 
 	// IsDefi is a flag that denotes that this provider is to be considered as a Defi venue.
 	IsDefi bool `json:"is_defi" mapstructure:"is_defi"`
+
+	// Groups is the group of this provider per group dimension (ex. operator -> okx, venue_type -> cex).
+	// Groups are used to enforce the provider diversity of markets.
+	Groups map[string]string `json:"groups,omitempty" mapstructure:"groups"`
 }
 
 // Filters is a set of filters to apply to a market on a per-provider basis.
@@ -53,6 +57,15 @@ type Filters struct {
 
 // Validate checks if the ProviderConfig is valid.
 func (pc *ProviderConfig) Validate() error {
+	for dimension, group := range pc.Groups {
+		if dimension == "" {
+			return fmt.Errorf("group dimension cannot be empty")
+		}
+		if group == "" {
+			return fmt.Errorf("group for dimension %s cannot be empty", dimension)
+		}
+	}
+
 	return nil
 }
 
@@ -68,6 +81,12 @@ type QuoteConfig struct {
 	// to be used to normalize this market. For example, Setting this to USDT/USD will convert USDT markets to be
 	// in terms of USD.
 	NormalizeByPair string `json:"normalize_by_pair" mapstructure:"normalize_by_pair"`
+	// NormalizeByCandidates is a list of currency pairs that can be used to normalize this market. If set, the
+	// normalization pair is chosen per provider: the provider's own most liquid candidate market is preferred, then
+	// the candidate market with the most liquidity across all providers. The base of each candidate must be the quote
+	// of this config, or a DeFi asset of it, and all candidates must share a quote so that markets are always
+	// normalized to the same quote. Cannot be set together with NormalizeByPair.
+	NormalizeByCandidates []string `json:"normalize_by_candidates,omitempty" mapstructure:"normalize_by_candidates"`
 }
 
 // Validate checks if the QuoteConfig is valid.
@@ -86,9 +105,64 @@ func (qc *QuoteConfig) Validate() error {
 		}
 	}
 
+	if len(qc.NormalizeByCandidates) > 0 && qc.NormalizeByPair != "" {
+		return fmt.Errorf("cannot set both normalize_by_pair and normalize_by_candidates")
+	}
+
+	seen := make(map[string]struct{}, len(qc.NormalizeByCandidates))
+	var quote string
+	for _, candidate := range qc.NormalizeByCandidates {
+		cp, err := connecttypes.CurrencyPairFromString(candidate)
+		if err != nil {
+			return fmt.Errorf("normalize_by_candidates must be valid currency pairs: %w", err)
+		}
+
+		if _, found := seen[cp.String()]; found {
+			return fmt.Errorf("duplicate normalize_by_candidates entry %s", candidate)
+		}
+		seen[cp.String()] = struct{}{}
+
+		// markets are normalized to the quote of the selected candidate, so all candidates must normalize to the same
+		// quote for the markets of this quote to have a single price denomination
+		if quote == "" {
+			quote = cp.Quote
+		} else if cp.Quote != quote {
+			return fmt.Errorf("normalize_by_candidates must share a quote: %s has quote %s, expected %s", candidate,
+				cp.Quote, quote)
+		}
+	}
+
 	return nil
 }
 
+// NormalizesTo returns the quote that markets with this quote config are normalized to: the quote of the
+// NormalizeByPair if set, otherwise the quote shared by all NormalizeByCandidates. False is returned if markets are
+// not normalized, or if the candidates do not share a quote.
+func (qc *QuoteConfig) NormalizesTo() (string, bool) {
+	pairs := qc.NormalizeByCandidates
+	if qc.NormalizeByPair != "" {
+		pairs = []string{qc.NormalizeByPair}
+	}
+	if len(pairs) == 0 {
+		return "", false
+	}
+
+	var quote string
+	for _, pair := range pairs {
+		cp, err := connecttypes.CurrencyPairFromString(pair)
+		if err != nil {
+			return "", false
+		}
+
+		if quote != "" && cp.Quote != quote {
+			return "", false
+		}
+		quote = cp.Quote
+	}
+
+	return quote, true
+}
+
 // GenerateConfig contains all configuration for generating a market map from data input.
 type GenerateConfig struct {
 	// Providers is a map of provider name -> ProviderConfig
@@ -127,6 +201,36 @@ type GenerateConfig struct {
 	// We require this value to be LTE the highest MinProviderCount for any Market in order to avoid producing markets
 	// which would be unable to post prices ever.
 	MinProviderCountOverride uint64 `json:"min_provider_count_override" mapstructure:"min_provider_count_override"`
+
+	// Pipeline optionally configures the order and set of transforms run during generation.
+	// If nil, the default pipeline is used.
+	Pipeline *PipelineConfig `json:"pipeline,omitempty" mapstructure:"pipeline"`
+
+	// Rules is a list of expression-based filter rules that are applied to feeds and markets during generation.
+	Rules []RuleConfig `json:"rules,omitempty" mapstructure:"rules"`
+
+	// MinProviderCountPolicy optionally derives the MinProviderCount of each market from its providers.
+	// If set, it takes precedence over MinProviderCountOverride.
+	MinProviderCountPolicy *MinProviderCountPolicyConfig `json:"min_provider_count_policy,omitempty" mapstructure:"min_provider_count_policy"`
+
+	// Tiers is an ordered list of market tiers with tier-specific thresholds, provider counts and enablement.
+	// Markets that do not match any tier use the global configuration.
+	Tiers []TierConfig `json:"tiers,omitempty" mapstructure:"tiers"`
+
+	// Diversity optionally constrains the diversity of the providers of each market.
+	Diversity *DiversityConfig `json:"diversity,omitempty" mapstructure:"diversity"`
+
+	// AssetAliases is the table of wrapped or bridged assets that are aliases of native assets.
+	// If nil, DefaultAssetAliases are used. Set it to an empty list to disable aliasing.
+	AssetAliases []AssetAliasConfig `json:"asset_aliases,omitempty" mapstructure:"asset_aliases"`
+
+	// TradingStatuses maps trading statuses of provider markets to whether their feeds are included or excluded.
+	// Statuses that are not configured use DefaultTradingStatuses.
+	TradingStatuses map[string]string `json:"trading_statuses,omitempty" mapstructure:"trading_statuses"`
+
+	// MarketPatches are operations applied to individual generated markets, in order, after generation.
+	// Patches of the same market may not write the same part of it.
+	MarketPatches []MarketPatchConfig `json:"market_patches,omitempty" mapstructure:"market_patches"`
 }
 
 var defaultProviders = map[string]ProviderConfig{
@@ -187,7 +291,13 @@ func DefaultGenerateConfig() GenerateConfig {
 // - min market volume for each quote is non-negative
 // - min provider count is greater than zero
 // - min volumes exist for each quote
-// - min market-volume (per quote) >= min provider-volume * min-providers (per quote).
+// - min market-volume (per quote) >= min provider-volume * min-providers (per quote)
+// - the pipeline, if configured, only contains named and unique stages
+// - rules have unique IDs, supported actions and parseable expressions
+// - the min provider count policy, if configured, only contains valid rules
+// - tiers have unique names, valid criteria and provider counts GTE MinProviderCountOverride
+// - the diversity constraints, if configured, are valid
+// - asset aliases are valid, unique per wrapped asset and not chained.
 func (cfg *GenerateConfig) Validate() error {
 	for name, providerCfg := range cfg.Providers {
 		if err := ValidateProviderName(name); err != nil {
@@ -208,6 +318,15 @@ func (cfg *GenerateConfig) Validate() error {
 		if err := quoteCfg.Validate(); err != nil {
 			return fmt.Errorf("invalid quote config for quote %q: %w", quote, err)
 		}
+
+		for _, candidate := range quoteCfg.NormalizeByCandidates {
+			// the base of a DeFi candidate is the symbol of the quote followed by its venue and address
+			cp, _ := connecttypes.CurrencyPairFromString(candidate)
+			if symbol, _, _ := strings.Cut(cp.Base, ","); symbol != quote {
+				return fmt.Errorf("invalid quote config for quote %q: normalize_by_candidates entry %s must have base %s",
+					quote, candidate, quote)
+			}
+		}
 	}
 
 	if len(cfg.ExcludeCurrencyPairs) > 0 && len(cfg.AllowedCurrencyPairs) > 0 {
@@ -251,6 +370,74 @@ func (cfg *GenerateConfig) Validate() error {
 		return fmt.Errorf("invalid MinProviderCountOverride: must be less than %d, got %d", int(math.Min(float64(cfg.MinCexProviderCount), float64(cfg.MinDexProviderCount))), cfg.MinProviderCountOverride)
 	}
 
+	if cfg.Pipeline != nil {
+		if err := cfg.Pipeline.Validate(); err != nil {
+			return fmt.Errorf("invalid pipeline: %w", err)
+		}
+	}
+
+	ruleIDs := make(map[string]struct{}, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid rule: %w", err)
+		}
+
+		if _, found := ruleIDs[rule.ID]; found {
+			return fmt.Errorf("duplicate rule id %q", rule.ID)
+		}
+		ruleIDs[rule.ID] = struct{}{}
+	}
+
+	if cfg.MinProviderCountPolicy != nil {
+		if err := cfg.MinProviderCountPolicy.Validate(); err != nil {
+			return fmt.Errorf("invalid min_provider_count_policy: %w", err)
+		}
+	}
+
+	tierNames := make(map[string]struct{}, len(cfg.Tiers))
+	for _, tier := range cfg.Tiers {
+		if err := tier.Validate(); err != nil {
+			return fmt.Errorf("invalid tier %q: %w", tier.Name, err)
+		}
+
+		if _, found := tierNames[tier.Name]; found {
+			return fmt.Errorf("duplicate tier name %q", tier.Name)
+		}
+		tierNames[tier.Name] = struct{}{}
+
+		if (tier.MinCexProviderCount != 0 && tier.MinCexProviderCount < cfg.MinProviderCountOverride) ||
+			(tier.MinDexProviderCount != 0 && tier.MinDexProviderCount < cfg.MinProviderCountOverride) {
+			return fmt.Errorf("invalid tier %q: provider counts must be GTE MinProviderCountOverride %d",
+				tier.Name, cfg.MinProviderCountOverride)
+		}
+	}
+
+	if cfg.MinProviderCountPolicy != nil {
+		for tier := range cfg.MinProviderCountPolicy.Tiers {
+			if _, found := tierNames[tier]; !found {
+				return fmt.Errorf("invalid min_provider_count_policy: rule for unknown tier %q", tier)
+			}
+		}
+	}
+
+	if cfg.Diversity != nil {
+		if err := cfg.Diversity.Validate(); err != nil {
+			return fmt.Errorf("invalid diversity: %w", err)
+		}
+	}
+
+	if err := validateAssetAliases(cfg.AssetAliases); err != nil {
+		return err
+	}
+
+	if err := validateTradingStatuses(cfg.TradingStatuses); err != nil {
+		return err
+	}
+
+	if err := validateMarketPatches(cfg.MarketPatches, cfg.MarketMapOverride); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+)
+
+// AssetAliasConfig declares a wrapped or bridged asset (ex. WETH, cbBTC, USDC.e) as an alias of its native asset.
+type AssetAliasConfig struct {
+	// WrappedCMCID is the CoinMarketCap ID of the wrapped or bridged asset.
+	WrappedCMCID int64 `json:"wrapped_cmc_id" mapstructure:"wrapped_cmc_id"`
+
+	// NativeCMCID is the CoinMarketCap ID of the native asset.
+	NativeCMCID int64 `json:"native_cmc_id" mapstructure:"native_cmc_id"`
+
+	// NativeSymbol is the ticker symbol of the native asset. If set and BackNative is true, feeds of the wrapped
+	// asset use it as their symbol so that they are generated as feeds of the native market.
+	NativeSymbol string `json:"native_symbol,omitempty" mapstructure:"native_symbol"`
+
+	// BackNative designates whether feeds of the wrapped asset may back markets of the native asset.
+	// If false, the alias only sets the aggregate ID in the ticker metadata of the wrapped asset's markets
+	// to the native asset, and the wrapped asset's markets are generated separately.
+	BackNative bool `json:"back_native" mapstructure:"back_native"`
+}
+
+// Validate checks if the AssetAliasConfig is valid.
+func (ac *AssetAliasConfig) Validate() error {
+	if ac.WrappedCMCID <= 0 {
+		return fmt.Errorf("wrapped_cmc_id must be positive")
+	}
+
+	if ac.NativeCMCID <= 0 {
+		return fmt.Errorf("native_cmc_id must be positive")
+	}
+
+	if ac.WrappedCMCID == ac.NativeCMCID {
+		return fmt.Errorf("wrapped_cmc_id and native_cmc_id cannot be equal")
+	}
+
+	if ac.NativeSymbol != "" && !ac.BackNative {
+		return fmt.Errorf("native_symbol can only be set if back_native is true")
+	}
+
+	return nil
+}
+
+// DefaultAssetAliases are the asset aliases used when none are configured.
+func DefaultAssetAliases() []AssetAliasConfig {
+	return []AssetAliasConfig{
+		// Wrapped SOL -> SOL
+		// - SOL:  https://coinmarketcap.com/currencies/solana/
+		// - Wrapped SOL: https://coinmarketcap.com/currencies/wrapped-solana/
+		{WrappedCMCID: 16116, NativeCMCID: 5426, BackNative: true},
+	}
+}
+
+// validateAssetAliases checks that the aliases are valid, that every wrapped asset is aliased at most once and
+// that aliases are not chained, i.e. no native asset is itself an alias.
+func validateAssetAliases(aliases []AssetAliasConfig) error {
+	wrapped := make(map[int64]struct{}, len(aliases))
+	for _, alias := range aliases {
+		if err := alias.Validate(); err != nil {
+			return fmt.Errorf("invalid asset alias for %d: %w", alias.WrappedCMCID, err)
+		}
+
+		if _, found := wrapped[alias.WrappedCMCID]; found {
+			return fmt.Errorf("duplicate asset alias for %d", alias.WrappedCMCID)
+		}
+		wrapped[alias.WrappedCMCID] = struct{}{}
+	}
+
+	for _, alias := range aliases {
+		if _, found := wrapped[alias.NativeCMCID]; found {
+			return fmt.Errorf("invalid asset alias for %d: native asset %d is itself an alias",
+				alias.WrappedCMCID, alias.NativeCMCID)
+		}
+	}
+
+	return nil
+}
+
+// AssetAlias returns the alias of the asset with the given CoinMarketCap ID. DefaultAssetAliases are used if
+// AssetAliases is nil. False is returned if the asset is not an alias.
+func (cfg *GenerateConfig) AssetAlias(cmcID int64) (AssetAliasConfig, bool) {
+	aliases := cfg.AssetAliases
+	if aliases == nil {
+		aliases = DefaultAssetAliases()
+	}
+
+	for _, alias := range aliases {
+		if alias.WrappedCMCID == cmcID {
+			return alias, true
+		}
+	}
+
+	return AssetAliasConfig{}, false
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Commonly used provider group dimensions. Any dimension may be configured.
+const (
+	// GroupOperator groups providers by the entity operating them, ex. the API and WS variants of an exchange.
+	GroupOperator = "operator"
+	// GroupVenueType groups providers by the kind of venue, ex. cex or a DEX liquidity source.
+	GroupVenueType = "venue_type"
+	// GroupJurisdiction groups providers by the jurisdiction they operate in.
+	GroupJurisdiction = "jurisdiction"
+)
+
+// DiversityConfig constrains how diverse the providers of a market must be.
+// Only non-supplemental providers are considered.
+type DiversityConfig struct {
+	// MinDistinctGroups is the minimum number of distinct groups, keyed by group dimension (ex. operator), that the
+	// providers of a market must belong to. Providers without a group for a dimension form their own group.
+	MinDistinctGroups map[string]uint64 `json:"min_distinct_groups,omitempty" mapstructure:"min_distinct_groups"`
+
+	// MaxDefiShare is the maximum share in [0, 1] of DeFi providers among the providers of an enabled market.
+	// DeFi providers exceeding the share are removed from the market. A value of 0 means there is no cap.
+	MaxDefiShare float64 `json:"max_defi_share,omitempty" mapstructure:"max_defi_share"`
+}
+
+// Validate checks if the DiversityConfig is valid.
+func (dc *DiversityConfig) Validate() error {
+	for dimension, minGroups := range dc.MinDistinctGroups {
+		if dimension == "" {
+			return fmt.Errorf("group dimension cannot be empty")
+		}
+		if minGroups < 1 {
+			return fmt.Errorf("min_distinct_groups for %s must be GTE 1", dimension)
+		}
+	}
+
+	if dc.MaxDefiShare < 0 || dc.MaxDefiShare > 1 {
+		return fmt.Errorf("max_defi_share must be in [0, 1]")
+	}
+
+	return nil
+}
+
+// ProviderGroup returns the group of the provider for the given dimension. Providers without a configured group
+// for the dimension form their own group, keyed by their name.
+func (cfg *GenerateConfig) ProviderGroup(providerName, dimension string) string {
+	if group := cfg.Providers[providerName].Groups[dimension]; group != "" {
+		return group
+	}
+
+	return "provider:" + providerName
+}
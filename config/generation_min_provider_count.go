@@ -0,0 +1,186 @@
+package config
+
+import (
+	"fmt"
+	"math"
+)
+
+// MinProviderCountPolicyConfig derives the MinProviderCount of every market from the set of providers of the market.
+// The most specific rule is used for each market: Markets, then Tiers, then Quotes, then the default rule.
+type MinProviderCountPolicyConfig struct {
+	// Default is the rule used for markets without a more specific rule.
+	Default MinProviderCountRule `json:"default" mapstructure:"default"`
+
+	// Quotes are rules for all markets of a quote, keyed by quote (ex. USD).
+	Quotes map[string]MinProviderCountRule `json:"quotes,omitempty" mapstructure:"quotes"`
+
+	// Tiers are rules for all markets of a tier, keyed by the name of the tier (ex. long-tail).
+	Tiers map[string]MinProviderCountRule `json:"tiers,omitempty" mapstructure:"tiers"`
+
+	// Markets are rules for single markets, keyed by ticker (ex. BTC/USD).
+	Markets map[string]MinProviderCountRule `json:"markets,omitempty" mapstructure:"markets"`
+}
+
+// MinProviderCountRule computes a MinProviderCount from the number of providers of a market.
+// Exactly one of Ladder, Fraction or Split must be set.
+type MinProviderCountRule struct {
+	// Ladder uses the step with the highest Providers that is LTE the number of providers of the market.
+	Ladder []MinProviderCountStep `json:"ladder,omitempty" mapstructure:"ladder"`
+
+	// Fraction requires a fraction of the providers of the market, bounded by a floor and ceiling.
+	Fraction *MinProviderCountFraction `json:"fraction,omitempty" mapstructure:"fraction"`
+
+	// Split applies separate rules to the CEX and DEX providers of the market and adds the results.
+	Split *MinProviderCountSplit `json:"split,omitempty" mapstructure:"split"`
+}
+
+// MinProviderCountStep is a single step of a ladder rule.
+type MinProviderCountStep struct {
+	// Providers is the number of providers a market needs for this step to apply.
+	Providers uint64 `json:"providers" mapstructure:"providers"`
+	// MinProviderCount is the MinProviderCount of markets this step applies to.
+	MinProviderCount uint64 `json:"min_provider_count" mapstructure:"min_provider_count"`
+}
+
+// MinProviderCountFraction requires ceil(Fraction * providers), clamped to [Floor, Ceiling].
+type MinProviderCountFraction struct {
+	Fraction float64 `json:"fraction" mapstructure:"fraction"`
+	Floor    uint64  `json:"floor" mapstructure:"floor"`
+	// Ceiling is the maximum MinProviderCount. A value of 0 means there is no ceiling.
+	Ceiling uint64 `json:"ceiling,omitempty" mapstructure:"ceiling"`
+}
+
+// MinProviderCountSplit applies separate rules to the CEX and DEX providers of a market. A rule is only applied
+// if the market has at least one provider of its kind.
+type MinProviderCountSplit struct {
+	Cex MinProviderCountRule `json:"cex" mapstructure:"cex"`
+	Dex MinProviderCountRule `json:"dex" mapstructure:"dex"`
+}
+
+// Validate checks that every rule of the policy is valid.
+func (p *MinProviderCountPolicyConfig) Validate() error {
+	if err := p.Default.Validate(); err != nil {
+		return fmt.Errorf("invalid default rule: %w", err)
+	}
+
+	for quote, rule := range p.Quotes {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid rule for quote %s: %w", quote, err)
+		}
+	}
+
+	for tier, rule := range p.Tiers {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid rule for tier %s: %w", tier, err)
+		}
+	}
+
+	for ticker, rule := range p.Markets {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid rule for market %s: %w", ticker, err)
+		}
+	}
+
+	return nil
+}
+
+// RuleFor returns the most specific rule for the market with the given ticker, tier and quote. The tier is empty
+// for markets without a tier.
+func (p *MinProviderCountPolicyConfig) RuleFor(ticker, tier, quote string) MinProviderCountRule {
+	if rule, found := p.Markets[ticker]; found {
+		return rule
+	}
+
+	if rule, found := p.Tiers[tier]; found && tier != "" {
+		return rule
+	}
+
+	if rule, found := p.Quotes[quote]; found {
+		return rule
+	}
+
+	return p.Default
+}
+
+// Validate checks that exactly one kind of rule is set and that it is well-formed.
+func (r *MinProviderCountRule) Validate() error {
+	set := 0
+	if len(r.Ladder) > 0 {
+		set++
+	}
+	if r.Fraction != nil {
+		set++
+	}
+	if r.Split != nil {
+		set++
+	}
+	if set != 1 {
+		return fmt.Errorf("exactly one of ladder, fraction or split must be set")
+	}
+
+	switch {
+	case len(r.Ladder) > 0:
+		for i, step := range r.Ladder {
+			if step.MinProviderCount < 1 {
+				return fmt.Errorf("ladder step %d: min_provider_count must be GTE 1", i)
+			}
+			if i > 0 && step.Providers <= r.Ladder[i-1].Providers {
+				return fmt.Errorf("ladder step %d: providers must be strictly increasing", i)
+			}
+		}
+	case r.Fraction != nil:
+		if r.Fraction.Fraction <= 0 || r.Fraction.Fraction > 1 {
+			return fmt.Errorf("fraction must be in (0, 1]")
+		}
+		if r.Fraction.Floor < 1 {
+			return fmt.Errorf("floor must be GTE 1")
+		}
+		if r.Fraction.Ceiling != 0 && r.Fraction.Ceiling < r.Fraction.Floor {
+			return fmt.Errorf("ceiling must be GTE floor")
+		}
+	case r.Split != nil:
+		if r.Split.Cex.Split != nil || r.Split.Dex.Split != nil {
+			return fmt.Errorf("split rules cannot be nested")
+		}
+		if err := r.Split.Cex.Validate(); err != nil {
+			return fmt.Errorf("invalid cex rule: %w", err)
+		}
+		if err := r.Split.Dex.Validate(); err != nil {
+			return fmt.Errorf("invalid dex rule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// MinProviderCount returns the MinProviderCount for a market with the given number of CEX and DEX providers.
+// The result is always GTE 1.
+func (r *MinProviderCountRule) MinProviderCount(cexProviders, dexProviders uint64) uint64 {
+	var out uint64
+	switch {
+	case len(r.Ladder) > 0:
+		providers := cexProviders + dexProviders
+		for _, step := range r.Ladder {
+			if step.Providers > providers {
+				break
+			}
+			out = step.MinProviderCount
+		}
+	case r.Fraction != nil:
+		providers := cexProviders + dexProviders
+		out = uint64(math.Ceil(r.Fraction.Fraction * float64(providers)))
+		out = max(out, r.Fraction.Floor)
+		if r.Fraction.Ceiling != 0 {
+			out = min(out, r.Fraction.Ceiling)
+		}
+	case r.Split != nil:
+		if cexProviders > 0 {
+			out += r.Split.Cex.MinProviderCount(cexProviders, 0)
+		}
+		if dexProviders > 0 {
+			out += r.Split.Dex.MinProviderCount(0, dexProviders)
+		}
+	}
+
+	return max(out, 1)
+}
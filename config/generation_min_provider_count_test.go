@@ -0,0 +1,136 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+func TestMinProviderCountRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    config.MinProviderCountRule
+		wantErr bool
+	}{
+		{
+			name: "valid ladder",
+			rule: config.MinProviderCountRule{Ladder: []config.MinProviderCountStep{
+				{Providers: 1, MinProviderCount: 1},
+				{Providers: 4, MinProviderCount: 2},
+			}},
+		},
+		{
+			name:    "no rule",
+			rule:    config.MinProviderCountRule{},
+			wantErr: true,
+		},
+		{
+			name: "multiple rules",
+			rule: config.MinProviderCountRule{
+				Ladder:   []config.MinProviderCountStep{{Providers: 1, MinProviderCount: 1}},
+				Fraction: &config.MinProviderCountFraction{Fraction: 0.5, Floor: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ladder not increasing",
+			rule: config.MinProviderCountRule{Ladder: []config.MinProviderCountStep{
+				{Providers: 4, MinProviderCount: 2},
+				{Providers: 4, MinProviderCount: 3},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "fraction out of range",
+			rule:    config.MinProviderCountRule{Fraction: &config.MinProviderCountFraction{Fraction: 1.5, Floor: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "ceiling below floor",
+			rule:    config.MinProviderCountRule{Fraction: &config.MinProviderCountFraction{Fraction: 0.5, Floor: 3, Ceiling: 2}},
+			wantErr: true,
+		},
+		{
+			name: "nested split",
+			rule: config.MinProviderCountRule{Split: &config.MinProviderCountSplit{
+				Cex: config.MinProviderCountRule{Split: &config.MinProviderCountSplit{}},
+				Dex: config.MinProviderCountRule{Fraction: &config.MinProviderCountFraction{Fraction: 0.5, Floor: 1}},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMinProviderCountRule_MinProviderCount(t *testing.T) {
+	ladder := config.MinProviderCountRule{Ladder: []config.MinProviderCountStep{
+		{Providers: 2, MinProviderCount: 2},
+		{Providers: 5, MinProviderCount: 3},
+	}}
+	fraction := config.MinProviderCountRule{Fraction: &config.MinProviderCountFraction{Fraction: 0.5, Floor: 2, Ceiling: 4}}
+	split := config.MinProviderCountRule{Split: &config.MinProviderCountSplit{
+		Cex: fraction,
+		Dex: config.MinProviderCountRule{Ladder: []config.MinProviderCountStep{{Providers: 1, MinProviderCount: 1}}},
+	}}
+
+	tests := []struct {
+		name     string
+		rule     config.MinProviderCountRule
+		cex, dex uint64
+		expected uint64
+	}{
+		{name: "ladder below first step", rule: ladder, cex: 1, expected: 1},
+		{name: "ladder first step", rule: ladder, cex: 3, expected: 2},
+		{name: "ladder counts dex providers", rule: ladder, cex: 3, dex: 3, expected: 3},
+		{name: "fraction floor", rule: fraction, cex: 2, expected: 2},
+		{name: "fraction rounds up", rule: fraction, cex: 5, expected: 3},
+		{name: "fraction ceiling", rule: fraction, cex: 12, expected: 4},
+		{name: "split cex and dex", rule: split, cex: 6, dex: 2, expected: 4},
+		{name: "split dex only", rule: split, dex: 2, expected: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.rule.MinProviderCount(tt.cex, tt.dex))
+		})
+	}
+}
+
+func TestMinProviderCountPolicyConfig_RuleFor(t *testing.T) {
+	defaultRule := config.MinProviderCountRule{Fraction: &config.MinProviderCountFraction{Fraction: 0.5, Floor: 1}}
+	quoteRule := config.MinProviderCountRule{Fraction: &config.MinProviderCountFraction{Fraction: 0.75, Floor: 1}}
+	marketRule := config.MinProviderCountRule{Fraction: &config.MinProviderCountFraction{Fraction: 1, Floor: 1}}
+	tierRule := config.MinProviderCountRule{Ladder: []config.MinProviderCountStep{{Providers: 1, MinProviderCount: 1}}}
+
+	policy := config.MinProviderCountPolicyConfig{
+		Default: defaultRule,
+		Quotes:  map[string]config.MinProviderCountRule{"USDT": quoteRule},
+		Tiers:   map[string]config.MinProviderCountRule{"long-tail": tierRule},
+		Markets: map[string]config.MinProviderCountRule{"BTC/USDT": marketRule},
+	}
+	require.NoError(t, policy.Validate())
+
+	require.Equal(t, marketRule, policy.RuleFor("BTC/USDT", "", "USDT"))
+	require.Equal(t, marketRule, policy.RuleFor("BTC/USDT", "long-tail", "USDT"))
+	require.Equal(t, tierRule, policy.RuleFor("MOG/USDT", "long-tail", "USDT"))
+	require.Equal(t, quoteRule, policy.RuleFor("ETH/USDT", "", "USDT"))
+	require.Equal(t, quoteRule, policy.RuleFor("ETH/USDT", "core", "USDT"))
+	require.Equal(t, defaultRule, policy.RuleFor("ETH/USD", "", "USD"))
+
+	policy.Tiers["core"] = config.MinProviderCountRule{}
+	require.Error(t, policy.Validate())
+	delete(policy.Tiers, "core")
+
+	policy.Quotes["USD"] = config.MinProviderCountRule{}
+	require.Error(t, policy.Validate())
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	"github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+// MarketPatchOp is the operation a MarketPatchConfig applies to its market.
+type MarketPatchOp string
+
+const (
+	// MarketPatchAddProvider adds the ProviderConfig to the market.
+	MarketPatchAddProvider MarketPatchOp = "add_provider"
+	// MarketPatchRemoveProvider removes the provider config of Provider from the market.
+	MarketPatchRemoveProvider MarketPatchOp = "remove_provider"
+	// MarketPatchReplaceProvider replaces the provider config of the market with the same name as the ProviderConfig.
+	MarketPatchReplaceProvider MarketPatchOp = "replace_provider"
+	// MarketPatchSetDecimals sets the Decimals of the market's ticker.
+	MarketPatchSetDecimals MarketPatchOp = "set_decimals"
+	// MarketPatchSetMinProviderCount sets the MinProviderCount of the market's ticker.
+	MarketPatchSetMinProviderCount MarketPatchOp = "set_min_provider_count"
+	// MarketPatchSetEnabled pins the Enabled flag of the market's ticker.
+	MarketPatchSetEnabled MarketPatchOp = "set_enabled"
+	// MarketPatchMergeMetadata merges the Metadata keys into the metadata JSON of the market's ticker.
+	MarketPatchMergeMetadata MarketPatchOp = "merge_metadata"
+)
+
+// MarketPatchOps are all supported patch operations.
+var MarketPatchOps = []MarketPatchOp{
+	MarketPatchAddProvider,
+	MarketPatchRemoveProvider,
+	MarketPatchReplaceProvider,
+	MarketPatchSetDecimals,
+	MarketPatchSetMinProviderCount,
+	MarketPatchSetEnabled,
+	MarketPatchMergeMetadata,
+}
+
+// MarketPatchConfig is a single operation applied to a generated market. Unlike the MarketMapOverride, which replaces
+// the whole market, a patch only changes the targeted field, so the rest of the market keeps following the
+// provider data.
+type MarketPatchConfig struct {
+	// Ticker is the currency pair of the patched market, ex. BTC/USD.
+	Ticker string `json:"ticker" mapstructure:"ticker"`
+
+	// Op is the operation applied to the market.
+	Op MarketPatchOp `json:"op" mapstructure:"op"`
+
+	// Provider is the name of the provider removed by remove_provider.
+	Provider string `json:"provider,omitempty" mapstructure:"provider"`
+
+	// ProviderConfig is the provider config added by add_provider, or the replacement of the provider config with the
+	// same name for replace_provider.
+	ProviderConfig *types.ProviderConfig `json:"provider_config,omitempty" mapstructure:"provider_config"`
+
+	// Decimals is the value set by set_decimals.
+	Decimals uint64 `json:"decimals,omitempty" mapstructure:"decimals"`
+
+	// MinProviderCount is the value set by set_min_provider_count.
+	MinProviderCount uint64 `json:"min_provider_count,omitempty" mapstructure:"min_provider_count"`
+
+	// Enabled is the value set by set_enabled.
+	Enabled *bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Metadata are the top-level keys of the ticker metadata set by merge_metadata.
+	Metadata map[string]json.RawMessage `json:"metadata,omitempty" mapstructure:"metadata"`
+}
+
+// Validate checks that the patch targets a valid ticker and sets exactly the fields its operation requires.
+func (pc *MarketPatchConfig) Validate() error {
+	if _, err := connecttypes.CurrencyPairFromString(pc.Ticker); err != nil {
+		return fmt.Errorf("invalid ticker %q: %w", pc.Ticker, err)
+	}
+
+	switch pc.Op {
+	case MarketPatchAddProvider, MarketPatchReplaceProvider:
+		if pc.ProviderConfig == nil {
+			return fmt.Errorf("%s requires a provider_config", pc.Op)
+		}
+		if err := pc.ProviderConfig.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid provider_config: %w", err)
+		}
+	case MarketPatchRemoveProvider:
+		if pc.Provider == "" {
+			return fmt.Errorf("%s requires a provider", pc.Op)
+		}
+	case MarketPatchSetDecimals:
+		if pc.Decimals == 0 {
+			return fmt.Errorf("%s requires decimals > 0", pc.Op)
+		}
+	case MarketPatchSetMinProviderCount:
+		if pc.MinProviderCount == 0 {
+			return fmt.Errorf("%s requires min_provider_count > 0", pc.Op)
+		}
+	case MarketPatchSetEnabled:
+		if pc.Enabled == nil {
+			return fmt.Errorf("%s requires enabled", pc.Op)
+		}
+	case MarketPatchMergeMetadata:
+		if len(pc.Metadata) == 0 {
+			return fmt.Errorf("%s requires metadata", pc.Op)
+		}
+	default:
+		return fmt.Errorf("invalid op %q: must be one of %v", pc.Op, MarketPatchOps)
+	}
+
+	return nil
+}
+
+// targets returns the parts of the market that the patch writes. Two patches of the same market that write the same
+// part conflict, since the result would depend on their order.
+func (pc *MarketPatchConfig) targets() []string {
+	switch pc.Op {
+	case MarketPatchAddProvider, MarketPatchReplaceProvider:
+		return []string{"provider " + pc.ProviderConfig.Name}
+	case MarketPatchRemoveProvider:
+		return []string{"provider " + pc.Provider}
+	case MarketPatchMergeMetadata:
+		keys := make([]string, 0, len(pc.Metadata))
+		for key := range pc.Metadata {
+			keys = append(keys, "metadata key "+key)
+		}
+		sort.Strings(keys)
+		return keys
+	default:
+		return []string{string(pc.Op)}
+	}
+}
+
+// validateMarketPatches checks that every patch is valid, that no two patches of a market write the same part of it,
+// and that no patched market is also replaced by the market map override.
+func validateMarketPatches(patches []MarketPatchConfig, override types.MarketMap) error {
+	written := make(map[string]int)
+	for i, patch := range patches {
+		if err := patch.Validate(); err != nil {
+			return fmt.Errorf("invalid market patch %d: %w", i, err)
+		}
+
+		if _, found := override.Markets[patch.Ticker]; found {
+			return fmt.Errorf("invalid market patch %d: market %s is also replaced by the market_map_override", i, patch.Ticker)
+		}
+
+		for _, target := range patch.targets() {
+			key := patch.Ticker + ": " + target
+			if j, found := written[key]; found {
+				return fmt.Errorf("market patches %d and %d conflict: both write %s", j, i, key)
+			}
+			written[key] = i
+		}
+	}
+
+	return nil
+}
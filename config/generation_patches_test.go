@@ -0,0 +1,105 @@
+package config_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+func TestValidateMarketPatches(t *testing.T) {
+	enabled := true
+	kraken := &mmtypes.ProviderConfig{Name: "kraken_api", OffChainTicker: "XXBTZUSD"}
+
+	tests := []struct {
+		name     string
+		patches  []config.MarketPatchConfig
+		override mmtypes.MarketMap
+		wantErr  bool
+	}{
+		{
+			name: "valid patches of different parts of a market",
+			patches: []config.MarketPatchConfig{
+				{Ticker: "BTC/USD", Op: config.MarketPatchReplaceProvider, ProviderConfig: kraken},
+				{Ticker: "BTC/USD", Op: config.MarketPatchRemoveProvider, Provider: "okx_ws"},
+				{Ticker: "BTC/USD", Op: config.MarketPatchSetEnabled, Enabled: &enabled},
+				{Ticker: "BTC/USD", Op: config.MarketPatchMergeMetadata, Metadata: map[string]json.RawMessage{"tier": []byte(`"core"`)}},
+				{Ticker: "BTC/USD", Op: config.MarketPatchMergeMetadata, Metadata: map[string]json.RawMessage{"note": []byte(`"pinned"`)}},
+				{Ticker: "ETH/USD", Op: config.MarketPatchSetEnabled, Enabled: &enabled},
+			},
+		},
+		{
+			name:    "invalid ticker",
+			patches: []config.MarketPatchConfig{{Ticker: "BTCUSD", Op: config.MarketPatchSetDecimals, Decimals: 8}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid op",
+			patches: []config.MarketPatchConfig{{Ticker: "BTC/USD", Op: "rename"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid add without provider config",
+			patches: []config.MarketPatchConfig{{Ticker: "BTC/USD", Op: config.MarketPatchAddProvider}},
+			wantErr: true,
+		},
+		{
+			name: "invalid provider config",
+			patches: []config.MarketPatchConfig{
+				{Ticker: "BTC/USD", Op: config.MarketPatchAddProvider, ProviderConfig: &mmtypes.ProviderConfig{Name: "kraken_api"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid set_enabled without enabled",
+			patches: []config.MarketPatchConfig{{Ticker: "BTC/USD", Op: config.MarketPatchSetEnabled}},
+			wantErr: true,
+		},
+		{
+			name: "invalid conflicting patches of a provider",
+			patches: []config.MarketPatchConfig{
+				{Ticker: "BTC/USD", Op: config.MarketPatchReplaceProvider, ProviderConfig: kraken},
+				{Ticker: "BTC/USD", Op: config.MarketPatchRemoveProvider, Provider: "kraken_api"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid conflicting metadata keys",
+			patches: []config.MarketPatchConfig{
+				{Ticker: "BTC/USD", Op: config.MarketPatchMergeMetadata, Metadata: map[string]json.RawMessage{"tier": []byte(`"core"`)}},
+				{Ticker: "BTC/USD", Op: config.MarketPatchMergeMetadata, Metadata: map[string]json.RawMessage{"tier": []byte(`"mid"`)}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "invalid patch of an overridden market",
+			patches: []config.MarketPatchConfig{{Ticker: "BTC/USD", Op: config.MarketPatchSetEnabled, Enabled: &enabled}},
+			override: mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+				"BTC/USD": {
+					Ticker:          mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD"), Decimals: 8, MinProviderCount: 1},
+					ProviderConfigs: []mmtypes.ProviderConfig{*kraken},
+				},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultGenerateConfig()
+			cfg.MarketPatches = tt.patches
+			cfg.MarketMapOverride = tt.override
+
+			err := cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+)
+
+// PipelineConfig configures which transforms are run during generation, and in which order.
+// If a PipelineConfig is not provided, the default pipeline of the generator is used.
+type PipelineConfig struct {
+	// FeedTransforms is the ordered list of transforms that are applied to the queried feeds.
+	FeedTransforms []TransformStageConfig `json:"feed_transforms" mapstructure:"feed_transforms"`
+
+	// MarketMapTransforms is the ordered list of transforms that are applied to the market map
+	// created from the transformed feeds.
+	MarketMapTransforms []TransformStageConfig `json:"market_map_transforms" mapstructure:"market_map_transforms"`
+}
+
+// TransformStageConfig is a single named stage of the generation pipeline.
+type TransformStageConfig struct {
+	// Name is the registered name of the transform (ex. InvertOrDrop).
+	Name string `json:"name" mapstructure:"name"`
+
+	// Params are optional, transform-specific parameters for this stage. They are decoded into the options of the
+	// transform (ex. top_markets for TopFeedsForProvider); transforms without options reject params.
+	Params map[string]any `json:"params,omitempty" mapstructure:"params"`
+}
+
+// Validate checks that every stage is named and that no stage is listed more than once.
+// The validity of the stage names and their ordering is checked when the pipeline is constructed.
+func (pc *PipelineConfig) Validate() error {
+	if err := validateStages(pc.FeedTransforms); err != nil {
+		return fmt.Errorf("invalid feed_transforms: %w", err)
+	}
+
+	if err := validateStages(pc.MarketMapTransforms); err != nil {
+		return fmt.Errorf("invalid market_map_transforms: %w", err)
+	}
+
+	return nil
+}
+
+func validateStages(stages []TransformStageConfig) error {
+	seen := make(map[string]struct{}, len(stages))
+	for i, stage := range stages {
+		if stage.Name == "" {
+			return fmt.Errorf("stage %d: name cannot be empty", i)
+		}
+
+		if _, found := seen[stage.Name]; found {
+			return fmt.Errorf("stage %d: duplicate stage %q", i, stage.Name)
+		}
+		seen[stage.Name] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr/parser"
+)
+
+// RuleScope is the kind of object a RuleConfig expression is evaluated against.
+type RuleScope string
+
+const (
+	// RuleScopeFeed rules are evaluated once per provider feed.
+	RuleScopeFeed RuleScope = "feed"
+	// RuleScopeMarket rules are evaluated once per generated market.
+	RuleScopeMarket RuleScope = "market"
+	// RuleScopeProvider rules are evaluated once per provider config of a generated market.
+	RuleScopeProvider RuleScope = "provider"
+)
+
+// RuleAction is the action taken when a rule's expression evaluates to true.
+type RuleAction string
+
+const (
+	// RuleActionDrop removes the matched feed or market.
+	RuleActionDrop RuleAction = "drop"
+	// RuleActionDisableProvider removes the matched provider config from its market.
+	RuleActionDisableProvider RuleAction = "disable_provider"
+	// RuleActionSetMinProviderCount sets the MinProviderCount of the matched market.
+	RuleActionSetMinProviderCount RuleAction = "set_min_provider_count"
+	// RuleActionMarkSupplemental excludes the matched provider config from its market's provider count.
+	RuleActionMarkSupplemental RuleAction = "mark_supplemental"
+)
+
+// ruleActionsForScope is the set of actions that are supported for each scope.
+var ruleActionsForScope = map[RuleScope][]RuleAction{
+	RuleScopeFeed:     {RuleActionDrop},
+	RuleScopeMarket:   {RuleActionDrop, RuleActionSetMinProviderCount},
+	RuleScopeProvider: {RuleActionDisableProvider, RuleActionMarkSupplemental},
+}
+
+// RuleConfig is a filter rule that is applied during generation. The Expression is a boolean expression
+// over the fields of the rule's scope, ex. `provider == "mexc_ws" && volume < 1e6`.
+type RuleConfig struct {
+	// ID uniquely identifies the rule and is recorded in the removal reasons of any match.
+	ID string `json:"id" mapstructure:"id"`
+
+	// Scope is the kind of object the Expression is evaluated against.
+	Scope RuleScope `json:"scope" mapstructure:"scope"`
+
+	// Expression is a boolean expression that selects the objects the Action is applied to.
+	Expression string `json:"expression" mapstructure:"expression"`
+
+	// Action is the action taken for every match.
+	Action RuleAction `json:"action" mapstructure:"action"`
+
+	// MinProviderCount is the MinProviderCount set on matched markets for the set_min_provider_count action.
+	MinProviderCount uint64 `json:"min_provider_count,omitempty" mapstructure:"min_provider_count"`
+}
+
+// Validate checks that the rule is well-formed and that its expression can be parsed.
+// Type checking of the expression against its scope is done when the rule is compiled.
+func (rc *RuleConfig) Validate() error {
+	if rc.ID == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+
+	actions, ok := ruleActionsForScope[rc.Scope]
+	if !ok {
+		return fmt.Errorf("rule %s: invalid scope %q: must be one of (%s, %s, %s)", rc.ID, rc.Scope,
+			RuleScopeFeed, RuleScopeMarket, RuleScopeProvider)
+	}
+
+	supported := false
+	for _, action := range actions {
+		if action == rc.Action {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("rule %s: action %q is not supported for scope %q: must be one of %v", rc.ID, rc.Action,
+			rc.Scope, actions)
+	}
+
+	if rc.Action == RuleActionSetMinProviderCount && rc.MinProviderCount < 1 {
+		return fmt.Errorf("rule %s: min_provider_count must be GTE 1", rc.ID)
+	}
+
+	if rc.Expression == "" {
+		return fmt.Errorf("rule %s: expression cannot be empty", rc.ID)
+	}
+
+	if _, err := parser.Parse(rc.Expression); err != nil {
+		return fmt.Errorf("rule %s: invalid expression: %w", rc.ID, err)
+	}
+
+	return nil
+}
@@ -310,6 +310,487 @@ func TestValidateGenerateConfig(t *testing.T) {
 			},
 			false,
 		},
+		{
+			name: "invalid pipeline with unnamed stage",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Pipeline: &config.PipelineConfig{
+					FeedTransforms: []config.TransformStageConfig{{Name: ""}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid pipeline with duplicate stage",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Pipeline: &config.PipelineConfig{
+					MarketMapTransforms: []config.TransformStageConfig{{Name: "PruneMarkets"}, {Name: "PruneMarkets"}},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid rules",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Rules: []config.RuleConfig{
+					{ID: "drop-mexc", Scope: config.RuleScopeFeed, Expression: `provider == "mexc_ws" && volume < 1e6`, Action: config.RuleActionDrop},
+					{ID: "min-2", Scope: config.RuleScopeMarket, Expression: `quote == "USD"`, Action: config.RuleActionSetMinProviderCount, MinProviderCount: 2},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "invalid rule with unsupported action for scope",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Rules: []config.RuleConfig{
+					{ID: "bad", Scope: config.RuleScopeFeed, Expression: `true`, Action: config.RuleActionDisableProvider},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid rule with unparseable expression",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Rules: []config.RuleConfig{
+					{ID: "bad", Scope: config.RuleScopeMarket, Expression: `provider_count <`, Action: config.RuleActionDrop},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid rules with duplicate ids",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Rules: []config.RuleConfig{
+					{ID: "dup", Scope: config.RuleScopeMarket, Expression: `true`, Action: config.RuleActionDrop},
+					{ID: "dup", Scope: config.RuleScopeFeed, Expression: `true`, Action: config.RuleActionDrop},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid min provider count policy",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				MinProviderCountPolicy: &config.MinProviderCountPolicyConfig{},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "min provider count policy for an unknown tier",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Tiers: []config.TierConfig{{Name: "core"}},
+				MinProviderCountPolicy: &config.MinProviderCountPolicyConfig{
+					Default: config.MinProviderCountRule{Fraction: &config.MinProviderCountFraction{Fraction: 0.5, Floor: 1}},
+					Tiers: map[string]config.MinProviderCountRule{
+						"long-tail": {Fraction: &config.MinProviderCountFraction{Fraction: 0.5, Floor: 1}},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid tiers",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      2,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Tiers: []config.TierConfig{
+					{Name: "core", Tickers: []string{"ETH/BTC"}, MinCexProviderCount: 3},
+					{Name: "long-tail", Quotes: map[string]config.TierThresholds{"BTC": {MinProviderVolume: 20}}},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "invalid duplicate tier names",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Tiers: []config.TierConfig{{Name: "core"}, {Name: "core"}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid tier ticker",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Tiers: []config.TierConfig{{Name: "core", Tickers: []string{"ETHBTC"}}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid tier provider count below override",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      2,
+				MinDexProviderCount:      2,
+				MinProviderCountOverride: 2,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Tiers: []config.TierConfig{{Name: "long-tail", MinDexProviderCount: 1}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid diversity",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx_ws":  {Groups: map[string]string{config.GroupOperator: "okx"}},
+					"okx_api": {Groups: map[string]string{config.GroupOperator: "okx"}},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Diversity: &config.DiversityConfig{
+					MinDistinctGroups: map[string]uint64{config.GroupOperator: 2},
+					MaxDefiShare:      0.5,
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "invalid empty provider group",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx_ws": {Groups: map[string]string{config.GroupOperator: ""}},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid max defi share",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx_ws": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Diversity: &config.DiversityConfig{MaxDefiShare: 1.5},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid min distinct groups",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx_ws": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"BTC": {
+						MinProviderVolume: 10,
+					},
+				},
+				Diversity: &config.DiversityConfig{MinDistinctGroups: map[string]uint64{config.GroupOperator: 0}},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid asset aliases",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				AssetAliases: []config.AssetAliasConfig{
+					{WrappedCMCID: 2396, NativeCMCID: 1027, NativeSymbol: "ETH", BackNative: true},
+					{WrappedCMCID: 32994, NativeCMCID: 1},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "invalid duplicate asset alias",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				AssetAliases: []config.AssetAliasConfig{
+					{WrappedCMCID: 2396, NativeCMCID: 1027, BackNative: true},
+					{WrappedCMCID: 2396, NativeCMCID: 1},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid chained asset alias",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				AssetAliases: []config.AssetAliasConfig{
+					{WrappedCMCID: 2396, NativeCMCID: 1027, BackNative: true},
+					{WrappedCMCID: 1027, NativeCMCID: 1},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid metadata-only asset alias with native symbol",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				AssetAliases: []config.AssetAliasConfig{
+					{WrappedCMCID: 32994, NativeCMCID: 1, NativeSymbol: "BTC"},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid self asset alias",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				AssetAliases: []config.AssetAliasConfig{
+					{WrappedCMCID: 1, NativeCMCID: 1, BackNative: true},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "valid normalize by candidates",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"USDT": {
+						NormalizeByCandidates: []string{
+							"USDT/USD",
+							"USDT,UNISWAP_V3,0XDAC17F958D2EE523A2206206994597C13D831EC7/USD",
+						},
+					},
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "invalid normalize by candidates with mixed quotes",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"USDT": {
+						NormalizeByCandidates: []string{"USDT/USD", "USDT/EUR"},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid normalize by pair and candidates",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"USDT": {
+						NormalizeByPair:       "USDT/USD",
+						NormalizeByCandidates: []string{"USDT/USD"},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid normalize by candidate base",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"USDT": {
+						NormalizeByCandidates: []string{"USDC/USD"},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid duplicate normalize by candidates",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				Quotes: map[string]config.QuoteConfig{
+					"USDT": {
+						NormalizeByCandidates: []string{"USDT/USD", "USDT/USD"},
+					},
+				},
+			},
+			expectedErr: true,
+		},
 		{
 			name: "invalid can't have both allowed and excluded pair configs set",
 			cfg: config.GenerateConfig{
@@ -328,6 +809,50 @@ func TestValidateGenerateConfig(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name: "valid trading statuses",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount:      1,
+				MinDexProviderCount:      1,
+				MinProviderCountOverride: 1,
+				TradingStatuses: map[string]string{
+					"post_only":                  config.TradingStatusInclude,
+					"deposit_withdraw_suspended": config.TradingStatusExclude,
+				},
+			},
+			expectedErr: false,
+		},
+		{
+			name: "invalid unknown trading status",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount: 1,
+				MinDexProviderCount: 1,
+				TradingStatuses: map[string]string{
+					"paused": config.TradingStatusExclude,
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid trading status action",
+			cfg: config.GenerateConfig{
+				Providers: map[string]config.ProviderConfig{
+					"okx": {},
+				},
+				MinCexProviderCount: 1,
+				MinDexProviderCount: 1,
+				TradingStatuses: map[string]string{
+					"halted": "drop",
+				},
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -384,3 +909,44 @@ func TestGenerateConfig_IsCurrencyPairAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestQuoteConfigNormalizesTo(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        config.QuoteConfig
+		quote      string
+		normalized bool
+	}{
+		{
+			name: "not normalized",
+		},
+		{
+			name:       "normalize by pair",
+			cfg:        config.QuoteConfig{NormalizeByPair: "USDT/USD"},
+			quote:      "USD",
+			normalized: true,
+		},
+		{
+			name: "normalize by candidates",
+			cfg: config.QuoteConfig{NormalizeByCandidates: []string{
+				"USDT/USD",
+				"USDT,UNISWAP_V3,0XDAC17F958D2EE523A2206206994597C13D831EC7/USD",
+			}},
+			quote:      "USD",
+			normalized: true,
+		},
+		{
+			name:  "candidates with mixed quotes",
+			cfg:   config.QuoteConfig{NormalizeByCandidates: []string{"USDT/USD", "USDT/EUR"}},
+			quote: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			quote, normalized := tc.cfg.NormalizesTo()
+			require.Equal(t, tc.quote, quote)
+			require.Equal(t, tc.normalized, normalized)
+		})
+	}
+}
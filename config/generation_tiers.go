@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+)
+
+// TierConfig defines a tier of markets (ex. core, mid, long-tail) with tier-specific generation settings.
+//
+// A market belongs to the first configured tier that it matches. A tier matches a market if the market is in
+// Tickers, or if it satisfies all of the configured MaxCMCRank and MinLiquidity criteria. A tier without any
+// criteria matches every market, which can be used as a catch-all last tier.
+type TierConfig struct {
+	// Name is the name of the tier that is recorded in the ticker metadata of its markets.
+	Name string `json:"name" mapstructure:"name"`
+
+	// Tickers is an explicit list of markets (ex. BTC/USD) in this tier. Tickers are matched after normalization.
+	Tickers []string `json:"tickers,omitempty" mapstructure:"tickers"`
+	// MaxCMCRank is the highest (worst) CoinMarketCap rank of the base asset of markets in this tier.
+	// A value of 0 means the rank is not considered.
+	MaxCMCRank int64 `json:"max_cmc_rank,omitempty" mapstructure:"max_cmc_rank"`
+	// MinLiquidity is the minimum total liquidity in USD across all providers of markets in this tier.
+	// A value of 0 means the liquidity is not considered.
+	MinLiquidity float64 `json:"min_liquidity,omitempty" mapstructure:"min_liquidity"`
+
+	// Quotes are per-quote provider thresholds for markets in this tier. They replace the thresholds of the
+	// corresponding QuoteConfig.
+	Quotes map[string]TierThresholds `json:"quotes,omitempty" mapstructure:"quotes"`
+
+	// MinCexProviderCount replaces the GenerateConfig's MinCexProviderCount for markets in this tier if non-zero.
+	MinCexProviderCount uint64 `json:"min_cex_provider_count,omitempty" mapstructure:"min_cex_provider_count"`
+	// MinDexProviderCount replaces the GenerateConfig's MinDexProviderCount for markets in this tier if non-zero.
+	MinDexProviderCount uint64 `json:"min_dex_provider_count,omitempty" mapstructure:"min_dex_provider_count"`
+
+	// TopMarkets is the maximum number of markets in this tier that each provider may provide for, chosen in the
+	// same way as the provider's Filters.TopMarkets. If the value is 0, no filter will be applied.
+	TopMarkets uint64 `json:"top_markets,omitempty" mapstructure:"top_markets"`
+
+	// Enabled sets the Enabled field of all markets in this tier if set, taking precedence over EnableAll.
+	Enabled *bool `json:"enabled,omitempty" mapstructure:"enabled"`
+}
+
+// TierThresholds are the per-provider thresholds of a tier for a single quote.
+type TierThresholds struct {
+	// MinProviderVolume is the minimum volume per-provider for a market in this tier.
+	MinProviderVolume float64 `json:"min_provider_volume" mapstructure:"min_provider_volume"`
+	// MinProviderLiquidity is the minimum liquidity (on buy and sell side) per-provider for a market in this tier
+	// denominated in USD.
+	MinProviderLiquidity float64 `json:"min_provider_liquidity" mapstructure:"min_provider_liquidity"`
+}
+
+// Validate checks if the TierConfig is valid.
+func (tc *TierConfig) Validate() error {
+	if tc.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+
+	for _, ticker := range tc.Tickers {
+		if _, err := connecttypes.CurrencyPairFromString(ticker); err != nil {
+			return fmt.Errorf("invalid ticker %q: %w", ticker, err)
+		}
+	}
+
+	if tc.MaxCMCRank < 0 {
+		return fmt.Errorf("max_cmc_rank must be non-negative")
+	}
+
+	if tc.MinLiquidity < 0 {
+		return fmt.Errorf("min_liquidity must be non-negative")
+	}
+
+	for quote, thresholds := range tc.Quotes {
+		if thresholds.MinProviderVolume < 0 {
+			return fmt.Errorf("min_provider_volume for quote %s must be non-negative", quote)
+		}
+		if thresholds.MinProviderLiquidity < 0 {
+			return fmt.Errorf("min_provider_liquidity for quote %s must be non-negative", quote)
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if a market with the given ticker, base asset CMC rank and total liquidity is in this tier.
+// A rank of 0 means the base asset is unranked and never satisfies MaxCMCRank.
+func (tc *TierConfig) Matches(ticker string, cmcRank int64, liquidity float64) bool {
+	for _, t := range tc.Tickers {
+		if t == ticker {
+			return true
+		}
+	}
+
+	if tc.MaxCMCRank == 0 && tc.MinLiquidity == 0 {
+		// a tier with only an explicit list only matches the listed tickers.
+		return len(tc.Tickers) == 0
+	}
+
+	if tc.MaxCMCRank != 0 && (cmcRank <= 0 || cmcRank > tc.MaxCMCRank) {
+		return false
+	}
+
+	return liquidity >= tc.MinLiquidity
+}
+
+// TierFor returns the first configured tier that matches the market. False is returned if no tier matches.
+func (cfg *GenerateConfig) TierFor(ticker string, cmcRank int64, liquidity float64) (TierConfig, bool) {
+	for _, tier := range cfg.Tiers {
+		if tier.Matches(ticker, cmcRank, liquidity) {
+			return tier, true
+		}
+	}
+
+	return TierConfig{}, false
+}
+
+// Tier returns the configured tier with the given name.
+func (cfg *GenerateConfig) Tier(name string) (TierConfig, bool) {
+	if name == "" {
+		return TierConfig{}, false
+	}
+
+	for _, tier := range cfg.Tiers {
+		if tier.Name == name {
+			return tier, true
+		}
+	}
+
+	return TierConfig{}, false
+}
+
+// QuoteConfigFor returns the QuoteConfig of the quote with the provider thresholds of the given tier applied.
+// False is returned if no QuoteConfig exists for the quote.
+func (cfg *GenerateConfig) QuoteConfigFor(tierName, quote string) (QuoteConfig, bool) {
+	quoteConfig, found := cfg.Quotes[quote]
+	if !found {
+		return QuoteConfig{}, false
+	}
+
+	if tier, found := cfg.Tier(tierName); found {
+		if thresholds, found := tier.Quotes[quote]; found {
+			quoteConfig.MinProviderVolume = thresholds.MinProviderVolume
+			quoteConfig.MinProviderLiquidity = thresholds.MinProviderLiquidity
+		}
+	}
+
+	return quoteConfig, true
+}
@@ -0,0 +1,112 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+func TestTierConfig_Matches(t *testing.T) {
+	tests := []struct {
+		name      string
+		tier      config.TierConfig
+		ticker    string
+		rank      int64
+		liquidity float64
+		want      bool
+	}{
+		{
+			name:   "explicit ticker",
+			tier:   config.TierConfig{Name: "core", Tickers: []string{"BTC/USD"}, MaxCMCRank: 1},
+			ticker: "BTC/USD",
+			rank:   100,
+			want:   true,
+		},
+		{
+			name:   "explicit list only matches listed tickers",
+			tier:   config.TierConfig{Name: "core", Tickers: []string{"BTC/USD"}},
+			ticker: "ETH/USD",
+			rank:   2,
+			want:   false,
+		},
+		{
+			name:      "rank and liquidity",
+			tier:      config.TierConfig{Name: "mid", MaxCMCRank: 100, MinLiquidity: 1000},
+			ticker:    "ETH/USD",
+			rank:      2,
+			liquidity: 1000,
+			want:      true,
+		},
+		{
+			name:      "insufficient liquidity",
+			tier:      config.TierConfig{Name: "mid", MaxCMCRank: 100, MinLiquidity: 1000},
+			ticker:    "ETH/USD",
+			rank:      2,
+			liquidity: 999,
+			want:      false,
+		},
+		{
+			name:   "unranked asset",
+			tier:   config.TierConfig{Name: "mid", MaxCMCRank: 100},
+			ticker: "MOG/USD",
+			rank:   0,
+			want:   false,
+		},
+		{
+			name:   "catch-all",
+			tier:   config.TierConfig{Name: "long-tail"},
+			ticker: "MOG/USD",
+			want:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.tier.Matches(tc.ticker, tc.rank, tc.liquidity))
+		})
+	}
+}
+
+func TestGenerateConfig_Tiers(t *testing.T) {
+	cfg := config.GenerateConfig{
+		Quotes: map[string]config.QuoteConfig{
+			"USD":  {MinProviderVolume: 100, MinProviderLiquidity: 10},
+			"USDT": {MinProviderVolume: 100, MinProviderLiquidity: 10, NormalizeByPair: "USDT/USD"},
+		},
+		Tiers: []config.TierConfig{
+			{
+				Name:       "core",
+				MaxCMCRank: 10,
+				Quotes:     map[string]config.TierThresholds{"USD": {MinProviderVolume: 1000, MinProviderLiquidity: 100}},
+			},
+			{Name: "long-tail"},
+		},
+	}
+
+	tier, found := cfg.TierFor("BTC/USD", 1, 0)
+	require.True(t, found)
+	require.Equal(t, "core", tier.Name)
+
+	tier, found = cfg.TierFor("MOG/USD", 500, 0)
+	require.True(t, found)
+	require.Equal(t, "long-tail", tier.Name)
+
+	// tier thresholds replace the quote thresholds
+	quoteConfig, found := cfg.QuoteConfigFor("core", "USD")
+	require.True(t, found)
+	require.Equal(t, config.QuoteConfig{MinProviderVolume: 1000, MinProviderLiquidity: 100}, quoteConfig)
+
+	// quotes without tier thresholds use the quote config
+	quoteConfig, found = cfg.QuoteConfigFor("core", "USDT")
+	require.True(t, found)
+	require.Equal(t, cfg.Quotes["USDT"], quoteConfig)
+
+	quoteConfig, found = cfg.QuoteConfigFor("", "USD")
+	require.True(t, found)
+	require.Equal(t, cfg.Quotes["USD"], quoteConfig)
+
+	_, found = cfg.QuoteConfigFor("core", "BTC")
+	require.False(t, found)
+}
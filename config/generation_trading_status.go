@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+const (
+	// TradingStatusInclude keeps the feeds of provider markets with the trading status.
+	TradingStatusInclude = "include"
+	// TradingStatusExclude removes the feeds of provider markets with the trading status.
+	TradingStatusExclude = "exclude"
+)
+
+// DefaultTradingStatuses are the actions of the trading statuses that are not configured. Markets that are not
+// trading, or are about to stop trading, are excluded. Suspended deposits and withdrawals do not stop trading, so
+// those markets are included. Markets whose provider does not report a status are included.
+func DefaultTradingStatuses() map[string]string {
+	return map[string]string{
+		string(provider.TradingStatusTrading):                  TradingStatusInclude,
+		string(provider.TradingStatusHalted):                   TradingStatusExclude,
+		string(provider.TradingStatusPostOnly):                 TradingStatusExclude,
+		string(provider.TradingStatusDelistingScheduled):       TradingStatusExclude,
+		string(provider.TradingStatusDepositWithdrawSuspended): TradingStatusInclude,
+		string(provider.TradingStatusUnknown):                  TradingStatusInclude,
+	}
+}
+
+// TradingStatusAction returns the configured action of the trading status, or its default action if not configured.
+// Feeds without a trading status are treated as trading.
+func (cfg *GenerateConfig) TradingStatusAction(status provider.TradingStatus) string {
+	status = status.OrTrading()
+	if action, found := cfg.TradingStatuses[string(status)]; found {
+		return action
+	}
+	return DefaultTradingStatuses()[string(status)]
+}
+
+// validateTradingStatuses checks that every configured trading status is valid and has a valid action.
+func validateTradingStatuses(statuses map[string]string) error {
+	for status, action := range statuses {
+		if err := provider.TradingStatus(status).Validate(); err != nil {
+			return fmt.Errorf("invalid trading_statuses: %w", err)
+		}
+
+		if action != TradingStatusInclude && action != TradingStatusExclude {
+			return fmt.Errorf("invalid trading_statuses: action of %q must be %q or %q, got %q",
+				status, TradingStatusInclude, TradingStatusExclude, action)
+		}
+	}
+
+	return nil
+}
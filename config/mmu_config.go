@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 )
 
@@ -11,6 +12,10 @@ type Config struct {
 	Upsert   *UpsertConfig   `json:"upsert,omitempty"`
 	Dispatch *DispatchConfig `json:"dispatch,omitempty"`
 	Chain    *ChainConfig    `json:"chain,omitempty"`
+	Oracle   *OracleConfig   `json:"oracle,omitempty"`
+
+	// Targets are the chains a generated market map is fanned out to by the multi-chain command.
+	Targets []TargetConfig `json:"targets,omitempty"`
 }
 
 func (c *Config) Validate() error {
@@ -44,6 +49,34 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Oracle != nil {
+		if err := c.Oracle.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateTargets(c.Targets); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateChainTypes checks the type and override config of the chain and of every target against the registered
+// chain types.
+func (c *Config) ValidateChainTypes(chainTypes ChainTypes) error {
+	if c.Chain != nil {
+		if err := c.Chain.ValidateChainType(chainTypes); err != nil {
+			return err
+		}
+	}
+
+	for _, target := range c.Targets {
+		if err := target.Chain.ValidateChainType(chainTypes); err != nil {
+			return fmt.Errorf("invalid target %s: %w", target.Name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -54,6 +87,7 @@ func DefaultConfig() Config {
 		Upsert:   &[]UpsertConfig{DefaultUpsertConfig()}[0],
 		Dispatch: &[]DispatchConfig{DefaultDispatchConfig()}[0],
 		Chain:    &[]ChainConfig{DefaultChainConfig()}[0],
+		Oracle:   &[]OracleConfig{DefaultOracleConfig()}[0],
 	}
 }
 
@@ -78,3 +112,14 @@ func ReadConfig(path string) (Config, error) {
 
 	return cfg, cfg.Validate()
 }
+
+// ReadConfigWithChainTypes reads and validates the config at path, and validates the type and override config of its
+// chains against the registered chain types.
+func ReadConfigWithChainTypes(path string, chainTypes ChainTypes) (Config, error) {
+	cfg, err := ReadConfig(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	return cfg, cfg.ValidateChainTypes(chainTypes)
+}
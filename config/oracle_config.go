@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OracleConfig contains the provider templates used to derive a Connect oracle config from a market map.
+type OracleConfig struct {
+	// Providers are the oracle config templates of each provider, keyed by provider name.
+	// Only the templates of providers used by the market map are included in a derived oracle config.
+	Providers map[string]OracleProviderTemplate `json:"providers"`
+}
+
+// OracleProviderTemplate is the template of the oracle config of a provider. Providers whose name ends with _ws are
+// configured as websocket providers, all others as API providers. Unset fields use the Connect defaults.
+type OracleProviderTemplate struct {
+	// Interval is the interval at which an API provider is polled, ex. 500ms.
+	Interval string `json:"interval,omitempty"`
+
+	// Endpoints are the endpoints of the provider.
+	Endpoints []OracleEndpointTemplate `json:"endpoints,omitempty"`
+}
+
+// OracleEndpointTemplate is the template of a provider endpoint.
+type OracleEndpointTemplate struct {
+	// URL is the URL of the endpoint.
+	URL string `json:"url"`
+
+	// APIKeyHeader is the header that the API key is sent in, ex. x-api-key.
+	APIKeyHeader string `json:"api_key_header,omitempty"`
+
+	// APIKeyEnv is the name of the environment variable that the API key is read from.
+	// The API key is never stored in the config itself.
+	APIKeyEnv string `json:"api_key_env,omitempty"`
+}
+
+// IsWebSocketProvider reports whether the provider is a websocket provider.
+func IsWebSocketProvider(name string) bool {
+	return strings.HasSuffix(name, "_ws")
+}
+
+// Validate checks if the OracleProviderTemplate of the named provider is valid.
+func (t *OracleProviderTemplate) Validate(name string) error {
+	if t.Interval != "" {
+		if IsWebSocketProvider(name) {
+			return fmt.Errorf("interval can only be set for API providers")
+		}
+
+		interval, err := time.ParseDuration(t.Interval)
+		if err != nil {
+			return fmt.Errorf("invalid interval: %w", err)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("interval must be positive")
+		}
+	}
+
+	for _, endpoint := range t.Endpoints {
+		if endpoint.URL == "" {
+			return fmt.Errorf("endpoint url cannot be empty")
+		}
+
+		if endpoint.APIKeyEnv != "" && endpoint.APIKeyHeader == "" {
+			return fmt.Errorf("endpoint %s: api_key_header must be set if api_key_env is set", endpoint.URL)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks if the OracleConfig is valid.
+func (c *OracleConfig) Validate() error {
+	for name, template := range c.Providers {
+		if err := ValidateProviderName(name); err != nil {
+			return err
+		}
+
+		if err := template.Validate(name); err != nil {
+			return fmt.Errorf("invalid oracle template for provider %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// DefaultOracleConfig returns the default provider templates, which configure the RPC endpoints of DeFi providers.
+func DefaultOracleConfig() OracleConfig {
+	return OracleConfig{
+		Providers: map[string]OracleProviderTemplate{
+			"raydium_api": {Endpoints: []OracleEndpointTemplate{
+				{URL: "https://solana.polkachu.com", APIKeyHeader: "x-api-key"},
+				{URL: "https://connect-solana.kingnodes.com", APIKeyHeader: "x-api-key"},
+				{URL: "https://solana.lavenderfive.com", APIKeyHeader: "x-api-key"},
+				{URL: "https://solana-rpc.rhino-apis.com", APIKeyHeader: "x-api-key"},
+			}},
+			"uniswapv3_api-ethereum": {Endpoints: []OracleEndpointTemplate{
+				{URL: "https://ethereum.lavenderfive.com", APIKeyHeader: "x-api-key"},
+				{URL: "https://ethereum-rpc.polkachu.com", APIKeyHeader: "x-api-key"},
+				{URL: "https://connect-eth.kingnodes.com", APIKeyHeader: "x-api-key"},
+				{URL: "https://ethereum-rpc.rhino-apis.com", APIKeyHeader: "x-api-key"},
+			}},
+			"uniswapv3_api-base": {Endpoints: []OracleEndpointTemplate{
+				{URL: "https://base-rpc.rhino-apis.com", APIKeyHeader: "x-api-key"},
+				{URL: "https://connect-base.kingnodes.com", APIKeyHeader: "x-api-key"},
+				{URL: "https://base.lavenderfive.com", APIKeyHeader: "x-api-key"},
+			}},
+		},
+	}
+}
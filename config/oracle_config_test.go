@@ -0,0 +1,77 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+func TestOracleConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  config.OracleConfig
+		wantErr bool
+	}{
+		{
+			name:   "valid default",
+			config: config.DefaultOracleConfig(),
+		},
+		{
+			name: "valid api provider with interval and api key",
+			config: config.OracleConfig{Providers: map[string]config.OracleProviderTemplate{
+				"raydium_api": {
+					Interval:  "500ms",
+					Endpoints: []config.OracleEndpointTemplate{{URL: "https://solana.com", APIKeyHeader: "x-api-key", APIKeyEnv: "SOLANA_API_KEY"}},
+				},
+			}},
+		},
+		{
+			name: "invalid interval",
+			config: config.OracleConfig{Providers: map[string]config.OracleProviderTemplate{
+				"raydium_api": {Interval: "soon"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid interval for websocket provider",
+			config: config.OracleConfig{Providers: map[string]config.OracleProviderTemplate{
+				"coinbase_ws": {Interval: "1s"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid empty endpoint url",
+			config: config.OracleConfig{Providers: map[string]config.OracleProviderTemplate{
+				"coinbase_ws": {Endpoints: []config.OracleEndpointTemplate{{}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid api key env without header",
+			config: config.OracleConfig{Providers: map[string]config.OracleProviderTemplate{
+				"raydium_api": {Endpoints: []config.OracleEndpointTemplate{{URL: "https://solana.com", APIKeyEnv: "SOLANA_API_KEY"}}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid empty provider name",
+			config: config.OracleConfig{Providers: map[string]config.OracleProviderTemplate{
+				"": {},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// targetNameRegex matches target names, which are used as the names of their output directories.
+var targetNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// TargetConfig is a chain that a generated market map is fanned out to. Each target is overridden, upserted and
+// dispatched on its own.
+type TargetConfig struct {
+	// Name identifies the target, and is the name of the directory its output is written to.
+	Name string `json:"name"`
+
+	// Chain is the chain of the target. The MarketMapOverride of the target is chosen by the chain.
+	Chain ChainConfig `json:"chain"`
+
+	// Upsert is the upsert config of the target. Defaults to the upsert config of the Config.
+	Upsert *UpsertConfig `json:"upsert,omitempty"`
+
+	// Dispatch is the dispatch config of the target. Defaults to the dispatch config of the Config.
+	Dispatch *DispatchConfig `json:"dispatch,omitempty"`
+}
+
+func (t *TargetConfig) Validate() error {
+	if !targetNameRegex.MatchString(t.Name) {
+		return fmt.Errorf("invalid target name %q: must only contain letters, digits, '_', '.' and '-'", t.Name)
+	}
+
+	if err := t.Chain.Validate(); err != nil {
+		return fmt.Errorf("invalid chain of target %s: %w", t.Name, err)
+	}
+
+	if t.Upsert != nil {
+		if err := t.Upsert.Validate(); err != nil {
+			return fmt.Errorf("invalid upsert config of target %s: %w", t.Name, err)
+		}
+	}
+
+	if t.Dispatch != nil {
+		if err := t.Dispatch.Validate(); err != nil {
+			return fmt.Errorf("invalid dispatch config of target %s: %w", t.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateTargets validates each target and that target names are unique.
+func ValidateTargets(targets []TargetConfig) error {
+	names := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		if err := target.Validate(); err != nil {
+			return err
+		}
+
+		if _, found := names[target.Name]; found {
+			return fmt.Errorf("duplicate target name %s", target.Name)
+		}
+		names[target.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+// ResolveTargets returns the targets of the config, with the upsert and dispatch configs of the Config as defaults.
+// If no targets are configured, the chain of the Config is the only target, named after its chain id.
+func (c *Config) ResolveTargets() []TargetConfig {
+	targets := c.Targets
+	if len(targets) == 0 && c.Chain != nil {
+		targets = []TargetConfig{{Name: c.Chain.ChainID, Chain: *c.Chain}}
+	}
+
+	resolved := make([]TargetConfig, len(targets))
+	for i, target := range targets {
+		if target.Upsert == nil {
+			target.Upsert = c.Upsert
+		}
+		if target.Dispatch == nil {
+			target.Dispatch = c.Dispatch
+		}
+		resolved[i] = target
+	}
+
+	return resolved
+}
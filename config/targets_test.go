@@ -0,0 +1,125 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+func targetChain(chainID string) config.ChainConfig {
+	chain := config.DefaultChainConfig()
+	chain.ChainID = chainID
+	return chain
+}
+
+func TestValidateTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []config.TargetConfig
+		wantErr bool
+	}{
+		{
+			name: "no targets",
+		},
+		{
+			name: "valid targets",
+			targets: []config.TargetConfig{
+				{Name: "dydx-mainnet", Chain: targetChain("dydx-mainnet-1")},
+				{Name: "neutron_1", Chain: targetChain("neutron-1")},
+			},
+		},
+		{
+			name: "invalid name",
+			targets: []config.TargetConfig{
+				{Name: "../dydx", Chain: targetChain("dydx-mainnet-1")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty name",
+			targets: []config.TargetConfig{
+				{Chain: targetChain("dydx-mainnet-1")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate names",
+			targets: []config.TargetConfig{
+				{Name: "dydx", Chain: targetChain("dydx-mainnet-1")},
+				{Name: "dydx", Chain: targetChain("dydx-testnet-4")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid chain",
+			targets: []config.TargetConfig{
+				{Name: "dydx", Chain: config.DefaultChainConfig()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid upsert config",
+			targets: []config.TargetConfig{
+				{Name: "dydx", Chain: targetChain("dydx-mainnet-1"), Upsert: &config.UpsertConfig{
+					AutoEnable: &config.AutoEnableConfig{RequiredValidRuns: 0},
+				}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := config.ValidateTargets(tt.targets)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestResolveTargets(t *testing.T) {
+	upsert := config.DefaultUpsertConfig()
+	dispatch := config.DefaultDispatchConfig()
+	chain := targetChain("dydx-mainnet-1")
+
+	t.Run("default to the chain of the config", func(t *testing.T) {
+		cfg := config.Config{Chain: &chain, Upsert: &upsert, Dispatch: &dispatch}
+		require.Equal(t, []config.TargetConfig{
+			{Name: "dydx-mainnet-1", Chain: chain, Upsert: &upsert, Dispatch: &dispatch},
+		}, cfg.ResolveTargets())
+	})
+
+	t.Run("no targets without a chain", func(t *testing.T) {
+		cfg := config.Config{Upsert: &upsert}
+		require.Empty(t, cfg.ResolveTargets())
+	})
+
+	t.Run("default to the upsert and dispatch configs of the config", func(t *testing.T) {
+		targetUpsert := config.DefaultUpsertConfig()
+		targetUpsert.RestrictedMarkets = []string{"BTC/USD"}
+
+		cfg := config.Config{
+			Chain:    &chain,
+			Upsert:   &upsert,
+			Dispatch: &dispatch,
+			Targets: []config.TargetConfig{
+				{Name: "dydx", Chain: chain},
+				{Name: "neutron", Chain: targetChain("neutron-1"), Upsert: &targetUpsert},
+			},
+		}
+
+		targets := cfg.ResolveTargets()
+		require.Len(t, targets, 2)
+		require.Equal(t, &upsert, targets[0].Upsert)
+		require.Equal(t, &dispatch, targets[0].Dispatch)
+		require.Equal(t, &targetUpsert, targets[1].Upsert)
+		require.Equal(t, &dispatch, targets[1].Dispatch)
+
+		// the targets of the config are not modified
+		require.Nil(t, cfg.Targets[0].Upsert)
+	})
+}
@@ -1,10 +1,31 @@
 package config
 
+import "fmt"
+
 // Config is the configuration for additional upsert modifications.
 type UpsertConfig struct {
 	// RestrictedMarkets removes the defined markets from the final set of market upserts.
 	// This ensures that a chain's marketmap does not receive updates for the markets defined here.
 	RestrictedMarkets []string `json:"restricted_markets"`
+
+	// AutoEnable enables markets and removes failing providers based on the reports of previous validation runs.
+	// If nil, the Enabled field of markets is left as generated.
+	AutoEnable *AutoEnableConfig `json:"auto_enable,omitempty"`
+}
+
+// AutoEnableConfig configures how validation reports are used to enable markets.
+type AutoEnableConfig struct {
+	// RequiredValidRuns is the number of most recent validation runs that a disabled market must be VALID in to be
+	// enabled. A provider of an enabled market must fail in each of these runs to be removed.
+	RequiredValidRuns int `json:"required_valid_runs"`
+
+	// MinPassingProviders is the minimum number of providers of a market that must pass in each run for the market
+	// to be enabled. The market's MinProviderCount is used if it is greater.
+	MinPassingProviders uint64 `json:"min_passing_providers"`
+
+	// RemoveFailedProviders removes providers that failed in each of the required runs from enabled markets, as long
+	// as the market keeps at least its MinProviderCount providers.
+	RemoveFailedProviders bool `json:"remove_failed_providers"`
 }
 
 func DefaultUpsertConfig() UpsertConfig {
@@ -14,5 +35,20 @@ func DefaultUpsertConfig() UpsertConfig {
 }
 
 func (c *UpsertConfig) Validate() error {
+	if c.AutoEnable != nil {
+		if err := c.AutoEnable.Validate(); err != nil {
+			return fmt.Errorf("invalid auto enable config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks if the AutoEnableConfig is valid.
+func (c *AutoEnableConfig) Validate() error {
+	if c.RequiredValidRuns <= 0 {
+		return fmt.Errorf("required_valid_runs must be positive")
+	}
+
 	return nil
 }
@@ -6,10 +6,29 @@ const (
 
 	// VersionConnect indicates that the chain we are concerned about is using connect v2.
 	VersionConnect Version = "connect"
+
+	// VersionAuto indicates that the version is detected by querying the chain's node.
+	VersionAuto Version = "auto"
 )
 
 type Version string
 
 func IsValidVersion(version Version) bool {
-	return version == VersionSlinky || version == VersionConnect
+	return version == VersionSlinky || version == VersionConnect || version == VersionAuto
+}
+
+const (
+	// MessageModeUpsert indicates that markets are created and updated with MsgUpsertMarkets.
+	MessageModeUpsert MessageMode = "upsert"
+
+	// MessageModeCreateUpdate indicates that markets are created with MsgCreateMarkets and updated with
+	// MsgUpdateMarkets, for chains whose marketmap version or params do not accept MsgUpsertMarkets.
+	MessageModeCreateUpdate MessageMode = "create_update"
+)
+
+// MessageMode is the set of marketmap messages that upserts are dispatched with.
+type MessageMode string
+
+func IsValidMessageMode(mode MessageMode) bool {
+	return mode == "" || mode == MessageModeUpsert || mode == MessageModeCreateUpdate
 }
@@ -0,0 +1,229 @@
+package delistings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+// Classification is the reason that an on-chain provider config did not match the latest provider data.
+type Classification string
+
+const (
+	// ClassificationDelisted is used for provider configs whose off-chain ticker is no longer listed by the provider.
+	ClassificationDelisted Classification = "DELISTED"
+	// ClassificationTradingHalted is used for provider configs whose off-chain ticker is listed, but whose trading
+	// status reported by the provider is halted or post-only.
+	ClassificationTradingHalted Classification = "TRADING_HALTED"
+	// ClassificationNoVolume is used for provider configs whose off-chain ticker is listed without a halted trading
+	// status, but had no 24hr volume.
+	ClassificationNoVolume Classification = "NO_VOLUME"
+	// ClassificationBelowThreshold is used for provider configs whose off-chain ticker is traded, but below the
+	// volume or liquidity thresholds of the generate config.
+	ClassificationBelowThreshold Classification = "BELOW_THRESHOLD"
+)
+
+// severity orders classifications from most to least severe. Provider configs are removed in this order.
+var severity = map[Classification]int{
+	ClassificationDelisted:       0,
+	ClassificationTradingHalted:  1,
+	ClassificationNoVolume:       2,
+	ClassificationBelowThreshold: 3,
+}
+
+// Finding is an on-chain provider config that did not match the latest provider data.
+type Finding struct {
+	Market         string         `json:"market"`
+	Provider       string         `json:"provider"`
+	OffChainTicker string         `json:"off_chain_ticker"`
+	Classification Classification `json:"classification"`
+	Reason         string         `json:"reason"`
+	// Removed is true if the provider config is removed by the removal upserts.
+	Removed bool `json:"removed"`
+}
+
+// FlaggedMarket is a market whose unmatched provider configs cannot all be removed without dropping it below its
+// MinProviderCount.
+type FlaggedMarket struct {
+	Market           string   `json:"market"`
+	MinProviderCount uint64   `json:"min_provider_count"`
+	Providers        int      `json:"providers"`
+	Kept             []string `json:"kept"`
+}
+
+// Report is the result of diffing the on-chain market map against the latest provider data.
+type Report struct {
+	Findings       []Finding       `json:"findings"`
+	FlaggedMarkets []FlaggedMarket `json:"flagged_markets"`
+	// UnindexedProviders are providers of the on-chain market map without any provider data. Their provider configs
+	// are not classified.
+	UnindexedProviders []string `json:"unindexed_providers"`
+}
+
+// Detect compares every provider config of the on-chain market map against the latest provider data and classifies
+// each unmatched config. If cfg is not nil, matched configs below its volume or liquidity thresholds are also
+// reported. It returns the report and the upserts that remove the unmatched configs, in order of severity, as long
+// as each market keeps at least MinProviderCount providers.
+func Detect(
+	cfg *config.GenerateConfig,
+	onChain mmtypes.MarketMap,
+	rows []provider.GetFilteredProviderMarketsRow,
+) (Report, []mmtypes.Market) {
+	indexed := make(map[string]map[string]provider.GetFilteredProviderMarketsRow)
+	for _, row := range rows {
+		if _, found := indexed[row.ProviderName]; !found {
+			indexed[row.ProviderName] = make(map[string]provider.GetFilteredProviderMarketsRow)
+		}
+		indexed[row.ProviderName][strings.ToUpper(row.OffChainTicker)] = row
+	}
+
+	report := Report{
+		Findings:           make([]Finding, 0),
+		FlaggedMarkets:     make([]FlaggedMarket, 0),
+		UnindexedProviders: make([]string, 0),
+	}
+	unindexed := make(map[string]struct{})
+	upserts := make([]mmtypes.Market, 0)
+
+	tickers := make([]string, 0, len(onChain.Markets))
+	for ticker := range onChain.Markets {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+
+	for _, ticker := range tickers {
+		market := onChain.Markets[ticker]
+
+		findings := make([]Finding, 0)
+		for _, pc := range market.ProviderConfigs {
+			providerRows, found := indexed[pc.Name]
+			if !found {
+				unindexed[pc.Name] = struct{}{}
+				continue
+			}
+
+			if finding, unmatched := classify(cfg, ticker, pc, providerRows); unmatched {
+				findings = append(findings, finding)
+			}
+		}
+		if len(findings) == 0 {
+			continue
+		}
+
+		sort.SliceStable(findings, func(i, j int) bool {
+			return severity[findings[i].Classification] < severity[findings[j].Classification]
+		})
+
+		remove := make(map[string]struct{})
+		kept := make([]string, 0)
+		for i, finding := range findings {
+			if uint64(len(market.ProviderConfigs)-len(remove)-1) < market.Ticker.MinProviderCount {
+				kept = append(kept, finding.Provider)
+				continue
+			}
+			remove[finding.Provider] = struct{}{}
+			findings[i].Removed = true
+		}
+		report.Findings = append(report.Findings, findings...)
+
+		if len(kept) > 0 {
+			report.FlaggedMarkets = append(report.FlaggedMarkets, FlaggedMarket{
+				Market:           ticker,
+				MinProviderCount: market.Ticker.MinProviderCount,
+				Providers:        len(market.ProviderConfigs),
+				Kept:             kept,
+			})
+		}
+
+		if len(remove) > 0 {
+			providers := make([]mmtypes.ProviderConfig, 0, len(market.ProviderConfigs)-len(remove))
+			for _, pc := range market.ProviderConfigs {
+				if _, found := remove[pc.Name]; !found {
+					providers = append(providers, pc)
+				}
+			}
+			market.ProviderConfigs = providers
+			upserts = append(upserts, market)
+		}
+	}
+
+	for name := range unindexed {
+		report.UnindexedProviders = append(report.UnindexedProviders, name)
+	}
+	sort.Strings(report.UnindexedProviders)
+
+	return report, upserts
+}
+
+// classify classifies the provider config of the market against the provider data of its provider. False is returned
+// if the provider config matches the provider data.
+func classify(
+	cfg *config.GenerateConfig,
+	ticker string,
+	pc mmtypes.ProviderConfig,
+	providerRows map[string]provider.GetFilteredProviderMarketsRow,
+) (Finding, bool) {
+	finding := Finding{
+		Market:         ticker,
+		Provider:       pc.Name,
+		OffChainTicker: pc.OffChainTicker,
+	}
+
+	row, found := providerRows[strings.ToUpper(pc.OffChainTicker)]
+	if !found {
+		finding.Classification = ClassificationDelisted
+		finding.Reason = fmt.Sprintf("%s is not listed by %s", pc.OffChainTicker, pc.Name)
+		return finding, true
+	}
+
+	if status := row.TradingStatus.OrTrading(); status == provider.TradingStatusHalted || status == provider.TradingStatusPostOnly {
+		finding.Classification = ClassificationTradingHalted
+		finding.Reason = fmt.Sprintf("%s is listed by %s, but has trading status %s", pc.OffChainTicker, pc.Name, status)
+		return finding, true
+	}
+
+	// the volume of providers that ignore volume is not meaningful, see belowThreshold
+	if row.QuoteVolume == 0 && (cfg == nil || !cfg.Providers[row.ProviderName].IgnoreVolume) {
+		finding.Classification = ClassificationNoVolume
+		finding.Reason = fmt.Sprintf("%s is listed by %s, but has no 24hr volume", pc.OffChainTicker, pc.Name)
+		return finding, true
+	}
+
+	if cfg == nil {
+		return Finding{}, false
+	}
+
+	if reason, below := belowThreshold(*cfg, row); below {
+		finding.Classification = ClassificationBelowThreshold
+		finding.Reason = reason
+		return finding, true
+	}
+
+	return Finding{}, false
+}
+
+// belowThreshold checks the provider data against the volume and liquidity thresholds of its quote.
+func belowThreshold(cfg config.GenerateConfig, row provider.GetFilteredProviderMarketsRow) (string, bool) {
+	quoteConfig, found := cfg.Quotes[row.TargetQuote]
+	if !found {
+		return "", false
+	}
+	providerCfg := cfg.Providers[row.ProviderName]
+
+	if !providerCfg.IgnoreVolume && row.QuoteVolume < quoteConfig.MinProviderVolume {
+		return fmt.Sprintf("24hr quote volume %f is below the minimum %f", row.QuoteVolume, quoteConfig.MinProviderVolume), true
+	}
+
+	if !providerCfg.IgnoreLiquidity &&
+		(row.NegativeDepthTwo < quoteConfig.MinProviderLiquidity || row.PositiveDepthTwo < quoteConfig.MinProviderLiquidity) {
+		return fmt.Sprintf("liquidity (-2%%: %f, +2%%: %f) is below the minimum %f",
+			row.NegativeDepthTwo, row.PositiveDepthTwo, quoteConfig.MinProviderLiquidity), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,206 @@
+package delistings_test
+
+import (
+	"testing"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/delistings"
+	"github.com/skip-mev/connect-mmu/store/provider"
+	"github.com/skip-mev/connect-mmu/testutil/markets"
+)
+
+func row(name, ticker string, volume, depth float64) provider.GetFilteredProviderMarketsRow {
+	return provider.GetFilteredProviderMarketsRow{
+		ProviderName:     name,
+		OffChainTicker:   ticker,
+		TargetQuote:      "USD",
+		QuoteVolume:      volume,
+		NegativeDepthTwo: depth,
+		PositiveDepthTwo: depth,
+	}
+}
+
+func TestDetect(t *testing.T) {
+	binance := mmtypes.ProviderConfig{Name: "binance_ws", OffChainTicker: "BTCUSDT"}
+	coinbase := mmtypes.ProviderConfig{Name: "coinbase_ws", OffChainTicker: "BTC-USD"}
+	okx := mmtypes.ProviderConfig{Name: "okx_ws", OffChainTicker: "BTC-USDT"}
+	kraken := mmtypes.ProviderConfig{Name: "kraken_api", OffChainTicker: "XXBTZUSD"}
+	enabled := markets.WithEnabled(true)
+
+	cfg := &config.GenerateConfig{
+		Providers: map[string]config.ProviderConfig{
+			"kraken_api": {IgnoreLiquidity: true},
+		},
+		Quotes: map[string]config.QuoteConfig{
+			"USD": {MinProviderVolume: 100, MinProviderLiquidity: 10},
+		},
+	}
+
+	t.Run("matched provider configs are not reported", func(t *testing.T) {
+		onChain := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+			"BTC/USD": markets.New("BTC", enabled, markets.WithProviders(binance, coinbase)),
+		}}
+		rows := []provider.GetFilteredProviderMarketsRow{
+			row("binance_ws", "BTCUSDT", 1000, 100),
+			// off-chain tickers are matched case-insensitively
+			row("coinbase_ws", "btc-usd", 1000, 100),
+		}
+
+		report, upserts := delistings.Detect(cfg, onChain, rows)
+		require.Empty(t, report.Findings)
+		require.Empty(t, report.FlaggedMarkets)
+		require.Empty(t, report.UnindexedProviders)
+		require.Empty(t, upserts)
+	})
+
+	t.Run("classify and remove unmatched provider configs", func(t *testing.T) {
+		onChain := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+			"BTC/USD": markets.New("BTC", enabled, markets.WithProviders(binance, coinbase, okx, kraken)),
+		}}
+		rows := []provider.GetFilteredProviderMarketsRow{
+			row("binance_ws", "ETHUSDT", 1000, 100),
+			row("coinbase_ws", "BTC-USD", 0, 0),
+			row("okx_ws", "BTC-USDT", 50, 100),
+			// liquidity is ignored for kraken
+			row("kraken_api", "XXBTZUSD", 1000, 0),
+		}
+
+		report, upserts := delistings.Detect(cfg, onChain, rows)
+		require.Equal(t, []delistings.Finding{
+			{
+				Market:         "BTC/USD",
+				Provider:       "binance_ws",
+				OffChainTicker: "BTCUSDT",
+				Classification: delistings.ClassificationDelisted,
+				Reason:         "BTCUSDT is not listed by binance_ws",
+				Removed:        true,
+			},
+			{
+				Market:         "BTC/USD",
+				Provider:       "coinbase_ws",
+				OffChainTicker: "BTC-USD",
+				Classification: delistings.ClassificationNoVolume,
+				Reason:         "BTC-USD is listed by coinbase_ws, but has no 24hr volume",
+				Removed:        true,
+			},
+			{
+				Market:         "BTC/USD",
+				Provider:       "okx_ws",
+				OffChainTicker: "BTC-USDT",
+				Classification: delistings.ClassificationBelowThreshold,
+				Reason:         "24hr quote volume 50.000000 is below the minimum 100.000000",
+				Removed:        true,
+			},
+		}, report.Findings)
+		require.Empty(t, report.FlaggedMarkets)
+
+		require.Len(t, upserts, 1)
+		require.Equal(t, []mmtypes.ProviderConfig{kraken}, upserts[0].ProviderConfigs)
+	})
+
+	t.Run("recorded trading halts are classified as trading halted", func(t *testing.T) {
+		onChain := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+			"BTC/USD": markets.New("BTC", enabled, markets.WithProviders(binance, coinbase)),
+		}}
+		halted := row("coinbase_ws", "BTC-USD", 1000, 100)
+		halted.TradingStatus = provider.TradingStatusHalted
+		rows := []provider.GetFilteredProviderMarketsRow{
+			row("binance_ws", "BTCUSDT", 1000, 100),
+			halted,
+		}
+
+		report, upserts := delistings.Detect(cfg, onChain, rows)
+		require.Equal(t, []delistings.Finding{{
+			Market:         "BTC/USD",
+			Provider:       "coinbase_ws",
+			OffChainTicker: "BTC-USD",
+			Classification: delistings.ClassificationTradingHalted,
+			Reason:         "BTC-USD is listed by coinbase_ws, but has trading status halted",
+			Removed:        true,
+		}}, report.Findings)
+		require.Len(t, upserts, 1)
+		require.Equal(t, []mmtypes.ProviderConfig{binance}, upserts[0].ProviderConfigs)
+	})
+
+	t.Run("no volume is only classified as trading halted with a recorded halt", func(t *testing.T) {
+		onChain := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+			"BTC/USD": markets.New("BTC", enabled, markets.WithProviders(binance, coinbase, kraken)),
+		}}
+		halted := row("coinbase_ws", "BTC-USD", 0, 100)
+		halted.TradingStatus = provider.TradingStatusHalted
+		rows := []provider.GetFilteredProviderMarketsRow{
+			row("binance_ws", "BTCUSDT", 0, 100),
+			halted,
+			row("kraken_api", "XXBTZUSD", 0, 100),
+		}
+
+		ignoreVolume := &config.GenerateConfig{Providers: map[string]config.ProviderConfig{"kraken_api": {IgnoreVolume: true}}}
+		report, _ := delistings.Detect(ignoreVolume, onChain, rows)
+		require.Len(t, report.Findings, 2)
+		require.Equal(t, "coinbase_ws", report.Findings[0].Provider)
+		require.Equal(t, delistings.ClassificationTradingHalted, report.Findings[0].Classification)
+		require.Equal(t, "binance_ws", report.Findings[1].Provider)
+		require.Equal(t, delistings.ClassificationNoVolume, report.Findings[1].Classification)
+	})
+
+	t.Run("keep providers needed for the min provider count and flag the market", func(t *testing.T) {
+		onChain := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+			"BTC/USD": markets.New("BTC", enabled, markets.WithMinProviderCount(2), markets.WithProviders(okx, binance, kraken)),
+		}}
+		rows := []provider.GetFilteredProviderMarketsRow{
+			row("okx_ws", "BTC-USDT", 1000, 1),
+			row("kraken_api", "XXBTZUSD", 1000, 100),
+			row("binance_ws", "ETHUSDT", 1000, 100),
+		}
+
+		report, upserts := delistings.Detect(cfg, onChain, rows)
+
+		// the delisted provider is removed before the provider below threshold
+		require.Len(t, report.Findings, 2)
+		require.Equal(t, "binance_ws", report.Findings[0].Provider)
+		require.True(t, report.Findings[0].Removed)
+		require.Equal(t, "okx_ws", report.Findings[1].Provider)
+		require.Equal(t, delistings.ClassificationBelowThreshold, report.Findings[1].Classification)
+		require.False(t, report.Findings[1].Removed)
+
+		require.Equal(t, []delistings.FlaggedMarket{{
+			Market:           "BTC/USD",
+			MinProviderCount: 2,
+			Providers:        3,
+			Kept:             []string{"okx_ws"},
+		}}, report.FlaggedMarkets)
+
+		require.Len(t, upserts, 1)
+		require.Equal(t, []mmtypes.ProviderConfig{okx, kraken}, upserts[0].ProviderConfigs)
+	})
+
+	t.Run("provider configs of unindexed providers are not classified", func(t *testing.T) {
+		onChain := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+			"BTC/USD": markets.New("BTC", enabled, markets.WithProviders(binance, coinbase)),
+		}}
+		rows := []provider.GetFilteredProviderMarketsRow{
+			row("binance_ws", "BTCUSDT", 1000, 100),
+		}
+
+		report, upserts := delistings.Detect(cfg, onChain, rows)
+		require.Empty(t, report.Findings)
+		require.Equal(t, []string{"coinbase_ws"}, report.UnindexedProviders)
+		require.Empty(t, upserts)
+	})
+
+	t.Run("thresholds are not checked without a generate config", func(t *testing.T) {
+		onChain := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+			"BTC/USD": markets.New("BTC", enabled, markets.WithProviders(okx)),
+		}}
+		rows := []provider.GetFilteredProviderMarketsRow{
+			row("okx_ws", "BTC-USDT", 1, 1),
+		}
+
+		report, upserts := delistings.Detect(nil, onChain, rows)
+		require.Empty(t, report.Findings)
+		require.Empty(t, upserts)
+	})
+}
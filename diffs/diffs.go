@@ -19,6 +19,16 @@ func WriteRemovalReasonsToFile(filePath string, removalReasons types.RemovalReas
 	return os.WriteFile(filePath, bz, 0o600)
 }
 
+// WriteRemovalSummaryToFile writes the summary of the removal reasons, grouped by code and provider, to a file.
+func WriteRemovalSummaryToFile(filePath string, removalReasons types.RemovalReasons) error {
+	bz, err := json.MarshalIndent(removalReasons.Summarize(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal removal summary: %w", err)
+	}
+
+	return os.WriteFile(filePath, bz, 0o600)
+}
+
 // FilterMarketUpdates identifies all fields in the updatedMarket.Ticker and updatedMarket.ProviderConfigs
 // that are different from the corresponding fields in the currentMarket, and zeros (sets to default value)
 // all fields in updatedMarket that are the same as currentMarket.
@@ -3,11 +3,13 @@ package dispatcher
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 
 	cmthttp "github.com/cometbft/cometbft/rpc/client/http"
 	cmttypes "github.com/cometbft/cometbft/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect-mmu/config"
@@ -33,8 +35,15 @@ type Dispatcher struct {
 	// transactionClient is the client that communicates with the market-map module of a chain
 	transactionClient submitter.TransactionSubmitter
 
-	txConfig      config.TransactionConfig
-	signingConfig config.SigningConfig
+	// pipelinedSubmitter broadcasts transactions back to back if set.
+	pipelinedSubmitter *submitter.PipelinedSubmitter
+
+	// stop stops the clients started by the dispatcher, if any.
+	stop func() error
+
+	txConfig        config.TransactionConfig
+	signingConfig   config.SigningConfig
+	submitterConfig config.SubmitterConfig
 }
 
 // New creates a new Dispatcher from a configuration.
@@ -44,9 +53,41 @@ func New(
 	signer signing.SigningAgent,
 	logger *zap.Logger,
 ) (*Dispatcher, error) {
-	// create a comet-rpc client
-	rpcClient, err := cmthttp.New(chainCfg.RPCAddress, "") // ignore websocket for now
+	// create a comet-rpc client for each endpoint. the websocket of the first endpoint that can be started is used to
+	// track the inclusion of pipelined transactions.
+	clients := make([]submitter.CometJSONRPCClient, 0, len(chainCfg.RPCEndpoints()))
+	var (
+		wsClient *cmthttp.HTTP
+		events   submitter.CometEventClient
+	)
+	// stop stops the started websocket client, also if the dispatcher cannot be created
+	stop := func() error {
+		if wsClient == nil {
+			return nil
+		}
+		return wsClient.Stop()
+	}
+	for _, endpoint := range chainCfg.RPCEndpoints() {
+		rpcClient, err := cmthttp.New(endpoint, "/websocket")
+		if err != nil {
+			_ = stop()
+			return nil, err
+		}
+		clients = append(clients, rpcClient)
+
+		if cfg.SubmitterConfig.Pipelined && wsClient == nil {
+			if err := rpcClient.Start(); err != nil {
+				logger.Warn("failed to start websocket client, transaction inclusion will be polled",
+					zap.String("endpoint", endpoint), zap.Error(err))
+				continue
+			}
+			wsClient, events = rpcClient, rpcClient
+		}
+	}
+
+	rpcClient, err := submitter.NewFailoverClient(logger, clients...)
 	if err != nil {
+		_ = stop()
 		return nil, err
 	}
 
@@ -61,15 +102,37 @@ func New(
 		logger,
 	)
 	if err != nil {
+		_ = stop()
 		return nil, err
 	}
 
-	return NewFromClients(
+	d := NewFromClients(
 		txProvider,
 		txSubmitter,
 		logger,
 		cfg,
-	), nil
+	)
+	if cfg.SubmitterConfig.Pipelined {
+		d = d.WithPipelinedSubmitter(submitter.NewPipelinedSubmitter(rpcClient, events, cfg.SubmitterConfig, logger))
+	}
+	d.stop = stop
+
+	return d, nil
+}
+
+// Close stops the websocket client the dispatcher started to track the inclusion of pipelined transactions. It
+// must be called once the dispatcher is no longer used.
+func (d *Dispatcher) Close() error {
+	if d.stop == nil {
+		return nil
+	}
+
+	stop := d.stop
+	d.stop = nil
+	if err := stop(); err != nil {
+		return fmt.Errorf("failed to stop websocket client: %w", err)
+	}
+	return nil
 }
 
 // NewFromClients creates a new Dispatcher.
@@ -85,9 +148,17 @@ func NewFromClients(
 		logger:               logger.With(zap.String("service", ServiceLabel)),
 		txConfig:             cfg.TxConfig,
 		signingConfig:        cfg.SigningConfig,
+		submitterConfig:      cfg.SubmitterConfig,
 	}
 }
 
+// WithPipelinedSubmitter returns a copy of the Dispatcher that submits plans using the given PipelinedSubmitter.
+func (d *Dispatcher) WithPipelinedSubmitter(ps *submitter.PipelinedSubmitter) *Dispatcher {
+	out := *d
+	out.pipelinedSubmitter = ps
+	return &out
+}
+
 // GenerateTransactions generates transactions for a given set of messages based on the dispatcher's tx configuration.
 func (d *Dispatcher) GenerateTransactions(ctx context.Context, msgs []sdk.Msg) ([]cmttypes.Tx, error) {
 	// retrieve set of transactions necessary for submitting upserts
@@ -101,6 +172,30 @@ func (d *Dispatcher) GenerateTransactions(ctx context.Context, msgs []sdk.Msg) (
 	return txs, nil
 }
 
+// PlanBatches simulates the upserts and packs them into batches based on the dispatcher's tx configuration.
+func (d *Dispatcher) PlanBatches(ctx context.Context, upserts []mmtypes.Market) (generator.BatchPlan, error) {
+	plan, err := d.transactionGenerator.PlanBatches(ctx, upserts)
+	if err != nil {
+		d.logger.Error("failed to plan batches", zap.Error(err))
+		return generator.BatchPlan{}, err
+	}
+
+	d.logger.Info("successfully planned batches", zap.Int("batches", len(plan.Batches)))
+	return plan, nil
+}
+
+// GenerateTransactionsFromPlan generates a transaction for each batch of the plan.
+func (d *Dispatcher) GenerateTransactionsFromPlan(ctx context.Context, plan generator.BatchPlan) ([]cmttypes.Tx, error) {
+	txs, err := d.transactionGenerator.GenerateTransactionsFromPlan(ctx, plan)
+	if err != nil {
+		d.logger.Error("failed to generate transactions", zap.Error(err))
+		return nil, err
+	}
+
+	d.logger.Info("successfully generated transactions", zap.Int("transactions", len(txs)))
+	return txs, nil
+}
+
 // SubmitTransactions submits and verifies inclusion of transactions, one by one.
 // If a transaction fails, the function returns the error and does not continue submitting the others.
 func (d *Dispatcher) SubmitTransactions(ctx context.Context, txs []cmttypes.Tx) error {
@@ -116,3 +211,70 @@ func (d *Dispatcher) SubmitTransactions(ctx context.Context, txs []cmttypes.Tx)
 	d.logger.Info("successfully submitted all transactions", zap.Int("transactions", len(txs)))
 	return nil
 }
+
+// SubmitPlan submits the transactions generated from the plan. If the dispatcher is pipelined, the transactions are
+// broadcast back to back and their inclusion is awaited afterwards. If a transaction fails check-tx because of its
+// sequence, the included transactions are awaited, and the remaining batches are re-signed from the current account
+// sequence and broadcast again, up to MaxResigns times. Otherwise, the transactions are submitted one by one.
+func (d *Dispatcher) SubmitPlan(ctx context.Context, plan generator.BatchPlan, txs []cmttypes.Tx) error {
+	if d.pipelinedSubmitter == nil {
+		return d.SubmitTransactions(ctx, txs)
+	}
+
+	if len(txs) != len(plan.Batches) {
+		return fmt.Errorf("expected a transaction for each of the %d batches, got %d", len(plan.Batches), len(txs))
+	}
+
+	batches := plan.Batches
+	for resigns := 0; ; resigns++ {
+		hashes, broadcastErr := d.pipelinedSubmitter.Broadcast(ctx, txs)
+		if err := d.pipelinedSubmitter.WaitForInclusion(ctx, hashes); err != nil {
+			d.logger.Error("failed to wait for transaction inclusion", zap.Error(err))
+			return fmt.Errorf("failed to wait for transaction inclusion: %w", err)
+		}
+
+		if broadcastErr == nil {
+			d.logger.Info("successfully submitted all transactions", zap.Int("transactions", len(plan.Batches)))
+			return nil
+		}
+
+		if !errors.Is(broadcastErr, submitter.ErrSequenceMismatch) || resigns >= d.submitterConfig.MaxResigns {
+			return fmt.Errorf("failed to submit transaction: %w", broadcastErr)
+		}
+
+		batches = batches[len(hashes):]
+		d.logger.Warn("re-signing remaining transactions after sequence mismatch",
+			zap.Int("transactions", len(batches)), zap.Int("resign", resigns+1))
+
+		var err error
+		txs, err = d.transactionGenerator.GenerateTransactionsFromPlan(ctx, generator.BatchPlan{
+			GasPrice: plan.GasPrice,
+			Batches:  batches,
+		})
+		if err != nil {
+			d.logger.Error("failed to re-sign transactions", zap.Error(err))
+			return fmt.Errorf("failed to re-sign transactions: %w", err)
+		}
+	}
+}
+
+// SubmitSigned submits the signed transactions exactly as they are. Unlike SubmitPlan, transactions that fail because
+// of their sequence are not re-signed, since that would change the transactions that were reviewed.
+func (d *Dispatcher) SubmitSigned(ctx context.Context, txs []cmttypes.Tx) error {
+	if d.pipelinedSubmitter == nil {
+		return d.SubmitTransactions(ctx, txs)
+	}
+
+	hashes, broadcastErr := d.pipelinedSubmitter.Broadcast(ctx, txs)
+	if err := d.pipelinedSubmitter.WaitForInclusion(ctx, hashes); err != nil {
+		d.logger.Error("failed to wait for transaction inclusion", zap.Error(err))
+		return fmt.Errorf("failed to wait for transaction inclusion: %w", err)
+	}
+
+	if broadcastErr != nil {
+		return fmt.Errorf("failed to submit transaction: %w", broadcastErr)
+	}
+
+	d.logger.Info("successfully submitted all transactions", zap.Int("transactions", len(txs)))
+	return nil
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"google.golang.org/grpc"
+)
+
+// GrantQuerier queries the x/authz grants of a granter to a grantee.
+type GrantQuerier interface {
+	Grants(ctx context.Context, req *authz.QueryGrantsRequest, opts ...grpc.CallOption) (*authz.QueryGrantsResponse, error)
+}
+
+var _ GrantQuerier = authz.QueryClient(nil)
+
+// CheckGrant returns an error unless the granter granted the msg type to the grantee, and the grant has not expired
+// at now.
+func CheckGrant(
+	ctx context.Context,
+	querier GrantQuerier,
+	granter, grantee, msgTypeURL string,
+	now time.Time,
+) error {
+	res, err := querier.Grants(ctx, &authz.QueryGrantsRequest{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgTypeUrl: msgTypeURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query grants of %s to %s: %w", granter, grantee, err)
+	}
+
+	for _, grant := range res.Grants {
+		if grant.Expiration == nil || grant.Expiration.After(now) {
+			return nil
+		}
+	}
+
+	if len(res.Grants) > 0 {
+		return fmt.Errorf("grant of %s from %s to %s has expired", msgTypeURL, granter, grantee)
+	}
+	return fmt.Errorf("%s has not granted %s to %s", granter, msgTypeURL, grantee)
+}
+
+// newExecMsg wraps the msgs in a MsgExec executed by the grantee.
+func newExecMsg(grantee string, msgs []sdk.Msg) (sdk.Msg, error) {
+	anys := make([]*codectypes.Any, len(msgs))
+	for i, msg := range msgs {
+		a, err := codectypes.NewAnyWithValue(msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack %s: %w", sdk.MsgTypeURL(msg), err)
+		}
+		anys[i] = a
+	}
+
+	return &authz.MsgExec{
+		Grantee: grantee,
+		Msgs:    anys,
+	}, nil
+}
+
+// authority returns the authority of the upserts signed by the signer: the granter if dispatching with authz, and the
+// signer otherwise.
+func (c *coreGenerator) authority(signer string) string {
+	if c.authzConfig != nil {
+		return c.authzConfig.Granter
+	}
+	return signer
+}
+
+// wrapMsgs wraps the msgs in a single MsgExec executed by the signer if dispatching with authz, and returns them
+// unchanged otherwise.
+func (c *coreGenerator) wrapMsgs(signer string, msgs []sdk.Msg) ([]sdk.Msg, error) {
+	if c.authzConfig == nil {
+		return msgs, nil
+	}
+
+	exec, err := newExecMsg(signer, msgs)
+	if err != nil {
+		return nil, err
+	}
+	return []sdk.Msg{exec}, nil
+}
+
+// checkGrant checks that the granter granted every msg of the chain's version and message mode to the signer, if
+// dispatching with authz.
+func (c *coreGenerator) checkGrant(ctx context.Context, signer string) error {
+	if c.authzConfig == nil {
+		return nil
+	}
+
+	if c.grantQuerier == nil {
+		return fmt.Errorf("authz is configured, but no grant querier is set")
+	}
+
+	typeURLs, err := NewMsgBuilder(c.chainConfig.Version, c.chainConfig.MessageMode, mmtypes.MarketMap{}).MsgTypeURLs()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, typeURL := range typeURLs {
+		if err := CheckGrant(ctx, c.grantQuerier, c.authzConfig.Granter, signer, typeURL, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
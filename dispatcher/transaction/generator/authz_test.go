@@ -0,0 +1,92 @@
+package generator_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/skip-mev/connect-mmu/dispatcher/transaction/generator"
+)
+
+const upsertTypeURL = "/connect.marketmap.v2.MsgUpsertMarkets"
+
+// grantQuerier returns the grants, or the error if set.
+type grantQuerier struct {
+	grants []*authz.Grant
+	err    error
+	req    *authz.QueryGrantsRequest
+}
+
+func (q *grantQuerier) Grants(
+	_ context.Context,
+	req *authz.QueryGrantsRequest,
+	_ ...grpc.CallOption,
+) (*authz.QueryGrantsResponse, error) {
+	q.req = req
+	if q.err != nil {
+		return nil, q.err
+	}
+	return &authz.QueryGrantsResponse{Grants: q.grants}, nil
+}
+
+func TestCheckGrant(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		querier *grantQuerier
+		wantErr string
+	}{
+		{
+			name:    "grant without expiration",
+			querier: &grantQuerier{grants: []*authz.Grant{{}}},
+		},
+		{
+			name:    "grant that has not expired",
+			querier: &grantQuerier{grants: []*authz.Grant{{Expiration: &future}}},
+		},
+		{
+			name:    "any grant that has not expired",
+			querier: &grantQuerier{grants: []*authz.Grant{{Expiration: &past}, {Expiration: &future}}},
+		},
+		{
+			name:    "expired grant",
+			querier: &grantQuerier{grants: []*authz.Grant{{Expiration: &past}}},
+			wantErr: "has expired",
+		},
+		{
+			name:    "no grant",
+			querier: &grantQuerier{},
+			wantErr: "has not granted",
+		},
+		{
+			name:    "query failure",
+			querier: &grantQuerier{err: fmt.Errorf("unavailable")},
+			wantErr: "unavailable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := generator.CheckGrant(context.Background(), tc.querier, "granter", "grantee", upsertTypeURL, now)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, &authz.QueryGrantsRequest{
+				Granter:    "granter",
+				Grantee:    "grantee",
+				MsgTypeUrl: upsertTypeURL,
+			}, tc.querier.req)
+		})
+	}
+}
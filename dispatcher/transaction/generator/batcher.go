@@ -0,0 +1,211 @@
+package generator
+
+import (
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+// BatchPlan is the set of transactions, in order of submission, that upsert a set of markets.
+type BatchPlan struct {
+	// GasPrice is the gas price that the fees of the batches are paid at.
+	GasPrice sdk.DecCoin `json:"gas_price"`
+	Batches  []Batch     `json:"batches"`
+}
+
+// TotalFee returns the sum of the fees of all batches.
+func (p BatchPlan) TotalFee() sdk.Coins {
+	total := sdk.NewCoins()
+	for _, batch := range p.Batches {
+		total = total.Add(batch.Fee...)
+	}
+	return total
+}
+
+// Batch is a set of markets that are upserted by a single transaction.
+type Batch struct {
+	// Markets are the markets upserted by the transaction.
+	Markets []mmtypes.Market `json:"markets"`
+	// Bytes is the total size of the markets.
+	Bytes int `json:"bytes"`
+	// Gas is the simulated gas of the transaction, including the gas adjustment.
+	Gas uint64 `json:"gas"`
+	// Fee is the fee paid for the Gas at the gas price of the plan.
+	Fee sdk.Coins `json:"fee"`
+}
+
+// Tickers returns the tickers of the markets of the batch.
+func (b Batch) Tickers() []string {
+	tickers := make([]string, len(b.Markets))
+	for i, market := range b.Markets {
+		tickers[i] = market.Ticker.String()
+	}
+	return tickers
+}
+
+// SimulateFunc returns the adjusted gas of a transaction containing the messages.
+type SimulateFunc func(msgs []sdk.Msg) (uint64, error)
+
+// Batcher packs upserts into transactions that fit within the gas and byte budget of the TransactionConfig.
+type Batcher struct {
+	logger *zap.Logger
+
+	cfg       config.TransactionConfig
+	msgs      MsgBuilder
+	authority string
+	gasPrice  sdk.DecCoin
+
+	simulate SimulateFunc
+}
+
+// NewBatcher creates a Batcher that builds the messages of the MsgBuilder for the authority and simulates them using
+// the given SimulateFunc.
+func NewBatcher(
+	logger *zap.Logger,
+	cfg config.TransactionConfig,
+	msgs MsgBuilder,
+	authority string,
+	simulate SimulateFunc,
+) *Batcher {
+	return &Batcher{
+		logger:    logger,
+		cfg:       cfg,
+		msgs:      msgs,
+		authority: authority,
+		gasPrice:  cfg.MinGasPrice,
+		simulate:  simulate,
+	}
+}
+
+// WithGasPrice returns a copy of the Batcher that computes fees at the gas price, instead of the MinGasPrice.
+func (b *Batcher) WithGasPrice(gasPrice sdk.DecCoin) *Batcher {
+	out := *b
+	out.gasPrice = gasPrice
+	return &out
+}
+
+// Plan packs the upserts into batches. The upserts are first grouped by size, then each group is simulated and split
+// in half, recursively, if it exceeds the gas or byte budget or its simulation fails. The order of the upserts is
+// preserved, so that markets used as normalize-by pairs are upserted before the markets that use them.
+//
+// Markets whose normalize-by market is upserted by an earlier batch cannot be simulated on their own, since the
+// earlier batch is not yet on chain. Such batches are simulated together with the normalize-by markets they depend
+// on, which over-estimates their gas.
+func (b *Batcher) Plan(upserts []mmtypes.Market) (BatchPlan, error) {
+	groups, err := packBySize(b.logger, b.cfg, upserts)
+	if err != nil {
+		return BatchPlan{}, err
+	}
+
+	plan := BatchPlan{GasPrice: b.gasPrice, Batches: make([]Batch, 0, len(groups))}
+	planned := make(map[string]mmtypes.Market)
+	for _, group := range groups {
+		batches, err := b.planGroup(group, planned)
+		if err != nil {
+			return BatchPlan{}, err
+		}
+		plan.Batches = append(plan.Batches, batches...)
+	}
+
+	b.logger.Info("planned batches", zap.Int("markets", len(upserts)), zap.Int("batches", len(plan.Batches)))
+	return plan, nil
+}
+
+// planGroup simulates the group and splits it until every batch is within budget. planned holds the markets of all
+// batches planned so far, and is updated with the markets of the group.
+func (b *Batcher) planGroup(group []mmtypes.Market, planned map[string]mmtypes.Market) ([]Batch, error) {
+	bytes := 0
+	for _, market := range group {
+		bytes += market.Size()
+	}
+
+	gas, err := b.simulateGroup(group, planned)
+	switch {
+	case err != nil:
+		b.logger.Info("simulation of batch failed", zap.Int("markets", len(group)), zap.Error(err))
+	case gas > b.cfg.MaxGas:
+		err = fmt.Errorf("gas estimation of %d exceeds max gas: %d", gas, b.cfg.MaxGas)
+		b.logger.Info("batch exceeds max gas", zap.Int("markets", len(group)), zap.Uint64("gas", gas))
+	case bytes > b.cfg.MaxBytesPerTx:
+		err = fmt.Errorf("batch size of %d exceeds max tx size: %d", bytes, b.cfg.MaxBytesPerTx)
+		b.logger.Info("batch exceeds max tx size", zap.Int("markets", len(group)), zap.Int("bytes", bytes))
+	}
+
+	if err == nil {
+		for _, market := range group {
+			planned[market.Ticker.String()] = market
+		}
+		return []Batch{{
+			Markets: group,
+			Bytes:   bytes,
+			Gas:     gas,
+			Fee:     Fee(b.gasPrice, gas),
+		}}, nil
+	}
+
+	if len(group) == 1 {
+		return nil, fmt.Errorf("failed to plan batch for market %s: %w", group[0].Ticker.String(), err)
+	}
+
+	mid := len(group) / 2
+	left, err := b.planGroup(group[:mid], planned)
+	if err != nil {
+		return nil, err
+	}
+	right, err := b.planGroup(group[mid:], planned)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), nil
+}
+
+// simulateGroup simulates the upsert of the group, preceded by the upsert of the normalize-by markets of the group
+// that are upserted by earlier batches.
+func (b *Batcher) simulateGroup(group []mmtypes.Market, planned map[string]mmtypes.Market) (uint64, error) {
+	dependencies := make([]mmtypes.Market, 0)
+	seen := make(map[string]struct{})
+	for _, market := range group {
+		for _, pc := range market.ProviderConfigs {
+			if pc.NormalizeByPair == nil {
+				continue
+			}
+
+			ticker := pc.NormalizeByPair.String()
+			dependency, found := planned[ticker]
+			if _, ok := seen[ticker]; ok || !found {
+				continue
+			}
+			seen[ticker] = struct{}{}
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	msgs := make([]sdk.Msg, 0, 4)
+	if len(dependencies) > 0 {
+		dependencyMsgs, err := b.msgs.Msgs(b.authority, dependencies)
+		if err != nil {
+			return 0, err
+		}
+		msgs = append(msgs, dependencyMsgs...)
+	}
+
+	groupMsgs, err := b.msgs.Msgs(b.authority, group)
+	if err != nil {
+		return 0, err
+	}
+	msgs = append(msgs, groupMsgs...)
+
+	return b.simulate(msgs)
+}
+
+// Fee returns the fee of a transaction with the given gas at the gas price.
+func Fee(gasPrice sdk.DecCoin, gas uint64) sdk.Coins {
+	amount := gasPrice.Amount.MulInt(sdkmath.NewIntFromUint64(gas)).Ceil().RoundInt()
+	return sdk.NewCoins(sdk.NewCoin(gasPrice.Denom, amount))
+}
@@ -0,0 +1,146 @@
+package generator_test
+
+import (
+	"fmt"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/dispatcher/transaction/generator"
+	"github.com/skip-mev/connect-mmu/testutil/markets"
+)
+
+// okx sets the okx provider of a market with the given base, optionally normalized by a pair.
+func okx(base string, normalizeBy *connecttypes.CurrencyPair) markets.Option {
+	return markets.WithProviders(mmtypes.ProviderConfig{
+		Name: "okx_ws", OffChainTicker: base + "-USDT", NormalizeByPair: normalizeBy,
+	})
+}
+
+// simulator simulates 100 gas per upserted market and fails for the markets of the failing tickers.
+type simulator struct {
+	failing map[string]struct{}
+	calls   [][]string
+}
+
+func (s *simulator) simulate(msgs []sdk.Msg) (uint64, error) {
+	var gas uint64
+	tickers := make([]string, 0)
+	for _, msg := range msgs {
+		for _, market := range msg.(*mmtypes.MsgUpsertMarkets).Markets {
+			if _, found := s.failing[market.Ticker.String()]; found {
+				return 0, fmt.Errorf("simulation of %s failed", market.Ticker.String())
+			}
+			tickers = append(tickers, market.Ticker.String())
+			gas += 100
+		}
+	}
+	s.calls = append(s.calls, tickers)
+	return gas, nil
+}
+
+func TestBatcherPlan(t *testing.T) {
+	usdt := connecttypes.NewCurrencyPair("USDT", "USD")
+	upserts := []mmtypes.Market{
+		markets.UsdtUsd,
+		markets.New("BTC", okx("BTC", &usdt)),
+		markets.New("ETH", okx("ETH", &usdt)),
+		markets.New("SOL", okx("SOL", nil)),
+		markets.New("ATOM", okx("ATOM", nil)),
+	}
+
+	cfg := config.TransactionConfig{
+		MaxBytesPerTx: 100000,
+		MaxGas:        250,
+		GasAdjustment: 1,
+		MinGasPrice:   sdk.NewDecCoinFromDec("utoken", sdkmath.LegacyMustNewDecFromStr("0.5")),
+	}
+	upsertMsgs := generator.NewMsgBuilder(config.VersionConnect, config.MessageModeUpsert, mmtypes.MarketMap{})
+
+	batchTickers := func(plan generator.BatchPlan) [][]string {
+		out := make([][]string, 0, len(plan.Batches))
+		for _, batch := range plan.Batches {
+			out = append(out, batch.Tickers())
+		}
+		return out
+	}
+
+	t.Run("split batches that exceed max gas in order", func(t *testing.T) {
+		sim := &simulator{}
+		batcher := generator.NewBatcher(zaptest.NewLogger(t), cfg, upsertMsgs, "authority", sim.simulate)
+
+		plan, err := batcher.Plan(upserts)
+		require.NoError(t, err)
+		require.Equal(t, [][]string{
+			{"USDT/USD", "BTC/USD"},
+			{"ETH/USD"},
+			{"SOL/USD", "ATOM/USD"},
+		}, batchTickers(plan))
+
+		require.Equal(t, uint64(200), plan.Batches[0].Gas)
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("utoken", 100)), plan.Batches[0].Fee)
+		require.Equal(t, markets.UsdtUsd.Size()+upserts[1].Size(), plan.Batches[0].Bytes)
+
+		// ETH/USD is simulated with the USDT/USD market of the earlier batch it is normalized by
+		require.Contains(t, sim.calls, []string{"USDT/USD", "ETH/USD"})
+		require.Equal(t, uint64(200), plan.Batches[1].Gas)
+	})
+
+	t.Run("split batches that exceed max bytes per tx", func(t *testing.T) {
+		cfg := cfg
+		cfg.MaxGas = 1000
+		cfg.MaxBytesPerTx = upserts[3].Size() + upserts[4].Size()
+
+		sim := &simulator{}
+		batcher := generator.NewBatcher(zaptest.NewLogger(t), cfg, upsertMsgs, "authority", sim.simulate)
+
+		plan, err := batcher.Plan(upserts[3:])
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"SOL/USD", "ATOM/USD"}}, batchTickers(plan))
+
+		cfg.MaxBytesPerTx--
+		batcher = generator.NewBatcher(zaptest.NewLogger(t), cfg, upsertMsgs, "authority", sim.simulate)
+
+		plan, err = batcher.Plan(upserts[3:])
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{"SOL/USD"}, {"ATOM/USD"}}, batchTickers(plan))
+	})
+
+	t.Run("split batches whose simulation fails", func(t *testing.T) {
+		cfg := cfg
+		cfg.MaxGas = 1000
+
+		sim := &simulator{failing: map[string]struct{}{"ATOM/USD": {}}}
+		batcher := generator.NewBatcher(zaptest.NewLogger(t), cfg, upsertMsgs, "authority", sim.simulate)
+
+		_, err := batcher.Plan(upserts)
+		require.ErrorContains(t, err, "failed to plan batch for market ATOM/USD")
+	})
+
+	t.Run("fail if a single market exceeds max gas", func(t *testing.T) {
+		cfg := cfg
+		cfg.MaxGas = 50
+
+		sim := &simulator{}
+		batcher := generator.NewBatcher(zaptest.NewLogger(t), cfg, upsertMsgs, "authority", sim.simulate)
+
+		_, err := batcher.Plan(upserts)
+		require.ErrorContains(t, err, "exceeds max gas")
+	})
+
+	t.Run("fail for invalid markets", func(t *testing.T) {
+		invalid := markets.New("BTC", okx("BTC", nil))
+		invalid.Ticker.Decimals = 0
+
+		batcher := generator.NewBatcher(zaptest.NewLogger(t), cfg, upsertMsgs, "authority", (&simulator{}).simulate)
+
+		_, err := batcher.Plan([]mmtypes.Market{invalid})
+		require.Error(t, err)
+	})
+}
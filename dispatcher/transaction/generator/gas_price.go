@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+// feeMarketGasPriceMethod is the x/feemarket query of the gas price of a denom.
+const feeMarketGasPriceMethod = "/feemarket.feemarket.v1.Query/GasPrice"
+
+// GasPriceOracle returns the gas price that transaction fees are paid at.
+type GasPriceOracle interface {
+	GasPrice(ctx context.Context) (sdk.DecCoin, error)
+}
+
+// NewGasPriceOracle creates the GasPriceOracle of the configured gas price source. Discovered gas prices are
+// floored at the MinGasPrice.
+func NewGasPriceOracle(cfg config.TransactionConfig, conn grpc.ClientConnInterface) (GasPriceOracle, error) {
+	switch cfg.GasPriceSource {
+	case "", config.GasPriceSourceStatic:
+		return StaticGasPrice(cfg.MinGasPrice), nil
+	case config.GasPriceSourceFeeMarket:
+		return NewFeeMarketGasPrice(conn, cfg.MinGasPrice), nil
+	case config.GasPriceSourceNode:
+		return NewNodeGasPrice(node.NewServiceClient(conn), cfg.MinGasPrice), nil
+	default:
+		return nil, fmt.Errorf("unsupported gas price source: %s", cfg.GasPriceSource)
+	}
+}
+
+// StaticGasPrice is a GasPriceOracle that always returns the same gas price.
+type StaticGasPrice sdk.DecCoin
+
+func (s StaticGasPrice) GasPrice(_ context.Context) (sdk.DecCoin, error) {
+	return sdk.DecCoin(s), nil
+}
+
+// FeeMarketGasPrice is a GasPriceOracle that queries the gas price of the chain's x/feemarket module.
+type FeeMarketGasPrice struct {
+	conn        grpc.ClientConnInterface
+	minGasPrice sdk.DecCoin
+}
+
+// NewFeeMarketGasPrice creates a FeeMarketGasPrice that queries the gas price of the denom of minGasPrice.
+func NewFeeMarketGasPrice(conn grpc.ClientConnInterface, minGasPrice sdk.DecCoin) *FeeMarketGasPrice {
+	return &FeeMarketGasPrice{conn: conn, minGasPrice: minGasPrice}
+}
+
+func (f *FeeMarketGasPrice) GasPrice(ctx context.Context) (sdk.DecCoin, error) {
+	req := &feeMarketGasPriceRequest{Denom: f.minGasPrice.Denom}
+	res := &feeMarketGasPriceResponse{}
+	if err := f.conn.Invoke(ctx, feeMarketGasPriceMethod, req, res); err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("failed to query feemarket gas price: %w", err)
+	}
+
+	return floorGasPrice(res.Price, f.minGasPrice)
+}
+
+// NodeGasPrice is a GasPriceOracle that queries the min-gas-prices of the node.
+type NodeGasPrice struct {
+	client      node.ServiceClient
+	minGasPrice sdk.DecCoin
+}
+
+// NewNodeGasPrice creates a NodeGasPrice that returns the node's min gas price of the denom of minGasPrice.
+func NewNodeGasPrice(client node.ServiceClient, minGasPrice sdk.DecCoin) *NodeGasPrice {
+	return &NodeGasPrice{client: client, minGasPrice: minGasPrice}
+}
+
+func (n *NodeGasPrice) GasPrice(ctx context.Context) (sdk.DecCoin, error) {
+	res, err := n.client.Config(ctx, &node.ConfigRequest{})
+	if err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("failed to query node config: %w", err)
+	}
+
+	prices, err := sdk.ParseDecCoins(res.MinimumGasPrice)
+	if err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("failed to parse node min gas prices %q: %w", res.MinimumGasPrice, err)
+	}
+
+	return floorGasPrice(sdk.NewDecCoinFromDec(n.minGasPrice.Denom, prices.AmountOf(n.minGasPrice.Denom)), n.minGasPrice)
+}
+
+// floorGasPrice returns price, or minGasPrice if it is higher. price must be of the denom of minGasPrice.
+func floorGasPrice(price, minGasPrice sdk.DecCoin) (sdk.DecCoin, error) {
+	if price.Denom != minGasPrice.Denom {
+		return sdk.DecCoin{}, fmt.Errorf("gas price denom %s does not match min gas price denom %s", price.Denom,
+			minGasPrice.Denom)
+	}
+
+	if price.Amount.LT(minGasPrice.Amount) {
+		return minGasPrice, nil
+	}
+	return price, nil
+}
+
+// feeMarketGasPriceRequest is the x/feemarket GasPriceRequest. The feemarket module is not a dependency, so the
+// request and response are encoded by hand.
+type feeMarketGasPriceRequest struct {
+	Denom string
+}
+
+func (m *feeMarketGasPriceRequest) Reset()         { *m = feeMarketGasPriceRequest{} }
+func (m *feeMarketGasPriceRequest) String() string { return m.Denom }
+func (*feeMarketGasPriceRequest) ProtoMessage()    {}
+
+func (m *feeMarketGasPriceRequest) Marshal() ([]byte, error) {
+	var bz []byte
+	bz = protowire.AppendTag(bz, 1, protowire.BytesType)
+	bz = protowire.AppendString(bz, m.Denom)
+	return bz, nil
+}
+
+func (m *feeMarketGasPriceRequest) Unmarshal(bz []byte) error {
+	m.Reset()
+	return consumeFields(bz, func(num protowire.Number, value []byte) error {
+		if num == 1 {
+			m.Denom = string(value)
+		}
+		return nil
+	})
+}
+
+// feeMarketGasPriceResponse is the x/feemarket GasPriceResponse.
+type feeMarketGasPriceResponse struct {
+	Price sdk.DecCoin
+}
+
+func (m *feeMarketGasPriceResponse) Reset()         { *m = feeMarketGasPriceResponse{} }
+func (m *feeMarketGasPriceResponse) String() string { return m.Price.String() }
+func (*feeMarketGasPriceResponse) ProtoMessage()    {}
+
+func (m *feeMarketGasPriceResponse) Marshal() ([]byte, error) {
+	price, err := m.Price.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	var bz []byte
+	bz = protowire.AppendTag(bz, 1, protowire.BytesType)
+	bz = protowire.AppendBytes(bz, price)
+	return bz, nil
+}
+
+func (m *feeMarketGasPriceResponse) Unmarshal(bz []byte) error {
+	m.Reset()
+	return consumeFields(bz, func(num protowire.Number, value []byte) error {
+		if num == 1 {
+			return m.Price.Unmarshal(value)
+		}
+		return nil
+	})
+}
+
+// consumeFields calls fn with the number and value of each length-delimited field of the message, skipping all other
+// fields.
+func consumeFields(bz []byte, fn func(num protowire.Number, value []byte) error) error {
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		bz = bz[n:]
+
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, bz)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			bz = bz[n:]
+			continue
+		}
+
+		value, n := protowire.ConsumeBytes(bz)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		bz = bz[n:]
+
+		if err := fn(num, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
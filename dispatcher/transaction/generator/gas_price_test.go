@@ -0,0 +1,112 @@
+package generator_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/mem"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/dispatcher/transaction/generator"
+)
+
+// feeMarketConn serves the x/feemarket gas price query through the grpc proto codec.
+type feeMarketConn struct {
+	grpc.ClientConnInterface
+	price sdk.DecCoin
+	denom string
+}
+
+func (c *feeMarketConn) Invoke(_ context.Context, method string, args, reply any, _ ...grpc.CallOption) error {
+	if method != "/feemarket.feemarket.v1.Query/GasPrice" {
+		return fmt.Errorf("unexpected method %s", method)
+	}
+
+	codec := encoding.GetCodecV2(proto.Name)
+	req, err := codec.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	// the request only has the denom
+	_, _, n := protowire.ConsumeTag(req.Materialize())
+	denom, _ := protowire.ConsumeString(req.Materialize()[n:])
+	c.denom = denom
+
+	price, err := c.price.Marshal()
+	if err != nil {
+		return err
+	}
+	var res []byte
+	res = protowire.AppendTag(res, 1, protowire.BytesType)
+	res = protowire.AppendBytes(res, price)
+
+	return codec.Unmarshal(mem.BufferSlice{mem.SliceBuffer(res)}, reply)
+}
+
+// nodeClient returns the min gas prices of the node config.
+type nodeClient struct {
+	node.ServiceClient
+	minGasPrice string
+}
+
+func (c *nodeClient) Config(_ context.Context, _ *node.ConfigRequest, _ ...grpc.CallOption) (*node.ConfigResponse, error) {
+	return &node.ConfigResponse{MinimumGasPrice: c.minGasPrice}, nil
+}
+
+func TestGasPriceOracles(t *testing.T) {
+	minGasPrice := sdk.NewDecCoinFromDec("utoken", sdkmath.LegacyMustNewDecFromStr("0.5"))
+
+	t.Run("static gas price", func(t *testing.T) {
+		oracle, err := generator.NewGasPriceOracle(config.TransactionConfig{MinGasPrice: minGasPrice}, nil)
+		require.NoError(t, err)
+
+		price, err := oracle.GasPrice(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, minGasPrice, price)
+	})
+
+	t.Run("feemarket gas price", func(t *testing.T) {
+		conn := &feeMarketConn{price: sdk.NewDecCoinFromDec("utoken", sdkmath.LegacyMustNewDecFromStr("0.75"))}
+		price, err := generator.NewFeeMarketGasPrice(conn, minGasPrice).GasPrice(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "utoken", conn.denom)
+		require.Equal(t, conn.price, price)
+	})
+
+	t.Run("feemarket gas price is floored at the min gas price", func(t *testing.T) {
+		conn := &feeMarketConn{price: sdk.NewDecCoinFromDec("utoken", sdkmath.LegacyMustNewDecFromStr("0.25"))}
+		price, err := generator.NewFeeMarketGasPrice(conn, minGasPrice).GasPrice(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, minGasPrice, price)
+	})
+
+	t.Run("feemarket gas price of another denom", func(t *testing.T) {
+		conn := &feeMarketConn{price: sdk.NewDecCoinFromDec("stake", sdkmath.LegacyMustNewDecFromStr("0.75"))}
+		_, err := generator.NewFeeMarketGasPrice(conn, minGasPrice).GasPrice(context.Background())
+		require.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("node gas price", func(t *testing.T) {
+		client := &nodeClient{minGasPrice: "0.1stake,1.5utoken"}
+		price, err := generator.NewNodeGasPrice(client, minGasPrice).GasPrice(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, sdk.NewDecCoinFromDec("utoken", sdkmath.LegacyMustNewDecFromStr("1.5")), price)
+	})
+
+	t.Run("node without a gas price of the denom", func(t *testing.T) {
+		client := &nodeClient{minGasPrice: "0.1stake"}
+		price, err := generator.NewNodeGasPrice(client, minGasPrice).GasPrice(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, minGasPrice, price)
+	})
+}
@@ -9,9 +9,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 	"go.uber.org/zap"
 
+	"github.com/skip-mev/connect-mmu/client/marketmap"
 	"github.com/skip-mev/connect-mmu/config"
 	mmusigning "github.com/skip-mev/connect-mmu/signing"
 )
@@ -21,6 +24,13 @@ import (
 type TransactionGenerator interface {
 	// GenerateTransactions returns a set of transactions to upsert a set of markets.
 	GenerateTransactions(ctx context.Context, msgs []sdk.Msg) ([]cmttypes.Tx, error)
+
+	// PlanBatches simulates the upserts and packs them into batches that fit within the gas and byte budget of a
+	// transaction.
+	PlanBatches(ctx context.Context, upserts []mmtypes.Market) (BatchPlan, error)
+
+	// GenerateTransactionsFromPlan returns a transaction for each batch of the plan, using the planned gas.
+	GenerateTransactionsFromPlan(ctx context.Context, plan BatchPlan) ([]cmttypes.Tx, error)
 }
 
 // coreGenerator is a set of core types commonly shared by generators
@@ -35,37 +45,72 @@ type coreGenerator struct {
 	txConfig      config.TransactionConfig
 	signingConfig config.SigningConfig
 	chainConfig   config.ChainConfig
+	authzConfig   *config.AuthzConfig
 
 	// gasEstimator is used to simulate transactions and estimate gas costs.
 	gasEstimator GasEstimator
 
 	// signingAgent is used to sign transactions as they are being generated.
 	signingAgent mmusigning.SigningAgent
+
+	// grantQuerier is used to check the authz grant of the signer, if authz is configured.
+	grantQuerier GrantQuerier
+
+	// gasPrices returns the gas price that fees are paid at.
+	gasPrices GasPriceOracle
+
+	// marketMapClient returns the on-chain market map, used to split upserts into creates and updates if the chain
+	// uses MessageModeCreateUpdate.
+	marketMapClient marketmap.Client
+}
+
+// msgBuilder returns the MsgBuilder of the chain. The on-chain market map is only queried in
+// MessageModeCreateUpdate.
+func (c *coreGenerator) msgBuilder(ctx context.Context) (MsgBuilder, error) {
+	if c.chainConfig.MessageMode != config.MessageModeCreateUpdate {
+		return NewMsgBuilder(c.chainConfig.Version, c.chainConfig.MessageMode, mmtypes.MarketMap{}), nil
+	}
+
+	if c.marketMapClient == nil {
+		return MsgBuilder{}, fmt.Errorf("message mode %s requires a market map client", c.chainConfig.MessageMode)
+	}
+
+	onChain, err := c.marketMapClient.GetMarketMap(ctx)
+	if err != nil {
+		return MsgBuilder{}, fmt.Errorf("failed to get on-chain market map: %w", err)
+	}
+
+	return NewMsgBuilder(c.chainConfig.Version, c.chainConfig.MessageMode, onChain), nil
 }
 
 func (c *coreGenerator) estimateUnsignedTx(
-	msg sdk.Msg,
+	msgs []sdk.Msg,
+	gasPrice sdk.DecCoin,
 	accSequence,
 	simSequence uint64,
 ) (client.TxBuilder, error) {
-	txf := tx.Factory{}
+	gas, err := c.estimateGas(msgs, simSequence)
+	if err != nil {
+		return nil, err
+	}
+	if gas > c.txConfig.MaxGas {
+		return nil, fmt.Errorf("gas estimation of %d exceeds max gas: %d", gas, c.txConfig.MaxGas)
+	}
 
-	minGasPrice := c.txConfig.MinGasPrice
+	return c.buildUnsignedTx(msgs, gas, Fee(gasPrice, gas), accSequence)
+}
 
+// estimateGas simulates a transaction containing the msgs and returns its adjusted gas.
+func (c *coreGenerator) estimateGas(msgs []sdk.Msg, simSequence uint64) (uint64, error) {
+	txf := c.txFactory()
 	txf = txf.WithGas(c.txConfig.MaxGas)
-	txf = txf.WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
 	// Set sequence for simulation.
 	txf = txf.WithSequence(simSequence)
-	txf = txf.WithChainID(c.chainConfig.ChainID)
-	txf = txf.WithTxConfig(c.sdkTxConfig)
 
 	c.logger.Info("estimating transaction and gas")
-	gas, err := c.gasEstimator.Estimate(txf, []sdk.Msg{msg}, c.txConfig.GasAdjustment)
+	gas, err := c.gasEstimator.Estimate(txf, msgs, c.txConfig.GasAdjustment)
 	if err != nil {
-		return nil, fmt.Errorf("failed to estimate gas: %w", err)
-	}
-	if gas > c.txConfig.MaxGas {
-		return nil, fmt.Errorf("gas estimation of %d exceeds max gas: %d", gas, c.txConfig.MaxGas)
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
 	}
 	c.logger.Info("gas returned from tx simulation", zap.Uint64("gas_estimation", gas))
 
@@ -73,8 +118,14 @@ func (c *coreGenerator) estimateUnsignedTx(
 		gas = math.MaxInt64
 	}
 
-	// create some padding
-	txf = txf.WithGasPrices(minGasPrice.String())
+	return gas, nil
+}
+
+// buildUnsignedTx builds the unsigned transaction of the msgs with the given gas, fee and sequence.
+func (c *coreGenerator) buildUnsignedTx(msgs []sdk.Msg, gas uint64, fee sdk.Coins, accSequence uint64) (client.TxBuilder, error) {
+	txf := c.txFactory()
+
+	txf = txf.WithFees(fee.String())
 	txf = txf.WithGas(gas)
 	txf = txf.WithSequence(accSequence) // set actual sequence
 
@@ -82,8 +133,76 @@ func (c *coreGenerator) estimateUnsignedTx(
 		zap.String("chain-id", txf.ChainID()),
 		zap.Uint64("sequence", txf.Sequence()),
 		zap.Uint64("gas_estimation", gas),
-		zap.String("gas prices", txf.GasPrices().String()),
+		zap.String("fees", txf.Fees().String()),
+		zap.String("fee granter", c.txConfig.FeeGranter),
 	)
 
-	return txf.BuildUnsignedTx(msg)
+	txb, err := txf.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.setFeeGranter(txb); err != nil {
+		return nil, err
+	}
+
+	return txb, nil
+}
+
+// setFeeGranter sets the fee granter of the transaction. It is set on the proto tx directly, since the tx builder
+// encodes addresses with the global bech32 prefix instead of the prefix of the chain.
+func (c *coreGenerator) setFeeGranter(txb client.TxBuilder) error {
+	if c.txConfig.FeeGranter == "" {
+		return nil
+	}
+
+	protoTxb, ok := txb.(interface{ GetProtoTx() *txtypes.Tx })
+	if !ok {
+		return fmt.Errorf("unexpected tx builder type %T", txb)
+	}
+
+	fee := protoTxb.GetProtoTx().AuthInfo.Fee
+	fee.Granter = c.txConfig.FeeGranter
+
+	// reset the cached auth info bytes of the builder
+	txb.SetFeeAmount(fee.Amount)
+	return nil
+}
+
+// gasPrice returns the gas price that fees are paid at.
+func (c *coreGenerator) gasPrice(ctx context.Context) (sdk.DecCoin, error) {
+	if c.gasPrices == nil {
+		return c.txConfig.MinGasPrice, nil
+	}
+
+	price, err := c.gasPrices.GasPrice(ctx)
+	if err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	c.logger.Info("using gas price", zap.String("gas_price", price.String()),
+		zap.String("source", c.txConfig.GasPriceSource))
+	return price, nil
+}
+
+// checkFeeCap returns an error if the total fee exceeds the MaxTotalFee.
+func (c *coreGenerator) checkFeeCap(total sdk.Coins) error {
+	if c.txConfig.MaxTotalFee.Empty() {
+		return nil
+	}
+
+	if !total.IsAllLTE(c.txConfig.MaxTotalFee) {
+		c.logger.Error("total fee exceeds max total fee", zap.String("total_fee", total.String()),
+			zap.String("max_total_fee", c.txConfig.MaxTotalFee.String()))
+		return fmt.Errorf("total fee %s exceeds max total fee %s", total, c.txConfig.MaxTotalFee)
+	}
+
+	return nil
+}
+
+func (c *coreGenerator) txFactory() tx.Factory {
+	return tx.Factory{}.
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT).
+		WithChainID(c.chainConfig.ChainID).
+		WithTxConfig(c.sdkTxConfig)
 }
@@ -8,12 +8,13 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
-	slinkymmtypes "github.com/skip-mev/slinky/x/marketmap/types"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/skip-mev/connect-mmu/client/marketmap"
 	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/signing"
 )
@@ -45,8 +46,24 @@ func NewSigningTransactionGeneratorFromConfig(
 		return nil, fmt.Errorf("failed to create chain gRPC client: %w", err)
 	}
 
+	// the msgs are built for the version of the chain, which may be detected from the node
+	chainCfg.Version, err = marketmap.ResolveVersion(context.Background(), logger, chainCfg, chainGRPC)
+	if err != nil {
+		return nil, err
+	}
+
 	gasEstimator := NewSimulationGasEstimator(chainGRPC, logger)
 
+	gasPrices, err := NewGasPriceOracle(cfg.TxConfig, chainGRPC)
+	if err != nil {
+		return nil, err
+	}
+
+	marketMapClient, err := marketmap.NewClientFromChainConfig(logger, chainCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create market map client: %w", err)
+	}
+
 	return NewSigningTransactionGenerator(
 		cdc,
 		cfg,
@@ -54,6 +71,9 @@ func NewSigningTransactionGeneratorFromConfig(
 		logger,
 		gasEstimator,
 		signingAgent,
+		authz.NewQueryClient(chainGRPC),
+		gasPrices,
+		marketMapClient,
 	)
 }
 
@@ -65,84 +85,96 @@ func NewSigningTransactionGenerator(
 	logger *zap.Logger,
 	gasEstimator GasEstimator,
 	signingAgent signing.SigningAgent,
+	grantQuerier GrantQuerier,
+	gasPrices GasPriceOracle,
+	marketMapClient marketmap.Client,
 ) (TransactionGenerator, error) {
 	sdkTxConfig := signing.TxConfig(codec)
 
 	return &SigningTransactionGenerator{
 		coreGenerator: coreGenerator{
-			sdkTxConfig:   sdkTxConfig,
-			logger:        logger,
-			txConfig:      cfg.TxConfig,
-			signingConfig: cfg.SigningConfig,
-			chainConfig:   chainCfg,
-			gasEstimator:  gasEstimator,
-			signingAgent:  signingAgent,
+			sdkTxConfig:     sdkTxConfig,
+			logger:          logger,
+			txConfig:        cfg.TxConfig,
+			signingConfig:   cfg.SigningConfig,
+			chainConfig:     chainCfg,
+			authzConfig:     cfg.AuthzConfig,
+			gasEstimator:    gasEstimator,
+			signingAgent:    signingAgent,
+			grantQuerier:    grantQuerier,
+			gasPrices:       gasPrices,
+			marketMapClient: marketMapClient,
 		},
 	}, nil
 }
 
 // GenerateTransactions generates and signs a set of transactions from the given set of markets using its internally
 // configured wallet. Simulate can be set to true which will simulate the execution of the transactions, but will not
-// sign and dispatch them.
+// sign and dispatch them. Each msg must be one built by the MsgBuilder of the chain's version and message mode. If
+// authz is configured, the granter is kept as the authority and each msg is executed by the signer with a MsgExec.
 func (s *SigningTransactionGenerator) GenerateTransactions(
 	ctx context.Context,
 	msgs []sdk.Msg,
 ) ([]cmttypes.Tx, error) {
-	// get the account
-	acc, err := s.coreGenerator.signingAgent.GetSigningAccount(ctx)
+	baseAcc, err := s.signingAccount(ctx)
 	if err != nil {
-		s.logger.Error("failed to get signing account", zap.Error(err))
+		return nil, err
+	}
+	address := baseAcc.Address
+	if err := s.checkGrant(ctx, address); err != nil {
 		return nil, err
 	}
 
-	// convert to a base account
-	baseAcc, ok := acc.(*authtypes.BaseAccount)
-	if !ok {
-		return nil, fmt.Errorf("expected BaseAccount but got %T", acc)
+	gasPrice, err := s.gasPrice(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	s.logger.Info("account used to submit txs", zap.Any("account", baseAcc))
-	address := baseAcc.Address
-	s.logger.Info("derived signing address", zap.String("address", address))
+	msgTypeURLs, err := NewMsgBuilder(s.chainConfig.Version, s.chainConfig.MessageMode, mmtypes.MarketMap{}).MsgTypeURLs()
+	if err != nil {
+		return nil, err
+	}
+	typeURLs := make(map[string]struct{}, len(msgTypeURLs))
+	for _, typeURL := range msgTypeURLs {
+		typeURLs[typeURL] = struct{}{}
+	}
 
 	txs := make([]cmttypes.Tx, 0)
+	totalFee := sdk.NewCoins()
 	simSequence := baseAcc.GetSequence()
 
 	for _, msg := range msgs {
 		accSequence := baseAcc.GetSequence()
 
-		var upsertMsg sdk.Msg
-		switch s.chainConfig.Version {
-		case config.VersionConnect:
-			upsert, ok := msg.(*mmtypes.MsgUpsertMarkets)
-			if !ok {
-				s.logger.Error("failed to cast sdk.Msg to expected type connect.MsgUpsertMarkets", zap.Any("msg", msg))
-				return nil, fmt.Errorf("failed to cast sdk.Msg to expected type connect.MsgUpsertMarkets")
-			}
-			// ensure that the message authority is the signer key bech32 address for the chain
-			upsert.Authority = address
-
-			upsertMsg = upsert
-		case config.VersionSlinky:
-			upsert, ok := msg.(*slinkymmtypes.MsgUpsertMarkets)
-			if !ok {
-				s.logger.Error("failed to cast sdk.Msg to expected type slinky.MsgUpsertMarkets", zap.Any("msg", msg))
-				return nil, fmt.Errorf("failed to cast sdk.Msg to expected type slinky.MsgUpsertMarkets")
-			}
-			// ensure that the message authority is the signer key bech32 address for the chain
-			upsert.Authority = address
-
-			upsertMsg = upsert
-		default:
-			return nil, fmt.Errorf("unsupported version: %s", s.chainConfig.Version)
+		if _, ok := typeURLs[sdk.MsgTypeURL(msg)]; !ok {
+			s.logger.Error("unexpected msg for chain", zap.String("type_url", sdk.MsgTypeURL(msg)),
+				zap.String("version", string(s.chainConfig.Version)),
+				zap.String("message_mode", string(s.chainConfig.MessageMode)))
+			return nil, fmt.Errorf("unexpected msg %s for chain version %s and message mode %s", sdk.MsgTypeURL(msg),
+				s.chainConfig.Version, s.chainConfig.MessageMode)
 		}
 
-		txb, err := s.estimateUnsignedTx(upsertMsg, accSequence, simSequence)
+		// ensure that the message authority is the signer key bech32 address for the chain, or its granter
+		if err := setAuthority(msg, s.authority(address)); err != nil {
+			return nil, err
+		}
+
+		txMsgs, err := s.wrapMsgs(address, []sdk.Msg{msg})
+		if err != nil {
+			return nil, err
+		}
+
+		txb, err := s.estimateUnsignedTx(txMsgs, gasPrice, accSequence, simSequence)
 		if err != nil {
 			s.logger.Error("failed to estimate tx", zap.Error(err))
 			return nil, err
 		}
 
+		totalFee = totalFee.Add(txb.GetTx().GetFee()...)
+		if err := s.checkFeeCap(totalFee); err != nil {
+			return nil, err
+		}
+
 		tx, err := s.signingAgent.Sign(ctx, txb)
 		if err != nil {
 			s.logger.Error("failed to sign tx", zap.Error(err))
@@ -161,3 +193,117 @@ func (s *SigningTransactionGenerator) GenerateTransactions(
 	s.logger.Info("generated txs", zap.Int("num tx", len(txs)))
 	return txs, nil
 }
+
+// PlanBatches simulates the upserts as the signing account and packs them into batches that fit within the gas and
+// byte budget of a transaction. If authz is configured, the grant of the signing account is checked first.
+func (s *SigningTransactionGenerator) PlanBatches(ctx context.Context, upserts []mmtypes.Market) (BatchPlan, error) {
+	baseAcc, err := s.signingAccount(ctx)
+	if err != nil {
+		return BatchPlan{}, err
+	}
+
+	if err := s.checkGrant(ctx, baseAcc.Address); err != nil {
+		return BatchPlan{}, err
+	}
+
+	gasPrice, err := s.gasPrice(ctx)
+	if err != nil {
+		return BatchPlan{}, err
+	}
+
+	msgBuilder, err := s.msgBuilder(ctx)
+	if err != nil {
+		return BatchPlan{}, err
+	}
+
+	simSequence := baseAcc.GetSequence()
+	authority := s.authority(baseAcc.Address)
+	batcher := NewBatcher(s.logger, s.txConfig, msgBuilder, authority, func(msgs []sdk.Msg) (uint64, error) {
+		txMsgs, err := s.wrapMsgs(baseAcc.Address, msgs)
+		if err != nil {
+			return 0, err
+		}
+		return s.estimateGas(txMsgs, simSequence)
+	}).WithGasPrice(gasPrice)
+
+	return batcher.Plan(upserts)
+}
+
+// GenerateTransactionsFromPlan generates and signs a transaction for each batch of the plan, in order, using the
+// planned gas and fee of the batch. It fails before signing if the total fee of the plan exceeds the MaxTotalFee.
+func (s *SigningTransactionGenerator) GenerateTransactionsFromPlan(ctx context.Context, plan BatchPlan) ([]cmttypes.Tx, error) {
+	baseAcc, err := s.signingAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkGrant(ctx, baseAcc.Address); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkFeeCap(plan.TotalFee()); err != nil {
+		return nil, err
+	}
+
+	msgBuilder, err := s.msgBuilder(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]cmttypes.Tx, 0, len(plan.Batches))
+	for i, batch := range plan.Batches {
+		accSequence := baseAcc.GetSequence()
+
+		msgs, err := msgBuilder.Msgs(s.authority(baseAcc.Address), batch.Markets)
+		if err != nil {
+			return nil, err
+		}
+
+		txMsgs, err := s.wrapMsgs(baseAcc.Address, msgs)
+		if err != nil {
+			return nil, err
+		}
+
+		txb, err := s.buildUnsignedTx(txMsgs, batch.Gas, batch.Fee, accSequence)
+		if err != nil {
+			s.logger.Error("failed to build tx", zap.Int("batch", i), zap.Error(err))
+			return nil, err
+		}
+
+		tx, err := s.signingAgent.Sign(ctx, txb)
+		if err != nil {
+			s.logger.Error("failed to sign tx", zap.Int("batch", i), zap.Error(err))
+			return nil, err
+		}
+
+		// update the account sequence
+		if err := baseAcc.SetSequence(accSequence + 1); err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	s.logger.Info("generated txs", zap.Int("num tx", len(txs)))
+	return txs, nil
+}
+
+// signingAccount returns the account of the signing agent.
+func (s *SigningTransactionGenerator) signingAccount(ctx context.Context) (*authtypes.BaseAccount, error) {
+	// get the account
+	acc, err := s.signingAgent.GetSigningAccount(ctx)
+	if err != nil {
+		s.logger.Error("failed to get signing account", zap.Error(err))
+		return nil, err
+	}
+
+	// convert to a base account
+	baseAcc, ok := acc.(*authtypes.BaseAccount)
+	if !ok {
+		return nil, fmt.Errorf("expected BaseAccount but got %T", acc)
+	}
+
+	s.logger.Info("account used to submit txs", zap.Any("account", baseAcc))
+	s.logger.Info("derived signing address", zap.String("address", baseAcc.Address))
+	return baseAcc, nil
+}
@@ -0,0 +1,201 @@
+package generator_test
+
+import (
+	"context"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/skip-mev/connect-mmu/client/marketmap"
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/dispatcher/transaction/generator"
+	"github.com/skip-mev/connect-mmu/signing"
+	"github.com/skip-mev/connect-mmu/testutil/markets"
+)
+
+const (
+	signerAddress = "dydx1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5kmz6xt"
+	otherAddress  = "dydx10d07y265gmmuvt4z0w9aw880jnsr700jnmapky"
+)
+
+// signingAgent records the tx builders it signs.
+type signingAgent struct {
+	signed []client.TxBuilder
+}
+
+func (s *signingAgent) Sign(_ context.Context, txb client.TxBuilder) (cmttypes.Tx, error) {
+	s.signed = append(s.signed, txb)
+	return cmttypes.Tx("tx"), nil
+}
+
+func (s *signingAgent) GetSigningAccount(_ context.Context) (sdk.AccountI, error) {
+	return &authtypes.BaseAccount{Address: signerAddress, Sequence: 5}, nil
+}
+
+// marketMapClient returns a fixed on-chain market map.
+type marketMapClient struct {
+	marketMap mmtypes.MarketMap
+}
+
+func (c *marketMapClient) GetMarketMap(_ context.Context) (mmtypes.MarketMap, error) {
+	return c.marketMap, nil
+}
+
+func TestGenerateTransactionsFromPlan(t *testing.T) {
+	cdc, err := signing.Codec("dydx")
+	require.NoError(t, err)
+
+	chainCfg := config.ChainConfig{ChainID: "dydx-testnet-4", Version: config.VersionConnect, Prefix: "dydx"}
+	txCfg := config.TransactionConfig{
+		MaxBytesPerTx: 100000,
+		MaxGas:        1000,
+		GasAdjustment: 1,
+		MinGasPrice:   sdk.NewDecCoinFromDec("utoken", sdkmath.LegacyMustNewDecFromStr("0.5")),
+	}
+
+	plan := generator.BatchPlan{
+		GasPrice: txCfg.MinGasPrice,
+		Batches: []generator.Batch{
+			{Markets: []mmtypes.Market{markets.New("BTC", okx("BTC", nil))}, Gas: 200, Fee: sdk.NewCoins(sdk.NewInt64Coin("utoken", 100))},
+			{Markets: []mmtypes.Market{markets.New("ETH", okx("ETH", nil))}, Gas: 300, Fee: sdk.NewCoins(sdk.NewInt64Coin("utoken", 150))},
+		},
+	}
+
+	newGenerator := func(cfg config.DispatchConfig, agent *signingAgent, grants generator.GrantQuerier) generator.TransactionGenerator {
+		gen, err := generator.NewSigningTransactionGenerator(cdc, cfg, chainCfg, zaptest.NewLogger(t), nil, agent,
+			grants, generator.StaticGasPrice(txCfg.MinGasPrice), nil)
+		require.NoError(t, err)
+		return gen
+	}
+
+	// generator of a chain that creates and updates markets
+	newCreateUpdateGenerator := func(agent *signingAgent, mmClient marketmap.Client) generator.TransactionGenerator {
+		chainCfg := chainCfg
+		chainCfg.MessageMode = config.MessageModeCreateUpdate
+		gen, err := generator.NewSigningTransactionGenerator(cdc, config.DispatchConfig{TxConfig: txCfg}, chainCfg,
+			zaptest.NewLogger(t), nil, agent, nil, generator.StaticGasPrice(txCfg.MinGasPrice), mmClient)
+		require.NoError(t, err)
+		return gen
+	}
+
+	protoTx := func(txb client.TxBuilder) *txtypes.Tx {
+		return txb.(interface{ GetProtoTx() *txtypes.Tx }).GetProtoTx()
+	}
+
+	t.Run("sign with the planned gas and fees", func(t *testing.T) {
+		agent := &signingAgent{}
+		gen := newGenerator(config.DispatchConfig{TxConfig: txCfg}, agent, nil)
+
+		txs, err := gen.GenerateTransactionsFromPlan(context.Background(), plan)
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+
+		for i, txb := range agent.signed {
+			require.Equal(t, plan.Batches[i].Gas, txb.GetTx().GetGas())
+			require.Equal(t, plan.Batches[i].Fee, txb.GetTx().GetFee())
+			require.Empty(t, protoTx(txb).AuthInfo.Fee.Granter)
+			require.Empty(t, protoTx(txb).AuthInfo.Fee.Payer)
+
+			upsert := txb.GetTx().GetMsgs()[0].(*mmtypes.MsgUpsertMarkets)
+			require.Equal(t, signerAddress, upsert.Authority)
+		}
+	})
+
+	t.Run("set the fee granter with the chain prefix", func(t *testing.T) {
+		cfg := config.DispatchConfig{TxConfig: txCfg}
+		cfg.TxConfig.FeeGranter = otherAddress
+
+		agent := &signingAgent{}
+		_, err := newGenerator(cfg, agent, nil).GenerateTransactionsFromPlan(context.Background(), plan)
+		require.NoError(t, err)
+
+		for i, txb := range agent.signed {
+			require.Equal(t, otherAddress, protoTx(txb).AuthInfo.Fee.Granter)
+			require.Empty(t, protoTx(txb).AuthInfo.Fee.Payer)
+			require.Equal(t, plan.Batches[i].Fee, txb.GetTx().GetFee())
+		}
+	})
+
+	t.Run("fail before signing if the total fee exceeds the cap", func(t *testing.T) {
+		cfg := config.DispatchConfig{TxConfig: txCfg}
+		cfg.TxConfig.MaxTotalFee = sdk.NewCoins(sdk.NewInt64Coin("utoken", 249))
+
+		agent := &signingAgent{}
+		_, err := newGenerator(cfg, agent, nil).GenerateTransactionsFromPlan(context.Background(), plan)
+		require.ErrorContains(t, err, "exceeds max total fee")
+		require.Empty(t, agent.signed)
+
+		cfg.TxConfig.MaxTotalFee = sdk.NewCoins(sdk.NewInt64Coin("utoken", 250))
+		_, err = newGenerator(cfg, agent, nil).GenerateTransactionsFromPlan(context.Background(), plan)
+		require.NoError(t, err)
+	})
+
+	t.Run("wrap upserts of the granter in a MsgExec", func(t *testing.T) {
+		cfg := config.DispatchConfig{TxConfig: txCfg, AuthzConfig: &config.AuthzConfig{Granter: otherAddress}}
+
+		agent := &signingAgent{}
+		grants := &grantQuerier{grants: []*authz.Grant{{}}}
+		_, err := newGenerator(cfg, agent, grants).GenerateTransactionsFromPlan(context.Background(), plan)
+		require.NoError(t, err)
+		require.Equal(t, otherAddress, grants.req.Granter)
+		require.Equal(t, signerAddress, grants.req.Grantee)
+
+		for _, txb := range agent.signed {
+			exec := txb.GetTx().GetMsgs()[0].(*authz.MsgExec)
+			require.Equal(t, signerAddress, exec.Grantee)
+
+			msgs, err := exec.GetMessages()
+			require.NoError(t, err)
+			require.Equal(t, otherAddress, msgs[0].(*mmtypes.MsgUpsertMarkets).Authority)
+		}
+	})
+
+	t.Run("create markets that are not on chain and update the others", func(t *testing.T) {
+		agent := &signingAgent{}
+		mmClient := &marketMapClient{marketMap: mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+			"BTC/USD": markets.New("BTC", okx("BTC", nil)),
+		}}}
+
+		_, err := newCreateUpdateGenerator(agent, mmClient).GenerateTransactionsFromPlan(context.Background(), plan)
+		require.NoError(t, err)
+		require.Len(t, agent.signed, 2)
+
+		update := agent.signed[0].GetTx().GetMsgs()[0].(*mmtypes.MsgUpdateMarkets)
+		require.Equal(t, signerAddress, update.Authority)
+		require.Equal(t, []mmtypes.Market{markets.New("BTC", okx("BTC", nil))}, update.UpdateMarkets)
+
+		create := agent.signed[1].GetTx().GetMsgs()[0].(*mmtypes.MsgCreateMarkets)
+		require.Equal(t, signerAddress, create.Authority)
+		require.Equal(t, []mmtypes.Market{markets.New("ETH", okx("ETH", nil))}, create.CreateMarkets)
+	})
+
+	t.Run("reject msgs of another message mode", func(t *testing.T) {
+		gen := newCreateUpdateGenerator(&signingAgent{}, &marketMapClient{})
+
+		_, err := gen.GenerateTransactions(context.Background(), []sdk.Msg{&mmtypes.MsgUpsertMarkets{}})
+		require.ErrorContains(t, err, "unexpected msg /connect.marketmap.v2.MsgUpsertMarkets")
+	})
+
+	t.Run("fail to create and update markets without a market map client", func(t *testing.T) {
+		_, err := newCreateUpdateGenerator(&signingAgent{}, nil).GenerateTransactionsFromPlan(context.Background(), plan)
+		require.ErrorContains(t, err, "requires a market map client")
+	})
+
+	t.Run("fail without a grant", func(t *testing.T) {
+		cfg := config.DispatchConfig{TxConfig: txCfg, AuthzConfig: &config.AuthzConfig{Granter: otherAddress}}
+
+		agent := &signingAgent{}
+		_, err := newGenerator(cfg, agent, &grantQuerier{}).GenerateTransactionsFromPlan(context.Background(), plan)
+		require.ErrorContains(t, err, "has not granted")
+		require.Empty(t, agent.signed)
+	})
+}
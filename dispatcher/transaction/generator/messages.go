@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 	slinkymmtypes "github.com/skip-mev/slinky/x/marketmap/types"
 	"go.uber.org/zap"
@@ -13,17 +14,138 @@ import (
 )
 
 // ConvertUpsertsToMessages converts a set of upsert markets to a slice to sdk.Messages respecting the configured
-// max size of a transaction.
+// max size of a transaction. Each group of markets that fits in a transaction is converted with the MsgBuilder, so
+// it may become a create and an update msg.
 func ConvertUpsertsToMessages(
 	logger *zap.Logger,
 	cfg config.TransactionConfig,
-	version config.Version,
+	builder MsgBuilder,
 	upserts []mmtypes.Market,
 ) ([]sdk.Msg, error) {
-	msgs := make([]sdk.Msg, 0)
+	groups, err := packBySize(logger, cfg, upserts)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]sdk.Msg, 0, len(groups))
+	for _, txMarkets := range groups {
+		logger.Info("creating update msg", zap.Int("markets", len(txMarkets)))
+
+		// TODO can we do something better than provide a nil authority and overwrite later?
+		groupMsgs, err := builder.Msgs("", txMarkets)
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, groupMsgs...)
+	}
+
+	return msgs, nil
+}
+
+// MsgBuilder builds the marketmap msgs of a chain's version and message mode for a set of upserts.
+type MsgBuilder struct {
+	version config.Version
+	mode    config.MessageMode
+
+	// onChain are the tickers of the markets on chain, which are updated rather than created.
+	onChain map[string]struct{}
+}
+
+// NewMsgBuilder creates a MsgBuilder for the version and message mode. The on-chain market map is only used by
+// MessageModeCreateUpdate, to split the upserts into creates and updates.
+func NewMsgBuilder(version config.Version, mode config.MessageMode, onChain mmtypes.MarketMap) MsgBuilder {
+	tickers := make(map[string]struct{}, len(onChain.Markets))
+	for ticker := range onChain.Markets {
+		tickers[ticker] = struct{}{}
+	}
+
+	return MsgBuilder{
+		version: version,
+		mode:    mode,
+		onChain: tickers,
+	}
+}
+
+// Msgs returns the msgs, signed by the authority, that upsert the markets. In MessageModeCreateUpdate, the markets
+// that are not on chain are created by a first msg and the others are updated by a second msg, so that updated markets
+// can be normalized by created ones. Either msg is omitted if it has no markets.
+func (b MsgBuilder) Msgs(authority string, markets []mmtypes.Market) ([]sdk.Msg, error) {
+	switch b.mode {
+	case "", config.MessageModeUpsert:
+		msg, err := newUpsertMsg(b.version, authority, markets)
+		if err != nil {
+			return nil, err
+		}
+		return []sdk.Msg{msg}, nil
+	case config.MessageModeCreateUpdate:
+		creates := make([]mmtypes.Market, 0)
+		updates := make([]mmtypes.Market, 0)
+		for _, market := range markets {
+			if _, found := b.onChain[market.Ticker.String()]; found {
+				updates = append(updates, market)
+			} else {
+				creates = append(creates, market)
+			}
+		}
+
+		msgs := make([]sdk.Msg, 0, 2)
+		if len(creates) > 0 {
+			msg, err := newCreateMsg(b.version, authority, creates)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, msg)
+		}
+		if len(updates) > 0 {
+			msg, err := newUpdateMsg(b.version, authority, updates)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, msg)
+		}
+		return msgs, nil
+	default:
+		return nil, fmt.Errorf("unsupported message mode %s", b.mode)
+	}
+}
+
+// MsgTypeURLs returns the type URLs of all msgs the MsgBuilder can build.
+func (b MsgBuilder) MsgTypeURLs() ([]string, error) {
+	var msgs []sdk.Msg
+	switch b.mode {
+	case "", config.MessageModeUpsert:
+		msg, err := newUpsertMsg(b.version, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		msgs = []sdk.Msg{msg}
+	case config.MessageModeCreateUpdate:
+		create, err := newCreateMsg(b.version, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		update, err := newUpdateMsg(b.version, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		msgs = []sdk.Msg{create, update}
+	default:
+		return nil, fmt.Errorf("unsupported message mode %s", b.mode)
+	}
+
+	typeURLs := make([]string, len(msgs))
+	for i, msg := range msgs {
+		typeURLs[i] = sdk.MsgTypeURL(msg)
+	}
+	return typeURLs, nil
+}
+
+// packBySize groups the upserts, in order, such that the size of all markets per group is optimized, while not
+// exceeding the max tx size.
+func packBySize(logger *zap.Logger, cfg config.TransactionConfig, upserts []mmtypes.Market) ([][]mmtypes.Market, error) {
+	groups := make([][]mmtypes.Market, 0)
 
-	// create the update txs, such that the size of all markets per tx is optimized, while
-	// not exceeding the max tx size
 	currentTxSize := 0
 	start := 0
 	for i, market := range upserts {
@@ -41,28 +163,9 @@ func ConvertUpsertsToMessages(
 			return nil, fmt.Errorf("market size exceeds max tx size: %d > %d", market.Size(), cfg.MaxBytesPerTx)
 		}
 
-		// update the currentTxSize
+		// start a new group if the market does not fit in the current one
 		if currentTxSize+market.Size() > cfg.MaxBytesPerTx {
-			// create the tx
-			txMarkets := upserts[start:i]
-			logger.Info("creating update msg", zap.Int("markets", len(txMarkets)))
-
-			// TODO can we do something better than provide a nil authority and overwrite later?
-			var msg sdk.Msg
-			switch version {
-			case config.VersionSlinky:
-				msg = &slinkymmtypes.MsgUpsertMarkets{
-					Markets: marketmap.ConnectToSlinkyMarkets(upserts),
-				}
-			case config.VersionConnect:
-				msg = &mmtypes.MsgUpsertMarkets{
-					Markets: upserts,
-				}
-			default:
-				return nil, fmt.Errorf("unsupported version %s", version)
-			}
-
-			msgs = append(msgs, msg)
+			groups = append(groups, upserts[start:i])
 
 			// reset the currentTxSize
 			currentTxSize = 0
@@ -73,24 +176,121 @@ func ConvertUpsertsToMessages(
 		currentTxSize += market.Size()
 	}
 
-	// create the last tx
+	// create the last group
 	if currentTxSize > 0 {
-		var msg sdk.Msg
-		switch version {
-		case config.VersionSlinky:
-			msg = &slinkymmtypes.MsgUpsertMarkets{
-				Markets: marketmap.ConnectToSlinkyMarkets(upserts[start:]),
-			}
-		case config.VersionConnect:
-			msg = &mmtypes.MsgUpsertMarkets{
-				Markets: upserts[start:],
-			}
-		default:
-			return nil, fmt.Errorf("unsupported version %s", version)
-		}
+		groups = append(groups, upserts[start:])
+	}
+
+	return groups, nil
+}
 
-		msgs = append(msgs, msg)
+// newUpsertMsg creates the MsgUpsertMarkets of the version for the markets, signed by the authority.
+func newUpsertMsg(version config.Version, authority string, markets []mmtypes.Market) (sdk.Msg, error) {
+	switch version {
+	case config.VersionSlinky:
+		return &slinkymmtypes.MsgUpsertMarkets{
+			Authority: authority,
+			Markets:   marketmap.ConnectToSlinkyMarkets(markets),
+		}, nil
+	case config.VersionConnect:
+		return &mmtypes.MsgUpsertMarkets{
+			Authority: authority,
+			Markets:   markets,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported version %s", version)
 	}
+}
 
-	return msgs, nil
+// newCreateMsg creates the MsgCreateMarkets of the version for the markets, signed by the authority.
+func newCreateMsg(version config.Version, authority string, markets []mmtypes.Market) (sdk.Msg, error) {
+	switch version {
+	case config.VersionSlinky:
+		return &slinkymmtypes.MsgCreateMarkets{
+			Authority:     authority,
+			CreateMarkets: marketmap.ConnectToSlinkyMarkets(markets),
+		}, nil
+	case config.VersionConnect:
+		return &mmtypes.MsgCreateMarkets{
+			Authority:     authority,
+			CreateMarkets: markets,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported version %s", version)
+	}
+}
+
+// newUpdateMsg creates the MsgUpdateMarkets of the version for the markets, signed by the authority.
+func newUpdateMsg(version config.Version, authority string, markets []mmtypes.Market) (sdk.Msg, error) {
+	switch version {
+	case config.VersionSlinky:
+		return &slinkymmtypes.MsgUpdateMarkets{
+			Authority:     authority,
+			UpdateMarkets: marketmap.ConnectToSlinkyMarkets(markets),
+		}, nil
+	case config.VersionConnect:
+		return &mmtypes.MsgUpdateMarkets{
+			Authority:     authority,
+			UpdateMarkets: markets,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported version %s", version)
+	}
+}
+
+// setAuthority sets the authority of a marketmap msg built by a MsgBuilder.
+func setAuthority(msg sdk.Msg, authority string) error {
+	switch msg := msg.(type) {
+	case *mmtypes.MsgUpsertMarkets:
+		msg.Authority = authority
+	case *mmtypes.MsgCreateMarkets:
+		msg.Authority = authority
+	case *mmtypes.MsgUpdateMarkets:
+		msg.Authority = authority
+	case *slinkymmtypes.MsgUpsertMarkets:
+		msg.Authority = authority
+	case *slinkymmtypes.MsgCreateMarkets:
+		msg.Authority = authority
+	case *slinkymmtypes.MsgUpdateMarkets:
+		msg.Authority = authority
+	default:
+		return fmt.Errorf("unsupported marketmap msg %T", msg)
+	}
+	return nil
+}
+
+// MsgMarkets returns the markets created, updated or upserted by a marketmap msg of any version. The msgs executed by
+// an authz MsgExec are unwrapped.
+func MsgMarkets(msg sdk.Msg) ([]mmtypes.Market, error) {
+	switch msg := msg.(type) {
+	case *mmtypes.MsgUpsertMarkets:
+		return msg.Markets, nil
+	case *mmtypes.MsgCreateMarkets:
+		return msg.CreateMarkets, nil
+	case *mmtypes.MsgUpdateMarkets:
+		return msg.UpdateMarkets, nil
+	case *slinkymmtypes.MsgUpsertMarkets:
+		return marketmap.SlinkyToConnectMarkets(msg.Markets), nil
+	case *slinkymmtypes.MsgCreateMarkets:
+		return marketmap.SlinkyToConnectMarkets(msg.CreateMarkets), nil
+	case *slinkymmtypes.MsgUpdateMarkets:
+		return marketmap.SlinkyToConnectMarkets(msg.UpdateMarkets), nil
+	case *authz.MsgExec:
+		execMsgs, err := msg.GetMessages()
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack msgs of MsgExec: %w", err)
+		}
+
+		markets := make([]mmtypes.Market, 0)
+		for _, execMsg := range execMsgs {
+			execMarkets, err := MsgMarkets(execMsg)
+			if err != nil {
+				return nil, err
+			}
+			markets = append(markets, execMarkets...)
+		}
+		return markets, nil
+	default:
+		return nil, fmt.Errorf("unsupported marketmap msg %T", msg)
+	}
 }
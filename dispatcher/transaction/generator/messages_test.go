@@ -5,9 +5,11 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	slinkymmtypes "github.com/skip-mev/slinky/x/marketmap/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
+	"github.com/skip-mev/connect-mmu/client/marketmap"
 	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/dispatcher/transaction/generator"
 	"github.com/skip-mev/connect-mmu/testutil/markets"
@@ -17,6 +19,8 @@ func TestConvertUpsertsToMessages(t *testing.T) {
 	tests := []struct {
 		name    string
 		cfg     config.TransactionConfig
+		mode    config.MessageMode
+		onChain mmtypes.MarketMap
 		upserts []mmtypes.Market
 		want    []sdk.Msg
 		wantErr bool
@@ -39,10 +43,43 @@ func TestConvertUpsertsToMessages(t *testing.T) {
 			want:    make([]sdk.Msg, 0),
 			wantErr: true,
 		},
+		{
+			name: "each msg only contains the markets of its tx",
+			cfg: config.TransactionConfig{
+				MaxBytesPerTx: markets.UsdtUsd.Size(),
+			},
+			upserts: []mmtypes.Market{
+				markets.UsdtUsd,
+				markets.UsdtUsd,
+			},
+			want: []sdk.Msg{
+				&mmtypes.MsgUpsertMarkets{Markets: []mmtypes.Market{markets.UsdtUsd}},
+				&mmtypes.MsgUpsertMarkets{Markets: []mmtypes.Market{markets.UsdtUsd}},
+			},
+		},
+		{
+			name: "split the markets of a tx into creates and updates",
+			cfg: config.TransactionConfig{
+				MaxBytesPerTx: 2000,
+			},
+			mode: config.MessageModeCreateUpdate,
+			onChain: mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+				markets.UsdtUsd.Ticker.String(): markets.UsdtUsd,
+			}},
+			upserts: []mmtypes.Market{
+				markets.UsdtUsd,
+				markets.New("BTC", okx("BTC", nil)),
+			},
+			want: []sdk.Msg{
+				&mmtypes.MsgCreateMarkets{CreateMarkets: []mmtypes.Market{markets.New("BTC", okx("BTC", nil))}},
+				&mmtypes.MsgUpdateMarkets{UpdateMarkets: []mmtypes.Market{markets.UsdtUsd}},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := generator.ConvertUpsertsToMessages(zaptest.NewLogger(t), tt.cfg, config.VersionConnect, tt.upserts)
+			builder := generator.NewMsgBuilder(config.VersionConnect, tt.mode, tt.onChain)
+			got, err := generator.ConvertUpsertsToMessages(zaptest.NewLogger(t), tt.cfg, builder, tt.upserts)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -52,3 +89,112 @@ func TestConvertUpsertsToMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestMsgBuilder(t *testing.T) {
+	onChain := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+		markets.UsdtUsd.Ticker.String(): markets.UsdtUsd,
+	}}
+	btc := markets.New("BTC", okx("BTC", nil))
+	upserts := []mmtypes.Market{markets.UsdtUsd, btc}
+
+	tests := []struct {
+		name         string
+		version      config.Version
+		mode         config.MessageMode
+		want         []sdk.Msg
+		wantTypeURLs []string
+		wantErr      bool
+	}{
+		{
+			name:    "connect upsert",
+			version: config.VersionConnect,
+			mode:    config.MessageModeUpsert,
+			want: []sdk.Msg{
+				&mmtypes.MsgUpsertMarkets{Authority: "authority", Markets: upserts},
+			},
+			wantTypeURLs: []string{"/connect.marketmap.v2.MsgUpsertMarkets"},
+		},
+		{
+			name:    "connect upsert by default",
+			version: config.VersionConnect,
+			want: []sdk.Msg{
+				&mmtypes.MsgUpsertMarkets{Authority: "authority", Markets: upserts},
+			},
+			wantTypeURLs: []string{"/connect.marketmap.v2.MsgUpsertMarkets"},
+		},
+		{
+			name:    "connect create and update",
+			version: config.VersionConnect,
+			mode:    config.MessageModeCreateUpdate,
+			want: []sdk.Msg{
+				&mmtypes.MsgCreateMarkets{Authority: "authority", CreateMarkets: []mmtypes.Market{btc}},
+				&mmtypes.MsgUpdateMarkets{Authority: "authority", UpdateMarkets: []mmtypes.Market{markets.UsdtUsd}},
+			},
+			wantTypeURLs: []string{"/connect.marketmap.v2.MsgCreateMarkets", "/connect.marketmap.v2.MsgUpdateMarkets"},
+		},
+		{
+			name:    "slinky upsert",
+			version: config.VersionSlinky,
+			mode:    config.MessageModeUpsert,
+			want: []sdk.Msg{
+				&slinkymmtypes.MsgUpsertMarkets{Authority: "authority", Markets: marketmap.ConnectToSlinkyMarkets(upserts)},
+			},
+			wantTypeURLs: []string{"/slinky.marketmap.v1.MsgUpsertMarkets"},
+		},
+		{
+			name:    "slinky create and update",
+			version: config.VersionSlinky,
+			mode:    config.MessageModeCreateUpdate,
+			want: []sdk.Msg{
+				&slinkymmtypes.MsgCreateMarkets{
+					Authority:     "authority",
+					CreateMarkets: marketmap.ConnectToSlinkyMarkets([]mmtypes.Market{btc}),
+				},
+				&slinkymmtypes.MsgUpdateMarkets{
+					Authority:     "authority",
+					UpdateMarkets: marketmap.ConnectToSlinkyMarkets([]mmtypes.Market{markets.UsdtUsd}),
+				},
+			},
+			wantTypeURLs: []string{"/slinky.marketmap.v1.MsgCreateMarkets", "/slinky.marketmap.v1.MsgUpdateMarkets"},
+		},
+		{
+			name:    "unsupported version",
+			version: "invalid",
+			mode:    config.MessageModeUpsert,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported message mode",
+			version: config.VersionConnect,
+			mode:    "invalid",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := generator.NewMsgBuilder(tt.version, tt.mode, onChain)
+
+			got, err := builder.Msgs("authority", upserts)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			typeURLs, err := builder.MsgTypeURLs()
+			require.NoError(t, err)
+			require.Equal(t, tt.wantTypeURLs, typeURLs)
+		})
+	}
+
+	t.Run("omit the create msg if all markets are on chain", func(t *testing.T) {
+		builder := generator.NewMsgBuilder(config.VersionConnect, config.MessageModeCreateUpdate, onChain)
+
+		got, err := builder.Msgs("authority", []mmtypes.Market{markets.UsdtUsd})
+		require.NoError(t, err)
+		require.Equal(t, []sdk.Msg{
+			&mmtypes.MsgUpdateMarkets{Authority: "authority", UpdateMarkets: []mmtypes.Market{markets.UsdtUsd}},
+		}, got)
+	})
+}
@@ -0,0 +1,119 @@
+package submitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
+)
+
+var _ CometJSONRPCClient = &FailoverClient{}
+
+// FailoverClient is a CometJSONRPCClient that rotates across a list of RPC endpoints. Requests are sent to the
+// current endpoint, and retried on the next endpoints, in order, if it fails. Broadcasts are only retried if the
+// transaction was never sent to the failed endpoint, so that a transaction is not broadcast twice.
+type FailoverClient struct {
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	clients []CometJSONRPCClient
+	current int
+}
+
+// NewFailoverClient creates a FailoverClient over the clients of each RPC endpoint, starting with the first.
+func NewFailoverClient(logger *zap.Logger, clients ...CometJSONRPCClient) (*FailoverClient, error) {
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("at least one rpc client is required")
+	}
+
+	return &FailoverClient{
+		logger:  logger,
+		clients: clients,
+	}, nil
+}
+
+// txInCacheError is the error returned by the mempool of an endpoint that already received the transaction.
+const txInCacheError = "tx already exists in cache"
+
+// BroadcastTxSync broadcasts the transaction using the first available endpoint. The broadcast is only retried on the
+// next endpoint if the transaction could not be sent to the failed one, ex. if the connection was refused. Any other
+// error, such as a timeout, is returned, since the endpoint may have accepted the transaction. An endpoint that
+// already has the transaction in its mempool is treated as a successful broadcast.
+func (c *FailoverClient) BroadcastTxSync(ctx context.Context, tx cmttypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	var res *ctypes.ResultBroadcastTx
+	err := c.do(func(client CometJSONRPCClient) error {
+		var err error
+		res, err = client.BroadcastTxSync(ctx, tx)
+		if err != nil && strings.Contains(err.Error(), txInCacheError) {
+			c.logger.Info("transaction is already in the mempool of the rpc endpoint", zap.Error(err))
+			res, err = &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+		}
+		return err
+	}, notSent)
+	return res, err
+}
+
+// Tx queries the transaction by hash using the first available endpoint. A transaction that is not found is not
+// retried on other endpoints.
+func (c *FailoverClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	var res *ctypes.ResultTx
+	var notFound error
+	err := c.do(func(client CometJSONRPCClient) error {
+		var err error
+		res, err = client.Tx(ctx, hash, prove)
+		if err != nil && strings.Contains(err.Error(), "not found") {
+			notFound = err
+			return nil
+		}
+		return err
+	}, func(error) bool { return true })
+	if notFound != nil {
+		return nil, notFound
+	}
+	return res, err
+}
+
+// do calls fn with the client of each endpoint, starting with the current one, until it succeeds or fails with an
+// error that cannot be retried. The endpoint that succeeded becomes the current one.
+func (c *FailoverClient) do(fn func(client CometJSONRPCClient) error, retryable func(err error) bool) error {
+	c.mu.Lock()
+	start := c.current
+	c.mu.Unlock()
+
+	var errs []error
+	for i := range c.clients {
+		idx := (start + i) % len(c.clients)
+		err := fn(c.clients[idx])
+		if err == nil {
+			if idx != start {
+				c.mu.Lock()
+				c.current = idx
+				c.mu.Unlock()
+				c.logger.Info("failed over to rpc endpoint", zap.Int("endpoint", idx))
+			}
+			return nil
+		}
+
+		c.logger.Warn("rpc endpoint request failed", zap.Int("endpoint", idx), zap.Error(err))
+		errs = append(errs, fmt.Errorf("endpoint %d: %w", idx, err))
+
+		if !retryable(err) {
+			return fmt.Errorf("rpc endpoint request failed and cannot be retried: %w", errors.Join(errs...))
+		}
+	}
+
+	return fmt.Errorf("all %d rpc endpoints failed: %w", len(c.clients), errors.Join(errs...))
+}
+
+// notSent returns true if the request failed before it was sent to the endpoint, because no connection to it could be
+// established.
+func notSent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,116 @@
+package submitter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	rpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/skip-mev/connect-mmu/dispatcher/transaction/submitter"
+	"github.com/skip-mev/connect-mmu/dispatcher/transaction/submitter/mocks"
+)
+
+func TestFailoverClient(t *testing.T) {
+	_, err := submitter.NewFailoverClient(zaptest.NewLogger(t))
+	require.Error(t, err)
+
+	// refused is the error of a request that could not be sent to the endpoint
+	refused := fmt.Errorf("post failed: %w", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+
+	t.Run("fail over to the next endpoint and stick to it", func(t *testing.T) {
+		first := mocks.NewCometJSONRPCClient(t)
+		second := mocks.NewCometJSONRPCClient(t)
+		client, err := submitter.NewFailoverClient(zaptest.NewLogger(t), first, second)
+		require.NoError(t, err)
+
+		tx := cmttypes.Tx("tx")
+		first.On("BroadcastTxSync", mock.Anything, tx).Return(nil, refused).Once()
+		second.On("BroadcastTxSync", mock.Anything, tx).Return(&rpctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil).Twice()
+
+		for range 2 {
+			res, err := client.BroadcastTxSync(context.Background(), tx)
+			require.NoError(t, err)
+			require.Equal(t, tx.Hash(), []byte(res.Hash))
+		}
+	})
+
+	t.Run("fail if all endpoints fail", func(t *testing.T) {
+		first := mocks.NewCometJSONRPCClient(t)
+		second := mocks.NewCometJSONRPCClient(t)
+		client, err := submitter.NewFailoverClient(zaptest.NewLogger(t), first, second)
+		require.NoError(t, err)
+
+		tx := cmttypes.Tx("tx")
+		first.On("BroadcastTxSync", mock.Anything, tx).Return(nil, refused).Once()
+		second.On("BroadcastTxSync", mock.Anything, tx).Return(nil, refused).Once()
+
+		_, err = client.BroadcastTxSync(context.Background(), tx)
+		require.ErrorContains(t, err, "endpoint 0")
+		require.ErrorContains(t, err, "endpoint 1")
+		require.ErrorContains(t, err, "all 2 rpc endpoints failed")
+	})
+
+	t.Run("do not fail over broadcasts that may have been accepted", func(t *testing.T) {
+		first := mocks.NewCometJSONRPCClient(t)
+		second := mocks.NewCometJSONRPCClient(t)
+		client, err := submitter.NewFailoverClient(zaptest.NewLogger(t), first, second)
+		require.NoError(t, err)
+
+		tx := cmttypes.Tx("tx")
+		first.On("BroadcastTxSync", mock.Anything, tx).Return(nil, context.DeadlineExceeded).Once()
+
+		_, err = client.BroadcastTxSync(context.Background(), tx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "cannot be retried")
+	})
+
+	t.Run("treat transactions already in the mempool as broadcast", func(t *testing.T) {
+		first := mocks.NewCometJSONRPCClient(t)
+		client, err := submitter.NewFailoverClient(zaptest.NewLogger(t), first)
+		require.NoError(t, err)
+
+		tx := cmttypes.Tx("tx")
+		first.On("BroadcastTxSync", mock.Anything, tx).
+			Return(nil, errors.New("error on broadcastTxSync: tx already exists in cache")).Once()
+
+		res, err := client.BroadcastTxSync(context.Background(), tx)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), res.Code)
+		require.Equal(t, tx.Hash(), []byte(res.Hash))
+	})
+
+	t.Run("fail over queries on any error", func(t *testing.T) {
+		first := mocks.NewCometJSONRPCClient(t)
+		second := mocks.NewCometJSONRPCClient(t)
+		client, err := submitter.NewFailoverClient(zaptest.NewLogger(t), first, second)
+		require.NoError(t, err)
+
+		hash := cmttypes.Tx("tx").Hash()
+		first.On("Tx", mock.Anything, hash, false).Return(nil, context.DeadlineExceeded).Once()
+		second.On("Tx", mock.Anything, hash, false).Return(&rpctypes.ResultTx{Hash: hash}, nil).Once()
+
+		res, err := client.Tx(context.Background(), hash, false)
+		require.NoError(t, err)
+		require.Equal(t, hash, []byte(res.Hash))
+	})
+
+	t.Run("do not fail over transactions that are not found", func(t *testing.T) {
+		first := mocks.NewCometJSONRPCClient(t)
+		second := mocks.NewCometJSONRPCClient(t)
+		client, err := submitter.NewFailoverClient(zaptest.NewLogger(t), first, second)
+		require.NoError(t, err)
+
+		hash := cmttypes.Tx("tx").Hash()
+		first.On("Tx", mock.Anything, hash, false).Return(nil, fmt.Errorf("tx (%X) not found", hash)).Once()
+
+		_, err = client.Tx(context.Background(), hash, false)
+		require.ErrorContains(t, err, "not found")
+	})
+}
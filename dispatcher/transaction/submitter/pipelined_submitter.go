@@ -0,0 +1,196 @@
+package submitter
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	cometabci "github.com/cometbft/cometbft/abci/types"
+	ctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+// ErrSequenceMismatch is returned when a transaction fails check-tx because its sequence does not match the
+// sequence of the signing account.
+var ErrSequenceMismatch = errors.New("account sequence mismatch")
+
+// txEventsQuery is the query of the events of all committed transactions.
+const txEventsQuery = "tm.event='Tx'"
+
+// subscriber is the name of the subscriber of the transaction events.
+const subscriber = "mmu-dispatcher"
+
+// CometEventClient is the interface expected to be fulfilled by a comet websocket client.
+type CometEventClient interface {
+	// Subscribe subscribes to the events matching the query.
+	Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (<-chan ctypes.ResultEvent, error)
+
+	// UnsubscribeAll unsubscribes the subscriber from all events.
+	UnsubscribeAll(ctx context.Context, subscriber string) error
+}
+
+// PipelinedSubmitter broadcasts transactions of consecutive sequences back to back, without waiting for the
+// inclusion of each transaction, and waits for their inclusion afterwards.
+type PipelinedSubmitter struct {
+	client CometJSONRPCClient
+	// events is used to track inclusion if set. Inclusion is polled otherwise, and in case events are missed.
+	events CometEventClient
+	logger *zap.Logger
+	cfg    config.SubmitterConfig
+}
+
+// NewPipelinedSubmitter creates a new PipelinedSubmitter. events may be nil, in which case inclusion is only polled.
+func NewPipelinedSubmitter(
+	client CometJSONRPCClient,
+	events CometEventClient,
+	cfg config.SubmitterConfig,
+	logger *zap.Logger,
+) *PipelinedSubmitter {
+	return &PipelinedSubmitter{
+		client: client,
+		events: events,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// Broadcast broadcasts the transactions in order and returns the hashes of the transactions that passed check-tx.
+// It stops at the first transaction that fails. If it failed because of its sequence, the error wraps
+// ErrSequenceMismatch.
+func (ps *PipelinedSubmitter) Broadcast(ctx context.Context, txs []cmttypes.Tx) ([][]byte, error) {
+	hashes := make([][]byte, 0, len(txs))
+	for i, tx := range txs {
+		ps.logger.Info("broadcasting tx", zap.Int("index", i), zap.String("tx", hex.EncodeToString(tx.Hash())))
+
+		broadcastCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+		res, err := ps.client.BroadcastTxSync(broadcastCtx, tx)
+		cancel()
+		if err != nil {
+			ps.logger.Error("failed to broadcast transaction", zap.Int("index", i), zap.Error(err))
+			return hashes, fmt.Errorf("failed to broadcast transaction %d: %w", i, err)
+		}
+
+		if res.Code != cometabci.CodeTypeOK {
+			ps.logger.Error("transaction check-tx failed", zap.Int("index", i), zap.Uint32("code", res.Code),
+				zap.String("codespace", res.Codespace), zap.String("log", res.Log))
+			if res.Codespace == sdkerrors.ErrWrongSequence.Codespace() && res.Code == sdkerrors.ErrWrongSequence.ABCICode() {
+				return hashes, fmt.Errorf("transaction %d check-tx failed: %w: %s", i, ErrSequenceMismatch, res.Log)
+			}
+			return hashes, fmt.Errorf("transaction %d check-tx failed with code %d", i, res.Code)
+		}
+
+		hashes = append(hashes, res.Hash)
+	}
+
+	return hashes, nil
+}
+
+// WaitForInclusion waits until all transactions are included in a block, and fails if any of them failed. Inclusion
+// is tracked using transaction events if available, and polled every PollingFrequency in case events are missed
+// or unavailable, for at most PollingDuration.
+func (ps *PipelinedSubmitter) WaitForInclusion(ctx context.Context, hashes [][]byte) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	pending := make(map[string][]byte, len(hashes))
+	for _, hash := range hashes {
+		pending[hex.EncodeToString(hash)] = hash
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ps.cfg.PollingDuration)
+	defer cancel()
+
+	events := ps.subscribe(ctx)
+
+	ticker := time.NewTicker(ps.cfg.PollingFrequency)
+	defer ticker.Stop()
+
+	ps.logger.Info("waiting for transaction inclusion", zap.Int("transactions", len(pending)),
+		zap.Bool("events", events != nil), zap.Duration("interval", ps.cfg.PollingFrequency),
+		zap.Duration("polling time", ps.cfg.PollingDuration))
+
+	for len(pending) > 0 {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				ps.logger.Warn("transaction event subscription closed, falling back to polling")
+				events = nil
+				continue
+			}
+
+			data, ok := event.Data.(cmttypes.EventDataTx)
+			if !ok {
+				continue
+			}
+
+			hash := hex.EncodeToString(cmttypes.Tx(data.Tx).Hash())
+			if _, found := pending[hash]; !found {
+				continue
+			}
+			if err := ps.checkResult(hash, data.Result.Code, data.Result.Log); err != nil {
+				return err
+			}
+			delete(pending, hash)
+
+		case <-ticker.C:
+			for key, hash := range pending {
+				result, err := ps.client.Tx(ctx, hash, false)
+				if err != nil {
+					continue
+				}
+				if err := ps.checkResult(key, result.TxResult.Code, result.TxResult.Log); err != nil {
+					return err
+				}
+				delete(pending, key)
+			}
+
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for inclusion of %d transactions", len(pending))
+		}
+	}
+
+	ps.logger.Info("all transactions included", zap.Int("transactions", len(hashes)))
+	return nil
+}
+
+// subscribe subscribes to transaction events until the context is done. A nil channel is returned if events are
+// unavailable.
+func (ps *PipelinedSubmitter) subscribe(ctx context.Context) <-chan ctypes.ResultEvent {
+	if ps.events == nil {
+		return nil
+	}
+
+	events, err := ps.events.Subscribe(ctx, subscriber, txEventsQuery)
+	if err != nil {
+		ps.logger.Warn("failed to subscribe to transaction events, falling back to polling", zap.Error(err))
+		return nil
+	}
+
+	go func() {
+		<-ctx.Done()
+		//nolint:contextcheck // the subscription must be removed after ctx is done
+		if err := ps.events.UnsubscribeAll(context.Background(), subscriber); err != nil {
+			ps.logger.Debug("failed to unsubscribe from transaction events", zap.Error(err))
+		}
+	}()
+
+	return events
+}
+
+func (ps *PipelinedSubmitter) checkResult(hash string, code uint32, log string) error {
+	if code != cometabci.CodeTypeOK {
+		ps.logger.Error("transaction tx result failed", zap.String("tx", hash), zap.Uint32("code", code),
+			zap.String("log", log))
+		return fmt.Errorf("transaction %s tx result failed with code: %d, log: %s", hash, code, log)
+	}
+
+	ps.logger.Debug("transaction was successful", zap.String("tx", hash))
+	return nil
+}
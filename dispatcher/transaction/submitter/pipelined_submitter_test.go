@@ -0,0 +1,121 @@
+package submitter_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cmtabci "github.com/cometbft/cometbft/abci/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/dispatcher/transaction/submitter"
+	"github.com/skip-mev/connect-mmu/dispatcher/transaction/submitter/mocks"
+)
+
+// eventClient is a CometEventClient that emits the given events.
+type eventClient struct {
+	events chan rpctypes.ResultEvent
+}
+
+func (c *eventClient) Subscribe(_ context.Context, _, _ string, _ ...int) (<-chan rpctypes.ResultEvent, error) {
+	return c.events, nil
+}
+
+func (c *eventClient) UnsubscribeAll(_ context.Context, _ string) error {
+	return nil
+}
+
+func TestPipelinedSubmitter(t *testing.T) {
+	cfg := config.SubmitterConfig{
+		PollingFrequency: 50 * time.Millisecond,
+		PollingDuration:  time.Second,
+	}
+	txs := []cmttypes.Tx{cmttypes.Tx("tx1"), cmttypes.Tx("tx2"), cmttypes.Tx("tx3")}
+
+	t.Run("broadcast stops at a sequence mismatch", func(t *testing.T) {
+		cli := mocks.NewCometJSONRPCClient(t)
+		s := submitter.NewPipelinedSubmitter(cli, nil, cfg, zaptest.NewLogger(t))
+
+		cli.On("BroadcastTxSync", mock.Anything, txs[0]).Return(&rpctypes.ResultBroadcastTx{Hash: txs[0].Hash()}, nil).Once()
+		cli.On("BroadcastTxSync", mock.Anything, txs[1]).Return(&rpctypes.ResultBroadcastTx{
+			Code:      sdkerrors.ErrWrongSequence.ABCICode(),
+			Codespace: sdkerrors.ErrWrongSequence.Codespace(),
+			Log:       "account sequence mismatch, expected 2, got 1",
+		}, nil).Once()
+
+		hashes, err := s.Broadcast(context.Background(), txs)
+		require.ErrorIs(t, err, submitter.ErrSequenceMismatch)
+		require.Len(t, hashes, 1)
+	})
+
+	t.Run("broadcast fails on other check-tx failures", func(t *testing.T) {
+		cli := mocks.NewCometJSONRPCClient(t)
+		s := submitter.NewPipelinedSubmitter(cli, nil, cfg, zaptest.NewLogger(t))
+
+		cli.On("BroadcastTxSync", mock.Anything, txs[0]).Return(&rpctypes.ResultBroadcastTx{Code: 5}, nil).Once()
+
+		hashes, err := s.Broadcast(context.Background(), txs)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, submitter.ErrSequenceMismatch)
+		require.Empty(t, hashes)
+	})
+
+	t.Run("poll inclusion", func(t *testing.T) {
+		cli := mocks.NewCometJSONRPCClient(t)
+		s := submitter.NewPipelinedSubmitter(cli, nil, cfg, zaptest.NewLogger(t))
+
+		hashes := [][]byte{txs[0].Hash(), txs[1].Hash()}
+		cli.On("Tx", mock.Anything, hashes[0], false).Return(&rpctypes.ResultTx{}, nil).Once()
+		cli.On("Tx", mock.Anything, hashes[1], false).Return(nil, fmt.Errorf("not found")).Once()
+		cli.On("Tx", mock.Anything, hashes[1], false).Return(&rpctypes.ResultTx{}, nil).Once()
+
+		require.NoError(t, s.WaitForInclusion(context.Background(), hashes))
+	})
+
+	t.Run("poll inclusion of a failed transaction", func(t *testing.T) {
+		cli := mocks.NewCometJSONRPCClient(t)
+		s := submitter.NewPipelinedSubmitter(cli, nil, cfg, zaptest.NewLogger(t))
+
+		hash := txs[0].Hash()
+		cli.On("Tx", mock.Anything, hash, false).Return(&rpctypes.ResultTx{
+			TxResult: cmtabci.ExecTxResult{Code: 1, Log: "invalid"},
+		}, nil).Once()
+
+		require.ErrorContains(t, s.WaitForInclusion(context.Background(), [][]byte{hash}), "invalid")
+	})
+
+	t.Run("time out waiting for inclusion", func(t *testing.T) {
+		cli := mocks.NewCometJSONRPCClient(t)
+		cfg := cfg
+		cfg.PollingDuration = 120 * time.Millisecond
+		s := submitter.NewPipelinedSubmitter(cli, nil, cfg, zaptest.NewLogger(t))
+
+		hash := txs[0].Hash()
+		cli.On("Tx", mock.Anything, hash, false).Return(nil, fmt.Errorf("not found"))
+
+		require.ErrorContains(t, s.WaitForInclusion(context.Background(), [][]byte{hash}), "timed out")
+	})
+
+	t.Run("track inclusion with events", func(t *testing.T) {
+		cli := mocks.NewCometJSONRPCClient(t)
+		events := &eventClient{events: make(chan rpctypes.ResultEvent, len(txs))}
+		cfg := cfg
+		cfg.PollingFrequency = time.Hour
+		s := submitter.NewPipelinedSubmitter(cli, events, cfg, zaptest.NewLogger(t))
+
+		hashes := make([][]byte, 0, len(txs))
+		for _, tx := range txs {
+			hashes = append(hashes, tx.Hash())
+			events.events <- rpctypes.ResultEvent{Data: cmttypes.EventDataTx{TxResult: cmtabci.TxResult{Tx: tx}}}
+		}
+
+		require.NoError(t, s.WaitForInclusion(context.Background(), hashes))
+	})
+}
@@ -0,0 +1,364 @@
+package dispatcher
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	txv1beta1 "cosmossdk.io/api/cosmos/tx/v1beta1"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txsigning "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/dispatcher/transaction/generator"
+	"github.com/skip-mev/connect-mmu/signing"
+)
+
+// TxPlan is the set of transactions of a dispatch, in order of submission. It is written in simulate mode so that the
+// exact transactions can be reviewed before they are submitted.
+type TxPlan struct {
+	ChainID  string      `json:"chain_id"`
+	Signer   string      `json:"signer"`
+	GasPrice sdk.DecCoin `json:"gas_price"`
+	TotalFee sdk.Coins   `json:"total_fee"`
+
+	Transactions []PlannedTx `json:"transactions"`
+
+	// Upserts are the markets upserted by the transactions, used to verify the market map once they are submitted.
+	Upserts []mmtypes.Market `json:"upserts"`
+}
+
+// PlannedTx is a single transaction of a TxPlan.
+type PlannedTx struct {
+	Sequence uint64 `json:"sequence"`
+	Hash     string `json:"hash"`
+	// Signed is false if the transaction was generated by a signing agent that does not sign, ex. for simulation.
+	Signed bool `json:"signed"`
+	// Messages are the JSON encoded messages of the transaction.
+	Messages []json.RawMessage `json:"messages"`
+	// MessageTypes are the type URLs of the messages of the transaction.
+	MessageTypes []string  `json:"message_types"`
+	Tickers      []string  `json:"tickers"`
+	Gas          uint64    `json:"gas"`
+	Fee          sdk.Coins `json:"fee"`
+	// TxBytes are the encoded transaction, as it is broadcast.
+	TxBytes []byte `json:"tx_bytes"`
+}
+
+// NewTxPlan decodes the transactions generated from the plan into a TxPlan. signer is the address of the signing
+// account, and sequence is its sequence before the first transaction.
+func NewTxPlan(
+	chainCfg config.ChainConfig,
+	signer string,
+	sequence uint64,
+	plan generator.BatchPlan,
+	txs []cmttypes.Tx,
+) (TxPlan, error) {
+	if len(txs) != len(plan.Batches) {
+		return TxPlan{}, fmt.Errorf("expected a transaction for each of the %d batches, got %d", len(plan.Batches), len(txs))
+	}
+
+	cdc, err := signing.Codec(chainCfg.Prefix)
+	if err != nil {
+		return TxPlan{}, fmt.Errorf("failed to create codec: %w", err)
+	}
+	decoder := signing.TxConfig(cdc).TxDecoder()
+
+	txPlan := TxPlan{
+		ChainID:      chainCfg.ChainID,
+		Signer:       signer,
+		GasPrice:     plan.GasPrice,
+		TotalFee:     sdk.NewCoins(),
+		Transactions: make([]PlannedTx, 0, len(txs)),
+		Upserts:      make([]mmtypes.Market, 0),
+	}
+
+	for i, tx := range txs {
+		decoded, err := decoder(tx)
+		if err != nil {
+			return TxPlan{}, fmt.Errorf("failed to decode transaction %d: %w", i, err)
+		}
+
+		planned := PlannedTx{
+			Sequence: sequence + uint64(i),
+			Hash:     strings.ToUpper(hex.EncodeToString(tx.Hash())),
+			Tickers:  plan.Batches[i].Tickers(),
+			TxBytes:  tx,
+		}
+
+		for _, msg := range decoded.GetMsgs() {
+			bz, err := cdc.MarshalInterfaceJSON(msg)
+			if err != nil {
+				return TxPlan{}, fmt.Errorf("failed to encode message of transaction %d: %w", i, err)
+			}
+			planned.Messages = append(planned.Messages, bz)
+			planned.MessageTypes = append(planned.MessageTypes, sdk.MsgTypeURL(msg))
+		}
+
+		if feeTx, ok := decoded.(sdk.FeeTx); ok {
+			planned.Gas = feeTx.GetGas()
+			planned.Fee = feeTx.GetFee()
+		}
+
+		if sigTx, ok := decoded.(authsigning.SigVerifiableTx); ok {
+			sigs, err := sigTx.GetSignaturesV2()
+			if err != nil {
+				return TxPlan{}, fmt.Errorf("failed to get signatures of transaction %d: %w", i, err)
+			}
+			if len(sigs) > 0 {
+				planned.Signed = true
+				planned.Sequence = sigs[0].Sequence
+			}
+		}
+
+		txPlan.TotalFee = txPlan.TotalFee.Add(planned.Fee...)
+		txPlan.Transactions = append(txPlan.Transactions, planned)
+		txPlan.Upserts = append(txPlan.Upserts, plan.Batches[i].Markets...)
+	}
+
+	return txPlan, nil
+}
+
+// Validate checks that the plan can be submitted by the signer on the chain. Every transaction is decoded and must be
+// signed by the signer, for its account number and the chain, with consecutive sequences starting at its current
+// sequence. The messages, tickers and upserts of the plan must be those of the decoded transactions, so that the plan
+// describes exactly what is submitted.
+func (p TxPlan) Validate(chainCfg config.ChainConfig, signer string, accountNumber, sequence uint64) error {
+	if p.ChainID != chainCfg.ChainID {
+		return fmt.Errorf("plan is for chain %s, not %s", p.ChainID, chainCfg.ChainID)
+	}
+
+	if p.Signer != signer {
+		return fmt.Errorf("plan is signed by %s, not %s", p.Signer, signer)
+	}
+
+	cdc, err := signing.Codec(chainCfg.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to create codec: %w", err)
+	}
+	decoder := signing.TxConfig(cdc).TxDecoder()
+
+	upserts := make(map[string]mmtypes.Market)
+	for i, tx := range p.Transactions {
+		if !tx.Signed {
+			return fmt.Errorf("transaction %d is not signed", i)
+		}
+
+		if expected := sequence + uint64(i); tx.Sequence != expected {
+			return fmt.Errorf("account sequence mismatch for transaction %d: planned %d, expected %d", i, tx.Sequence,
+				expected)
+		}
+
+		if hash := strings.ToUpper(hex.EncodeToString(cmttypes.Tx(tx.TxBytes).Hash())); hash != tx.Hash {
+			return fmt.Errorf("hash of transaction %d is %s, planned %s", i, hash, tx.Hash)
+		}
+
+		decoded, err := decoder(tx.TxBytes)
+		if err != nil {
+			return fmt.Errorf("failed to decode transaction %d: %w", i, err)
+		}
+
+		if err := verifySignature(decoded, chainCfg, signer, accountNumber, tx.Sequence); err != nil {
+			return fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		markets, err := validateMsgs(cdc, decoded, tx)
+		if err != nil {
+			return fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		for _, market := range markets {
+			upserts[market.Ticker.String()] = market
+		}
+	}
+
+	if len(upserts) != len(p.Upserts) {
+		return fmt.Errorf("plan has %d upserts, transactions upsert %d markets", len(p.Upserts), len(upserts))
+	}
+	for _, planned := range p.Upserts {
+		market, found := upserts[planned.Ticker.String()]
+		if !found {
+			return fmt.Errorf("upsert %s is not in any transaction", planned.Ticker.String())
+		}
+		if !market.Equal(planned) {
+			return fmt.Errorf("upsert %s does not match the market of its transaction", planned.Ticker.String())
+		}
+	}
+
+	return nil
+}
+
+// verifySignature checks that the first signature of the transaction is a direct signature of the signer for the
+// chain, account number and sequence.
+func verifySignature(
+	decoded sdk.Tx,
+	chainCfg config.ChainConfig,
+	signer string,
+	accountNumber, sequence uint64,
+) error {
+	sigTx, ok := decoded.(authsigning.SigVerifiableTx)
+	if !ok {
+		return fmt.Errorf("unexpected tx type %T", decoded)
+	}
+
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return fmt.Errorf("failed to get signatures: %w", err)
+	}
+	if len(sigs) == 0 {
+		return fmt.Errorf("transaction is not signed")
+	}
+	sig := sigs[0]
+
+	address, err := signing.PubKeyBech32(chainCfg.Prefix, sig.PubKey)
+	if err != nil {
+		return fmt.Errorf("failed to derive the address of the signature: %w", err)
+	}
+	if address != signer {
+		return fmt.Errorf("transaction is signed by %s, not %s", address, signer)
+	}
+
+	if sig.Sequence != sequence {
+		return fmt.Errorf("signature is for sequence %d, expected %d", sig.Sequence, sequence)
+	}
+
+	data, ok := sig.Data.(*txsigning.SingleSignatureData)
+	if !ok || data.SignMode != txsigning.SignMode_SIGN_MODE_DIRECT {
+		return fmt.Errorf("signature is not a direct signature")
+	}
+
+	adaptable, ok := decoded.(authsigning.V2AdaptableTx)
+	if !ok {
+		return fmt.Errorf("unexpected tx type %T", decoded)
+	}
+	txData := adaptable.GetSigningTxData()
+
+	signDocBz, err := proto.MarshalOptions{Deterministic: true}.Marshal(&txv1beta1.SignDoc{
+		BodyBytes:     txData.BodyBytes,
+		AuthInfoBytes: txData.AuthInfoBytes,
+		ChainId:       chainCfg.ChainID,
+		AccountNumber: accountNumber,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode sign doc: %w", err)
+	}
+
+	if !sig.PubKey.VerifySignature(signDocBz, data.Signature) {
+		return fmt.Errorf("signature is not valid for chain %s and account number %d", chainCfg.ChainID,
+			accountNumber)
+	}
+
+	return nil
+}
+
+// validateMsgs checks that the messages, message types, tickers, gas and fee of the planned transaction are those of
+// the decoded transaction, and returns the markets of its messages.
+func validateMsgs(cdc codec.Codec, decoded sdk.Tx, planned PlannedTx) ([]mmtypes.Market, error) {
+	msgs := decoded.GetMsgs()
+	if len(msgs) != len(planned.Messages) || len(msgs) != len(planned.MessageTypes) {
+		return nil, fmt.Errorf("transaction has %d messages, planned %d", len(msgs), len(planned.Messages))
+	}
+
+	markets := make([]mmtypes.Market, 0)
+	for j, msg := range msgs {
+		if typeURL := sdk.MsgTypeURL(msg); typeURL != planned.MessageTypes[j] {
+			return nil, fmt.Errorf("message %d is a %s, planned %s", j, typeURL, planned.MessageTypes[j])
+		}
+
+		bz, err := cdc.MarshalInterfaceJSON(msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode message %d: %w", j, err)
+		}
+		equal, err := jsonEqual(bz, planned.Messages[j])
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare message %d: %w", j, err)
+		}
+		if !equal {
+			return nil, fmt.Errorf("message %d does not match the planned message", j)
+		}
+
+		msgMarkets, err := generator.MsgMarkets(msg)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", j, err)
+		}
+		markets = append(markets, msgMarkets...)
+	}
+
+	tickers := make([]string, len(markets))
+	for j, market := range markets {
+		tickers[j] = market.Ticker.String()
+	}
+	plannedTickers := slices.Clone(planned.Tickers)
+	slices.Sort(tickers)
+	slices.Sort(plannedTickers)
+	if !slices.Equal(tickers, plannedTickers) {
+		return nil, fmt.Errorf("transaction upserts %s, planned %s", strings.Join(tickers, ", "),
+			strings.Join(plannedTickers, ", "))
+	}
+
+	feeTx, ok := decoded.(sdk.FeeTx)
+	if !ok {
+		return nil, fmt.Errorf("unexpected tx type %T", decoded)
+	}
+	if feeTx.GetGas() != planned.Gas || !feeTx.GetFee().Equal(planned.Fee) {
+		return nil, fmt.Errorf("transaction has gas %d and fee %s, planned gas %d and fee %s", feeTx.GetGas(),
+			feeTx.GetFee(), planned.Gas, planned.Fee)
+	}
+
+	return markets, nil
+}
+
+// jsonEqual returns whether the JSON documents are equal, regardless of their formatting.
+func jsonEqual(a, b []byte) (bool, error) {
+	var compactA, compactB bytes.Buffer
+	if err := json.Compact(&compactA, a); err != nil {
+		return false, err
+	}
+	if err := json.Compact(&compactB, b); err != nil {
+		return false, err
+	}
+	return bytes.Equal(compactA.Bytes(), compactB.Bytes()), nil
+}
+
+// Txs returns the encoded transactions of the plan.
+func (p TxPlan) Txs() []cmttypes.Tx {
+	txs := make([]cmttypes.Tx, len(p.Transactions))
+	for i, tx := range p.Transactions {
+		txs[i] = tx.TxBytes
+	}
+	return txs
+}
+
+// WriteTxPlanText writes a human-readable summary of the plan.
+func WriteTxPlanText(w io.Writer, p TxPlan) error {
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, "=== %d transactions on %s ===\n", len(p.Transactions), p.ChainID)
+	fmt.Fprintf(b, "signer: %s\n", p.Signer)
+	fmt.Fprintf(b, "gas price: %s\n", p.GasPrice)
+	fmt.Fprintf(b, "total fee: %s\n", p.TotalFee)
+	fmt.Fprintf(b, "markets: %d\n", len(p.Upserts))
+
+	for i, tx := range p.Transactions {
+		fmt.Fprintf(b, "\n--- tx %d: sequence %d ---\n", i, tx.Sequence)
+		fmt.Fprintf(b, "  hash: %s\n", tx.Hash)
+		fmt.Fprintf(b, "  signed: %t\n", tx.Signed)
+		fmt.Fprintf(b, "  messages: %s\n", strings.Join(tx.MessageTypes, ", "))
+		fmt.Fprintf(b, "  gas: %d\n", tx.Gas)
+		fmt.Fprintf(b, "  fee: %s\n", tx.Fee)
+		fmt.Fprintf(b, "  bytes: %d\n", len(tx.TxBytes))
+		fmt.Fprintf(b, "  tickers (%d): %s\n", len(tx.Tickers), strings.Join(tx.Tickers, ", "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
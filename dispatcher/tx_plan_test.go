@@ -0,0 +1,214 @@
+package dispatcher_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	txv1beta1 "cosmossdk.io/api/cosmos/tx/v1beta1"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txsigning "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/dispatcher"
+	"github.com/skip-mev/connect-mmu/dispatcher/transaction/generator"
+	"github.com/skip-mev/connect-mmu/signing"
+	"github.com/skip-mev/connect-mmu/testutil/markets"
+)
+
+func TestTxPlan(t *testing.T) {
+	chainCfg := config.ChainConfig{ChainID: "dydx-testnet-4", Prefix: "dydx"}
+	cdc, err := signing.Codec(chainCfg.Prefix)
+	require.NoError(t, err)
+	txCfg := signing.TxConfig(cdc)
+
+	const accountNumber = 3
+	privKey := secp256k1.GenPrivKey()
+	signer, err := signing.PubKeyBech32(chainCfg.Prefix, privKey.PubKey())
+	require.NoError(t, err)
+
+	okx := func(base string) markets.Option {
+		return markets.WithProviders(mmtypes.ProviderConfig{Name: "okx_ws", OffChainTicker: base + "-USDT"})
+	}
+	plan := generator.BatchPlan{
+		GasPrice: sdk.NewInt64DecCoin("utoken", 1),
+		Batches: []generator.Batch{
+			{Markets: []mmtypes.Market{markets.New("BTC", okx("BTC")), markets.New("ETH", okx("ETH"))}, Gas: 200, Fee: sdk.NewCoins(sdk.NewInt64Coin("utoken", 200))},
+			{Markets: []mmtypes.Market{markets.New("SOL", okx("SOL"))}, Gas: 100, Fee: sdk.NewCoins(sdk.NewInt64Coin("utoken", 100))},
+		},
+	}
+
+	// sign the transaction for the chain with the key
+	sign := func(txb client.TxBuilder, key *secp256k1.PrivKey, chainID string, sequence uint64) {
+		sig := txsigning.SignatureV2{
+			PubKey:   key.PubKey(),
+			Data:     &txsigning.SingleSignatureData{SignMode: txsigning.SignMode_SIGN_MODE_DIRECT},
+			Sequence: sequence,
+		}
+		require.NoError(t, txb.SetSignatures(sig))
+
+		txData := txb.GetTx().(authsigning.V2AdaptableTx).GetSigningTxData()
+		signDocBz, err := proto.MarshalOptions{Deterministic: true}.Marshal(&txv1beta1.SignDoc{
+			BodyBytes:     txData.BodyBytes,
+			AuthInfoBytes: txData.AuthInfoBytes,
+			ChainId:       chainID,
+			AccountNumber: accountNumber,
+		})
+		require.NoError(t, err)
+
+		signature, err := key.Sign(signDocBz)
+		require.NoError(t, err)
+		sig.Data.(*txsigning.SingleSignatureData).Signature = signature
+		require.NoError(t, txb.SetSignatures(sig))
+	}
+
+	// encode the transactions of the plan, signed for the sequence with the key if it is set
+	encodeWith := func(key *secp256k1.PrivKey, chainID string, sequence uint64) []cmttypes.Tx {
+		txs := make([]cmttypes.Tx, 0, len(plan.Batches))
+		for i, batch := range plan.Batches {
+			txb := txCfg.NewTxBuilder()
+			require.NoError(t, txb.SetMsgs(&mmtypes.MsgUpsertMarkets{Authority: signer, Markets: batch.Markets}))
+			txb.SetGasLimit(batch.Gas)
+			txb.SetFeeAmount(batch.Fee)
+			if key != nil {
+				sign(txb, key, chainID, sequence+uint64(i))
+			}
+
+			tx, err := txCfg.TxEncoder()(txb.GetTx())
+			require.NoError(t, err)
+			txs = append(txs, tx)
+		}
+		return txs
+	}
+
+	encode := func(signed bool, sequence uint64) []cmttypes.Tx {
+		if !signed {
+			return encodeWith(nil, chainCfg.ChainID, sequence)
+		}
+		return encodeWith(privKey, chainCfg.ChainID, sequence)
+	}
+
+	t.Run("decode signed transactions", func(t *testing.T) {
+		txs := encode(true, 7)
+		txPlan, err := dispatcher.NewTxPlan(chainCfg, signer, 7, plan, txs)
+		require.NoError(t, err)
+
+		require.Equal(t, "dydx-testnet-4", txPlan.ChainID)
+		require.Equal(t, signer, txPlan.Signer)
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("utoken", 300)), txPlan.TotalFee)
+		require.Len(t, txPlan.Upserts, 3)
+		require.Equal(t, txs, txPlan.Txs())
+
+		require.Len(t, txPlan.Transactions, 2)
+		tx := txPlan.Transactions[0]
+		require.True(t, tx.Signed)
+		require.Equal(t, uint64(7), tx.Sequence)
+		require.Equal(t, uint64(200), tx.Gas)
+		require.Equal(t, plan.Batches[0].Fee, tx.Fee)
+		require.Equal(t, []string{"BTC/USD", "ETH/USD"}, tx.Tickers)
+		require.Equal(t, []string{"/connect.marketmap.v2.MsgUpsertMarkets"}, tx.MessageTypes)
+		require.Contains(t, string(tx.Messages[0]), `"authority":"`+signer+`"`)
+		require.Equal(t, uint64(8), txPlan.Transactions[1].Sequence)
+
+		require.NoError(t, txPlan.Validate(chainCfg, signer, accountNumber, 7))
+
+		// the plan round trips through JSON
+		bz, err := json.Marshal(txPlan)
+		require.NoError(t, err)
+		var decoded dispatcher.TxPlan
+		require.NoError(t, json.Unmarshal(bz, &decoded))
+		require.Equal(t, txs, decoded.Txs())
+		require.NoError(t, decoded.Validate(chainCfg, signer, accountNumber, 7))
+
+		summary := &strings.Builder{}
+		require.NoError(t, dispatcher.WriteTxPlanText(summary, txPlan))
+		require.Contains(t, summary.String(), "=== 2 transactions on dydx-testnet-4 ===")
+		require.Contains(t, summary.String(), "total fee: 300utoken")
+		require.Contains(t, summary.String(), "--- tx 1: sequence 8 ---")
+		require.Contains(t, summary.String(), "tickers (2): BTC/USD, ETH/USD")
+	})
+
+	t.Run("reject plans that cannot be submitted", func(t *testing.T) {
+		txPlan, err := dispatcher.NewTxPlan(chainCfg, signer, 7, plan, encode(true, 7))
+		require.NoError(t, err)
+
+		mainnetCfg := config.ChainConfig{ChainID: "dydx-mainnet-1", Prefix: "dydx"}
+		require.ErrorContains(t, txPlan.Validate(mainnetCfg, signer, accountNumber, 7), "plan is for chain")
+		require.ErrorContains(t, txPlan.Validate(chainCfg, "dydx10d07y265gmmuvt4z0w9aw880jnsr700jnmapky",
+			accountNumber, 7), "plan is signed by")
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 8), "account sequence mismatch")
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber+1, 7), "signature is not valid")
+
+		txPlan.Transactions[1].TxBytes = txPlan.Transactions[0].TxBytes
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 7), "hash of transaction 1")
+	})
+
+	t.Run("reject transactions that are not signed by the signer for the chain", func(t *testing.T) {
+		// signed for another chain
+		txPlan, err := dispatcher.NewTxPlan(chainCfg, signer, 7, plan, encodeWith(privKey, "dydx-mainnet-1", 7))
+		require.NoError(t, err)
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 7), "signature is not valid")
+
+		// signed by another key
+		txPlan, err = dispatcher.NewTxPlan(chainCfg, signer, 7, plan,
+			encodeWith(secp256k1.GenPrivKey(), chainCfg.ChainID, 7))
+		require.NoError(t, err)
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 7), "transaction 0: transaction is signed by")
+	})
+
+	t.Run("reject plans that do not describe their transactions", func(t *testing.T) {
+		newPlan := func() dispatcher.TxPlan {
+			txPlan, err := dispatcher.NewTxPlan(chainCfg, signer, 7, plan, encode(true, 7))
+			require.NoError(t, err)
+			return txPlan
+		}
+
+		txPlan := newPlan()
+		txPlan.Transactions[0].Messages[0] = json.RawMessage(strings.Replace(string(txPlan.Transactions[0].Messages[0]),
+			"ETH", "DOGE", 1))
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 7),
+			"message 0 does not match the planned message")
+
+		txPlan = newPlan()
+		txPlan.Transactions[0].MessageTypes[0] = "/connect.marketmap.v2.MsgCreateMarkets"
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 7), "message 0 is a")
+
+		txPlan = newPlan()
+		txPlan.Transactions[0].Tickers = []string{"BTC/USD"}
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 7), "transaction 0: transaction upserts")
+
+		txPlan = newPlan()
+		txPlan.Transactions[1].Fee = sdk.NewCoins(sdk.NewInt64Coin("utoken", 1))
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 7), "transaction 1: transaction has gas")
+
+		txPlan = newPlan()
+		txPlan.Upserts = txPlan.Upserts[:2]
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 7), "plan has 2 upserts")
+
+		txPlan = newPlan()
+		txPlan.Upserts[2].Ticker.MinProviderCount = 3
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 7),
+			"upsert SOL/USD does not match the market of its transaction")
+	})
+
+	t.Run("reject unsigned transactions", func(t *testing.T) {
+		txPlan, err := dispatcher.NewTxPlan(chainCfg, signer, 7, plan, encode(false, 0))
+		require.NoError(t, err)
+		require.False(t, txPlan.Transactions[0].Signed)
+		require.Equal(t, uint64(7), txPlan.Transactions[0].Sequence)
+
+		require.ErrorContains(t, txPlan.Validate(chainCfg, signer, accountNumber, 7), "is not signed")
+	})
+
+	t.Run("fail if the transactions do not match the batches", func(t *testing.T) {
+		_, err := dispatcher.NewTxPlan(chainCfg, signer, 7, plan, encode(true, 7)[:1])
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,417 @@
+package explain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
+	"github.com/skip-mev/connect-mmu/generator/types"
+	"github.com/skip-mev/connect-mmu/override"
+	"github.com/skip-mev/connect-mmu/override/update"
+	"github.com/skip-mev/connect-mmu/store/provider"
+	"github.com/skip-mev/connect-mmu/upsert/strategy"
+)
+
+// StageKind is the kind of data a Stage was observed on.
+type StageKind string
+
+const (
+	StageKindFeeds     StageKind = "feeds"
+	StageKindMarketMap StageKind = "market_map"
+)
+
+// Options selects what is traced through the pipeline.
+type Options struct {
+	// Ticker is the currency pair of the market to trace, ex. BTC/USD.
+	Ticker connecttypes.CurrencyPair
+	// Provider optionally restricts the traced provider rows, feeds and removals to a single provider.
+	Provider string
+}
+
+// Explanation is the trace of a single market through every step of generating, overriding and upserting a market map.
+type Explanation struct {
+	Ticker   string `json:"ticker"`
+	Provider string `json:"provider,omitempty"`
+
+	// ProviderRows are the raw indexed rows of the provider store that can produce the ticker.
+	ProviderRows []provider.GetFilteredProviderMarketsRow `json:"provider_rows"`
+
+	// Stages are the states of the market after every generation step, in order.
+	Stages []Stage `json:"stages"`
+
+	// RemovedAt is the name of the first stage after which the market no longer exists, if it was removed.
+	RemovedAt string `json:"removed_at,omitempty"`
+
+	// Generated is the market in the generated market map, if it exists.
+	Generated *mmtypes.Market `json:"generated,omitempty"`
+
+	// Override is the result of merging the generated market with the on-chain market map.
+	Override *OverrideExplanation `json:"override,omitempty"`
+
+	// Upsert reports whether the market would be upserted on chain.
+	Upsert *UpsertExplanation `json:"upsert,omitempty"`
+}
+
+// Stage is the state of the traced market after a single generation step.
+type Stage struct {
+	Name string    `json:"name"`
+	Kind StageKind `json:"kind"`
+
+	// Feeds are the feeds that can produce the ticker. Only set for feed stages.
+	Feeds []types.Feed `json:"feeds,omitempty"`
+
+	// Market is the traced market. Only set for market map stages in which the market exists.
+	Market *mmtypes.Market `json:"market,omitempty"`
+
+	// Removals are the removal reasons for the traced market that were recorded by this stage.
+	Removals []types.RemovalReason `json:"removals,omitempty"`
+}
+
+// Present returns true if the traced market still exists after this stage.
+func (s Stage) Present() bool {
+	if s.Kind == StageKindFeeds {
+		return len(s.Feeds) > 0
+	}
+
+	return s.Market != nil
+}
+
+// OverrideExplanation is the result of merging the generated market with the on-chain market map.
+type OverrideExplanation struct {
+	// OnChain is the current on-chain market, if it exists.
+	OnChain *mmtypes.Market `json:"on_chain,omitempty"`
+	// Decision describes how each step of the override changed the market: the merging of DeFi markets, the
+	// combination with the on-chain market map by update.CombineMarketMaps, and the MarketMapOverride.
+	Decision string `json:"decision"`
+	// Combined is the market after combining with the on-chain market map, before the MarketMapOverride changed it.
+	Combined *mmtypes.Market `json:"combined,omitempty"`
+	// Result is the market in the overridden market map, if it exists.
+	Result *mmtypes.Market `json:"result,omitempty"`
+}
+
+// UpsertExplanation reports whether strategy.GetMarketMapUpserts would emit an upsert for the market.
+type UpsertExplanation struct {
+	Emitted bool            `json:"emitted"`
+	Reason  string          `json:"reason"`
+	Market  *mmtypes.Market `json:"market,omitempty"`
+}
+
+// tracer is a transformer.Observer that records the state of the traced market after every stage.
+type tracer struct {
+	opts   Options
+	stages []Stage
+}
+
+var _ transformer.Observer = (*tracer)(nil)
+
+func (t *tracer) ObserveFeeds(stage string, feeds types.Feeds, removals types.RemovalReasons) {
+	s := Stage{Name: stage, Kind: StageKindFeeds, Feeds: make([]types.Feed, 0)}
+	for _, feed := range feeds {
+		if t.feedMatches(feed) {
+			s.Feeds = append(s.Feeds, feed)
+		}
+	}
+	s.Removals = t.filterRemovals(removals)
+
+	t.stages = append(t.stages, s)
+}
+
+func (t *tracer) ObserveMarketMap(stage string, mm mmtypes.MarketMap, removals types.RemovalReasons) {
+	s := Stage{Name: stage, Kind: StageKindMarketMap}
+	if market, found := mm.Markets[t.opts.Ticker.String()]; found {
+		s.Market = copyMarket(market)
+	}
+	s.Removals = t.filterRemovals(removals)
+
+	t.stages = append(t.stages, s)
+}
+
+// pairMatches returns true if the given pair is the traced ticker, shares its base, or can be inverted into it.
+func (t *tracer) pairMatches(base, quote string) bool {
+	return base == t.opts.Ticker.Base || (base == t.opts.Ticker.Quote && quote == t.opts.Ticker.Base)
+}
+
+func (t *tracer) providerMatches(name string) bool {
+	return t.opts.Provider == "" || t.opts.Provider == name
+}
+
+func (t *tracer) feedMatches(feed types.Feed) bool {
+	return t.providerMatches(feed.ProviderConfig.Name) &&
+		t.pairMatches(feed.Ticker.CurrencyPair.Base, feed.Ticker.CurrencyPair.Quote)
+}
+
+func (t *tracer) filterRemovals(removals types.RemovalReasons) []types.RemovalReason {
+	out := make([]types.RemovalReason, 0)
+	for _, key := range sortedKeys(removals) {
+		for _, reason := range removals[key] {
+			if reason.Feed.ProviderConfig.Name != "" {
+				if t.feedMatches(reason.Feed) {
+					out = append(out, reason)
+				}
+				continue
+			}
+
+			// market level removals are recorded with the currency pair as the provider.
+			if reason.Market.Ticker.String() == t.opts.Ticker.String() &&
+				(t.providerMatches(reason.Provider) || reason.Provider == t.opts.Ticker.String()) {
+				out = append(out, reason)
+			}
+		}
+	}
+
+	return out
+}
+
+// Generate replays market map generation for the given config and traces the market selected by opts through
+// every stage. The full generated market map is returned so that it can be passed to TraceOverride.
+func Generate(
+	ctx context.Context,
+	logger *zap.Logger,
+	providerStore provider.Store,
+	transforms *transformer.Registry,
+	cfg config.GenerateConfig,
+	opts Options,
+) (Explanation, mmtypes.MarketMap, error) {
+	if err := opts.Ticker.ValidateBasic(); err != nil {
+		return Explanation{}, mmtypes.MarketMap{}, fmt.Errorf("invalid ticker: %w", err)
+	}
+
+	t := &tracer{opts: opts}
+	e := Explanation{
+		Ticker:   opts.Ticker.String(),
+		Provider: opts.Provider,
+	}
+
+	providerNames := maps.Keys(cfg.Providers)
+	if opts.Provider != "" {
+		providerNames = []string{opts.Provider}
+	}
+	rows, err := providerStore.GetProviderMarkets(ctx, provider.GetFilteredProviderMarketsParams{ProviderNames: providerNames})
+	if err != nil {
+		return Explanation{}, mmtypes.MarketMap{}, fmt.Errorf("failed to query provider store: %w", err)
+	}
+	e.ProviderRows = make([]provider.GetFilteredProviderMarketsRow, 0)
+	for _, row := range rows {
+		if t.providerMatches(row.ProviderName) && t.pairMatches(row.TargetBase, row.TargetQuote) {
+			e.ProviderRows = append(e.ProviderRows, row)
+		}
+	}
+
+	g, err := generator.NewFromConfig(logger, providerStore, transforms, cfg)
+	if err != nil {
+		return Explanation{}, mmtypes.MarketMap{}, err
+	}
+	g = g.WithObserver(t)
+
+	mm, _, err := g.GenerateMarketMap(ctx, cfg)
+	if err != nil {
+		return Explanation{}, mmtypes.MarketMap{}, fmt.Errorf("failed to generate market map: %w", err)
+	}
+
+	e.Stages = t.stages
+	for i, stage := range e.Stages {
+		if !stage.Present() && (i == 0 || e.Stages[i-1].Present()) {
+			e.RemovedAt = stage.Name
+		}
+		if stage.Present() {
+			e.RemovedAt = ""
+		}
+	}
+
+	if market, found := mm.Markets[e.Ticker]; found {
+		e.Generated = copyMarket(market)
+	}
+
+	return e, mm, nil
+}
+
+// TraceOverride merges the generated market map with the on-chain market map with override.Override, and records the
+// decision that was made for the traced market. The decision is derived from the markets produced by each step of the
+// override: the merging of DeFi markets, the combination with the on-chain market map, and the changes made by the
+// MarketMapOverride on top of that combination. The overridden market map is returned.
+func (e *Explanation) TraceOverride(
+	ctx context.Context,
+	logger *zap.Logger,
+	mmo override.MarketMapOverride,
+	actual, generated mmtypes.MarketMap,
+	options update.Options,
+) (mmtypes.MarketMap, error) {
+	// the steps are replayed on copies, since merging DeFi markets modifies the generated market map in place
+	merged := cloneMarketMap(generated)
+	if !options.DisableDeFiMarketMerging {
+		var err error
+		merged, err = override.ConsolidateDeFiMarkets(logger, merged, actual)
+		if err != nil {
+			return mmtypes.MarketMap{}, fmt.Errorf("failed to consolidate defi markets: %w", err)
+		}
+	}
+
+	combined, err := update.CombineMarketMaps(logger, actual, cloneMarketMap(merged), options)
+	if err != nil {
+		return mmtypes.MarketMap{}, fmt.Errorf("failed to combine market maps: %w", err)
+	}
+
+	overridden, err := override.Override(ctx, logger, mmo, actual, cloneMarketMap(generated), options)
+	if err != nil {
+		return mmtypes.MarketMap{}, err
+	}
+
+	oe := &OverrideExplanation{}
+	if market, found := actual.Markets[e.Ticker]; found {
+		oe.OnChain = copyMarket(market)
+	}
+	if market, found := combined.Markets[e.Ticker]; found {
+		oe.Combined = copyMarket(market)
+	}
+	if market, found := overridden.Markets[e.Ticker]; found {
+		oe.Result = copyMarket(market)
+	}
+
+	decisions := make([]string, 0, 3)
+	if decision := mergeDecision(e.Ticker, generated, merged); decision != "" {
+		decisions = append(decisions, decision)
+	}
+	decisions = append(decisions, combineDecision(e.Ticker, merged, oe, options))
+	if decision := overrideDecision(mmo, oe); decision != "" {
+		decisions = append(decisions, decision)
+	}
+	oe.Decision = strings.Join(decisions, "; ")
+	e.Override = oe
+
+	return overridden, nil
+}
+
+// TraceUpserts records whether strategy.GetMarketMapUpserts would emit an upsert for the traced market.
+func (e *Explanation) TraceUpserts(logger *zap.Logger, actual, overridden mmtypes.MarketMap) error {
+	upserts, err := strategy.GetMarketMapUpserts(logger, actual, overridden)
+	if err != nil {
+		return fmt.Errorf("failed to get upserts: %w", err)
+	}
+
+	ue := &UpsertExplanation{}
+	idx := slices.IndexFunc(upserts, func(m mmtypes.Market) bool { return m.Ticker.String() == e.Ticker })
+	actualMarket, onChain := actual.Markets[e.Ticker]
+	_, inOverridden := overridden.Markets[e.Ticker]
+
+	switch {
+	case idx >= 0 && !onChain:
+		ue.Emitted = true
+		ue.Reason = "market does not exist on chain"
+		ue.Market = copyMarket(upserts[idx])
+	case idx >= 0:
+		ue.Emitted = true
+		ue.Reason = "market differs from the on-chain market"
+		ue.Market = copyMarket(upserts[idx])
+	case !inOverridden:
+		ue.Reason = "market is not in the overridden market map"
+	case onChain && actualMarket.Equal(overridden.Markets[e.Ticker]):
+		ue.Reason = "market is unchanged from the on-chain market"
+	default:
+		ue.Reason = "market was not selected for upsert"
+	}
+	e.Upsert = ue
+
+	return nil
+}
+
+// mergeDecision describes how merging DeFi markets moved the traced market, if it did.
+func mergeDecision(ticker string, generated, merged mmtypes.MarketMap) string {
+	_, isGenerated := generated.Markets[ticker]
+	mergedMarket, isMerged := merged.Markets[ticker]
+
+	switch {
+	case isGenerated && !isMerged:
+		for _, other := range sortedKeys(merged.Markets) {
+			if _, found := generated.Markets[other]; found {
+				continue
+			}
+			market := generated.Markets[ticker]
+			market.Ticker.CurrencyPair = merged.Markets[other].Ticker.CurrencyPair
+			if market.Equal(merged.Markets[other]) {
+				return fmt.Sprintf("the generated DeFi market was merged into the on-chain market %s", other)
+			}
+		}
+		return "the generated DeFi market was merged into an on-chain market"
+	case !isGenerated && isMerged:
+		for _, other := range sortedKeys(generated.Markets) {
+			if _, found := merged.Markets[other]; found {
+				continue
+			}
+			market := generated.Markets[other]
+			market.Ticker.CurrencyPair = mergedMarket.Ticker.CurrencyPair
+			if market.Equal(mergedMarket) {
+				return fmt.Sprintf("the generated DeFi market %s was merged into the market", other)
+			}
+		}
+		return "a generated DeFi market was merged into the market"
+	default:
+		return ""
+	}
+}
+
+// combineDecision describes the result of update.CombineMarketMaps for the traced market, given the generated market
+// map after merging DeFi markets.
+func combineDecision(ticker string, merged mmtypes.MarketMap, oe *OverrideExplanation, options update.Options) string {
+	_, isGenerated := merged.Markets[ticker]
+
+	switch {
+	case oe.Combined == nil && isGenerated:
+		return "market is not on chain and existing-only is set: the generated market is dropped"
+	case oe.Combined == nil:
+		return "market is neither generated nor on chain"
+	case oe.OnChain == nil:
+		return "market is not on chain: the generated market is added disabled"
+	case !isGenerated:
+		return "market is only on chain: the on-chain market is kept"
+	case oe.OnChain.Ticker.Enabled && !options.UpdateEnabled:
+		return "market is enabled on chain and update-enabled is not set: the on-chain market is kept"
+	case oe.Combined.Equal(*oe.OnChain):
+		return "market is on chain: the generated market does not change the on-chain market"
+	case options.OverwriteProviders:
+		return "market is on chain: the generated provider configs replace the on-chain provider configs"
+	default:
+		return "market is on chain: new generated provider configs are appended to the on-chain provider configs"
+	}
+}
+
+// overrideDecision describes how the MarketMapOverride changed the combined market, if it did.
+func overrideDecision(mmo override.MarketMapOverride, oe *OverrideExplanation) string {
+	switch {
+	case oe.Result == nil && oe.Combined == nil:
+		return ""
+	case oe.Result == nil:
+		return fmt.Sprintf("the %T override removed the combined market", mmo)
+	case oe.Combined == nil:
+		return fmt.Sprintf("the %T override added the market", mmo)
+	case oe.Result.Equal(*oe.Combined):
+		return ""
+	case oe.OnChain != nil && oe.Result.Equal(*oe.OnChain):
+		return fmt.Sprintf("the %T override replaced the combined market with the on-chain market", mmo)
+	default:
+		return fmt.Sprintf("the %T override changed the combined market", mmo)
+	}
+}
+
+func cloneMarketMap(mm mmtypes.MarketMap) mmtypes.MarketMap {
+	return mmtypes.MarketMap{Markets: maps.Clone(mm.Markets)}
+}
+
+func copyMarket(market mmtypes.Market) *mmtypes.Market {
+	market.ProviderConfigs = slices.Clone(market.ProviderConfigs)
+	return &market
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := maps.Keys(m)
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,240 @@
+package explain_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/explain"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
+	"github.com/skip-mev/connect-mmu/override"
+	"github.com/skip-mev/connect-mmu/override/update"
+	"github.com/skip-mev/connect-mmu/store/provider"
+)
+
+func newStore(t *testing.T) provider.Store {
+	t.Helper()
+	ctx := context.Background()
+	store := provider.NewMemoryStore()
+
+	ids := make(map[string]int32)
+	for i, symbol := range []string{"BTC", "ETH", "USD"} {
+		res, err := store.AddAssetInfo(ctx, provider.CreateAssetInfoParams{
+			Symbol:         symbol,
+			MultiAddresses: [][]string{{"UNKNOWN", ""}},
+			CmcID:          int64(i + 1),
+			Rank:           int64(i + 1),
+		})
+		require.NoError(t, err)
+		ids[symbol] = res.ID
+	}
+
+	markets := []struct{ provider, base, ticker string }{
+		{"coinbase_ws", "BTC", "BTC-USD"},
+		{"kraken_ws", "BTC", "XBT/USD"},
+		{"coinbase_ws", "ETH", "ETH-USD"},
+		{"kraken_ws", "ETH", "ETH/USD"},
+	}
+	for _, m := range markets {
+		_, err := store.AddProviderMarket(ctx, provider.CreateProviderMarketParams{
+			TargetBase:       m.base,
+			TargetQuote:      "USD",
+			OffChainTicker:   m.ticker,
+			ProviderName:     m.provider,
+			BaseAssetInfoID:  ids[m.base],
+			QuoteAssetInfoID: ids["USD"],
+			QuoteVolume:      1e9,
+			NegativeDepthTwo: 1e9,
+			PositiveDepthTwo: 1e9,
+			ReferencePrice:   1,
+		})
+		require.NoError(t, err)
+	}
+
+	return store
+}
+
+func generateConfig() config.GenerateConfig {
+	return config.GenerateConfig{
+		Providers: map[string]config.ProviderConfig{
+			"coinbase_ws": {},
+			"kraken_ws":   {},
+		},
+		Quotes: map[string]config.QuoteConfig{
+			"USD": {},
+		},
+		MinCexProviderCount: 2,
+		MinDexProviderCount: 1,
+		Rules: []config.RuleConfig{
+			{
+				ID:         "no-coinbase-btc",
+				Scope:      config.RuleScopeFeed,
+				Expression: `provider == "coinbase_ws" && base == "BTC"`,
+				Action:     config.RuleActionDrop,
+			},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t)
+	cfg := generateConfig()
+
+	t.Run("removed market", func(t *testing.T) {
+		e, mm, err := explain.Generate(ctx, zap.NewNop(), store, transformer.NewDefaultRegistry(), cfg, explain.Options{
+			Ticker: connecttypes.NewCurrencyPair("BTC", "USD"),
+		})
+		require.NoError(t, err)
+		require.NotContains(t, mm.Markets, "BTC/USD")
+		require.Contains(t, mm.Markets, "ETH/USD")
+
+		require.Len(t, e.ProviderRows, 2)
+		require.Nil(t, e.Generated)
+		require.Equal(t, transformer.NamePruneInsufficientlyProvidedMarkets, e.RemovedAt)
+
+		stageNames := make([]string, len(e.Stages))
+		for i, stage := range e.Stages {
+			stageNames[i] = stage.Name
+		}
+		require.Equal(t, "Query", stageNames[0])
+		require.Contains(t, stageNames, "ToMarketMap")
+		require.Equal(t, transformer.NameOverrideMarkets, stageNames[len(stageNames)-1])
+
+		var ruleRemovals int
+		for _, stage := range e.Stages {
+			if stage.Name == transformer.NameApplyFeedRules {
+				require.Len(t, stage.Feeds, 1)
+				ruleRemovals = len(stage.Removals)
+			}
+		}
+		require.Equal(t, 1, ruleRemovals)
+
+		var b bytes.Buffer
+		require.NoError(t, explain.WriteText(&b, e))
+		require.Contains(t, b.String(), "removed at PruneInsufficientlyProvidedMarkets")
+		require.Contains(t, b.String(), "Rule no-coinbase-btc")
+	})
+
+	t.Run("single provider", func(t *testing.T) {
+		e, _, err := explain.Generate(ctx, zap.NewNop(), store, transformer.NewDefaultRegistry(), cfg, explain.Options{
+			Ticker:   connecttypes.NewCurrencyPair("ETH", "USD"),
+			Provider: "kraken_ws",
+		})
+		require.NoError(t, err)
+		require.Len(t, e.ProviderRows, 1)
+		require.NotNil(t, e.Generated)
+		require.Empty(t, e.RemovedAt)
+		for _, stage := range e.Stages {
+			if stage.Kind == explain.StageKindFeeds {
+				require.Len(t, stage.Feeds, 1)
+			}
+		}
+	})
+
+	t.Run("invalid ticker", func(t *testing.T) {
+		_, _, err := explain.Generate(ctx, zap.NewNop(), store, transformer.NewDefaultRegistry(), cfg, explain.Options{})
+		require.Error(t, err)
+	})
+}
+
+func TestTraceOverrideAndUpserts(t *testing.T) {
+	ctx := context.Background()
+	e, generated, err := explain.Generate(ctx, zap.NewNop(), newStore(t), transformer.NewDefaultRegistry(), generateConfig(), explain.Options{
+		Ticker: connecttypes.NewCurrencyPair("ETH", "USD"),
+	})
+	require.NoError(t, err)
+
+	actual := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{}}
+	overridden, err := e.TraceOverride(ctx, zap.NewNop(), override.NewCoreOverride(), actual, generated, update.Options{})
+	require.NoError(t, err)
+	require.Contains(t, overridden.Markets, "ETH/USD")
+	require.Contains(t, e.Override.Decision, "not on chain")
+	require.Nil(t, e.Override.OnChain)
+	require.NotNil(t, e.Override.Result)
+	require.False(t, e.Override.Result.Ticker.Enabled)
+
+	require.NoError(t, e.TraceUpserts(zap.NewNop(), actual, overridden))
+	require.True(t, e.Upsert.Emitted)
+
+	// once the market is on chain, no upsert is emitted
+	require.NoError(t, e.TraceUpserts(zap.NewNop(), overridden, overridden))
+	require.False(t, e.Upsert.Emitted)
+	require.Contains(t, e.Upsert.Reason, "unchanged")
+}
+
+// protectedMarkets is a ProtectedMarketsClient that returns a fixed list of tickers.
+type protectedMarkets []string
+
+func (p protectedMarkets) ProtectedMarkets(context.Context) ([]string, error) {
+	return p, nil
+}
+
+func TestTraceOverrideProtectedMarket(t *testing.T) {
+	ctx := context.Background()
+	e, generated, err := explain.Generate(ctx, zap.NewNop(), newStore(t), transformer.NewDefaultRegistry(), generateConfig(), explain.Options{
+		Ticker: connecttypes.NewCurrencyPair("ETH", "USD"),
+	})
+	require.NoError(t, err)
+
+	// the on-chain market has a single provider, so the core override appends the generated provider
+	onChain := *e.Generated
+	onChain.ProviderConfigs = onChain.ProviderConfigs[:1]
+	actual := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{"ETH/USD": onChain}}
+
+	mmo, err := override.NewProtectedMarketsOverride(override.NewCoreOverride(), protectedMarkets{"ETH/USD"})
+	require.NoError(t, err)
+
+	overridden, err := e.TraceOverride(ctx, zap.NewNop(), mmo, actual, generated, update.Options{UpdateEnabled: true})
+	require.NoError(t, err)
+	require.Equal(t, onChain, overridden.Markets["ETH/USD"])
+
+	require.NotNil(t, e.Override.Combined)
+	require.Len(t, e.Override.Combined.ProviderConfigs, 2)
+	require.Equal(t, onChain, *e.Override.Result)
+	require.Contains(t, e.Override.Decision, "appended to the on-chain provider configs")
+	require.Contains(t, e.Override.Decision,
+		"the *override.ProtectedMarketsOverride override replaced the combined market with the on-chain market")
+
+	var b bytes.Buffer
+	require.NoError(t, explain.WriteText(&b, e))
+	require.Contains(t, b.String(), "combined:")
+
+	// the core override keeps the combined market
+	overridden, err = e.TraceOverride(ctx, zap.NewNop(), override.NewCoreOverride(), actual, generated,
+		update.Options{UpdateEnabled: true})
+	require.NoError(t, err)
+	require.Len(t, overridden.Markets["ETH/USD"].ProviderConfigs, 2)
+	require.NotContains(t, e.Override.Decision, "override replaced")
+}
+
+func TestTraceOverrideDeFiMerging(t *testing.T) {
+	ctx := context.Background()
+	e, _, err := explain.Generate(ctx, zap.NewNop(), newStore(t), transformer.NewDefaultRegistry(), generateConfig(), explain.Options{
+		Ticker: connecttypes.NewCurrencyPair("ETH", "USD"),
+	})
+	require.NoError(t, err)
+
+	// a DeFi market with the same CMC ID as the on-chain market
+	defi := *e.Generated
+	defi.Ticker.CurrencyPair = connecttypes.NewCurrencyPair("ETH,UNISWAP_V3,0XETH", "USD")
+	generated := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{defi.Ticker.String(): defi}}
+	actual := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{"ETH/USD": *e.Generated}}
+
+	_, err = e.TraceOverride(ctx, zap.NewNop(), override.NewCoreOverride(), actual, generated, update.Options{})
+	require.NoError(t, err)
+	require.Contains(t, e.Override.Decision, "the generated DeFi market ETH,UNISWAP_V3,0XETH/USD was merged into the market")
+	// the generated market map is not modified
+	require.Contains(t, generated.Markets, "ETH,UNISWAP_V3,0XETH/USD")
+
+	_, err = e.TraceOverride(ctx, zap.NewNop(), override.NewCoreOverride(), actual, generated,
+		update.Options{DisableDeFiMarketMerging: true})
+	require.NoError(t, err)
+	require.Equal(t, "market is only on chain: the on-chain market is kept", e.Override.Decision)
+}
@@ -0,0 +1,111 @@
+package explain
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+
+	"github.com/skip-mev/connect-mmu/generator/types"
+)
+
+// WriteText writes a human readable rendering of the Explanation to w.
+func WriteText(w io.Writer, e Explanation) error {
+	b := &strings.Builder{}
+
+	fmt.Fprintf(b, "=== %s ===\n", e.Ticker)
+	if e.Provider != "" {
+		fmt.Fprintf(b, "provider: %s\n", e.Provider)
+	}
+
+	fmt.Fprintf(b, "\n--- provider rows (%d) ---\n", len(e.ProviderRows))
+	for _, row := range e.ProviderRows {
+		fmt.Fprintf(b, "  %s %s/%s (%s) volume=%g price=%g depth=-%g/+%g cmc=%d/%d\n",
+			row.ProviderName, row.TargetBase, row.TargetQuote, row.OffChainTicker, row.QuoteVolume, row.ReferencePrice,
+			row.NegativeDepthTwo, row.PositiveDepthTwo, row.BaseCmcID, row.QuoteCmcID)
+	}
+
+	for _, stage := range e.Stages {
+		switch stage.Kind {
+		case StageKindFeeds:
+			fmt.Fprintf(b, "\n--- %s: %d feeds ---\n", stage.Name, len(stage.Feeds))
+			for _, feed := range stage.Feeds {
+				writeFeed(b, feed)
+			}
+		case StageKindMarketMap:
+			if stage.Market == nil {
+				fmt.Fprintf(b, "\n--- %s: market absent ---\n", stage.Name)
+			} else {
+				fmt.Fprintf(b, "\n--- %s: market present ---\n", stage.Name)
+				writeMarket(b, *stage.Market)
+			}
+		}
+
+		for _, reason := range stage.Removals {
+			fmt.Fprintf(b, "  removed [%s] %s: %s\n", reason.Provider, reason.Code, reason.Reason)
+		}
+	}
+
+	fmt.Fprintln(b)
+	switch {
+	case e.Generated != nil:
+		fmt.Fprintln(b, "result: market is generated")
+	case e.RemovedAt != "":
+		fmt.Fprintf(b, "result: market is not generated, removed at %s\n", e.RemovedAt)
+	default:
+		fmt.Fprintln(b, "result: market is not generated")
+	}
+
+	if e.Override != nil {
+		fmt.Fprintf(b, "\n--- override ---\n  %s\n", e.Override.Decision)
+		if e.Override.OnChain != nil {
+			fmt.Fprintln(b, "  on chain:")
+			writeMarket(b, *e.Override.OnChain)
+		}
+		if e.Override.Combined != nil && (e.Override.Result == nil || !e.Override.Combined.Equal(*e.Override.Result)) {
+			fmt.Fprintln(b, "  combined:")
+			writeMarket(b, *e.Override.Combined)
+		}
+		if e.Override.Result != nil {
+			fmt.Fprintln(b, "  result:")
+			writeMarket(b, *e.Override.Result)
+		}
+	}
+
+	if e.Upsert != nil {
+		fmt.Fprintf(b, "\n--- upsert ---\n  emitted=%t: %s\n", e.Upsert.Emitted, e.Upsert.Reason)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeFeed(b *strings.Builder, feed types.Feed) {
+	fmt.Fprintf(b, "  %s %s (%s) invert=%t", feed.ProviderConfig.Name, feed.Ticker.String(),
+		feed.ProviderConfig.OffChainTicker, feed.ProviderConfig.Invert)
+	if feed.ProviderConfig.NormalizeByPair != nil {
+		fmt.Fprintf(b, " normalize_by=%s", feed.ProviderConfig.NormalizeByPair.String())
+	}
+	if feed.Tier != "" {
+		fmt.Fprintf(b, " tier=%s", feed.Tier)
+	}
+	fmt.Fprintf(b, " volume=%s price=%s liquidity=%g cmc_rank=%d\n", feed.DailyQuoteVolume.Text('g', 6),
+		feed.ReferencePrice.Text('g', 6), feed.LiquidityInfo.TotalLiquidity(), feed.CMCInfo.BaseRank)
+}
+
+func writeMarket(b *strings.Builder, market mmtypes.Market) {
+	fmt.Fprintf(b, "  enabled=%t decimals=%d min_provider_count=%d", market.Ticker.Enabled, market.Ticker.Decimals,
+		market.Ticker.MinProviderCount)
+	if tier := types.MarketTier(market); tier != "" {
+		fmt.Fprintf(b, " tier=%s", tier)
+	}
+	fmt.Fprintln(b)
+	for _, pc := range market.ProviderConfigs {
+		fmt.Fprintf(b, "    %s (%s) invert=%t", pc.Name, pc.OffChainTicker, pc.Invert)
+		if pc.NormalizeByPair != nil {
+			fmt.Fprintf(b, " normalize_by=%s", pc.NormalizeByPair.String())
+		}
+		fmt.Fprintln(b)
+	}
+}
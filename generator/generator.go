@@ -2,6 +2,7 @@ package generator
 
 import (
 	"context"
+	"fmt"
 
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 	"go.uber.org/zap"
@@ -18,8 +19,16 @@ type Generator struct {
 
 	q querier.Querier
 	t transformer.Transformer
+
+	observer transformer.Observer
 }
 
+// Names of the generator steps that are reported to an Observer in addition to the transform stages.
+const (
+	StageQuery       = "Query"
+	StageToMarketMap = "ToMarketMap"
+)
+
 func New(logger *zap.Logger, providerStore provider.Store) Generator {
 	return Generator{
 		logger: logger.With(zap.String("service", "generator")),
@@ -28,6 +37,34 @@ func New(logger *zap.Logger, providerStore provider.Store) Generator {
 	}
 }
 
+// NewFromConfig creates a Generator whose transform pipeline is built from the given GenerateConfig, using the
+// transforms of the registry.
+func NewFromConfig(
+	logger *zap.Logger,
+	providerStore provider.Store,
+	transforms *transformer.Registry,
+	cfg config.GenerateConfig,
+) (Generator, error) {
+	t, err := transformer.NewFromConfig(logger, transforms, cfg)
+	if err != nil {
+		return Generator{}, fmt.Errorf("failed to create transform pipeline: %w", err)
+	}
+
+	return Generator{
+		logger: logger.With(zap.String("service", "generator")),
+		q:      querier.New(logger, providerStore),
+		t:      t,
+	}, nil
+}
+
+// WithObserver returns a copy of the Generator that notifies the given Observer after querying feeds, after every
+// transform stage, and after the transformed feeds are converted to a market map.
+func (g Generator) WithObserver(observer transformer.Observer) Generator {
+	g.observer = observer
+	g.t = g.t.WithObserver(observer)
+	return g
+}
+
 func (g *Generator) GenerateMarketMap(
 	ctx context.Context,
 	cfg config.GenerateConfig,
@@ -39,6 +76,9 @@ func (g *Generator) GenerateMarketMap(
 	}
 
 	g.logger.Info("queried", zap.Int("feeds", len(feeds)))
+	if g.observer != nil {
+		g.observer.ObserveFeeds(StageQuery, feeds, nil)
+	}
 
 	transformed, dropped, err := g.t.TransformFeeds(ctx, cfg, feeds)
 	if err != nil {
@@ -53,6 +93,9 @@ func (g *Generator) GenerateMarketMap(
 		g.logger.Error("Unable to transform feeds to a MarketMap", zap.Error(err))
 		return mmtypes.MarketMap{}, nil, err
 	}
+	if g.observer != nil {
+		g.observer.ObserveMarketMap(StageToMarketMap, mm, nil)
+	}
 
 	mm, droppedMarkets, err := g.t.TransformMarketMap(ctx, cfg, mm)
 	if err != nil {
@@ -61,6 +104,11 @@ func (g *Generator) GenerateMarketMap(
 	}
 	dropped.Merge(droppedMarkets)
 
+	if err := types.ValidateNormalizeByRoutes(mm); err != nil {
+		g.logger.Error("Invalid normalization routes", zap.Error(err))
+		return mmtypes.MarketMap{}, nil, err
+	}
+
 	g.logger.Info("final market", zap.Int("size", len(mm.Markets)))
 
 	return mm, dropped, nil
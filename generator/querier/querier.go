@@ -89,18 +89,49 @@ func toFeed(pm provider.GetFilteredProviderMarketsRow, cfg config.GenerateConfig
 	}
 
 	cmcInfo := mmutypes.NewCoinMarketCapInfo(pm.BaseCmcID, pm.QuoteCmcID, pm.BaseRank, pm.QuoteRank)
+	resolveAssetAliases(cfg, &ticker, &cmcInfo)
 
 	liquidityInfo := mmutypes.LiquidityInfo{
 		NegativeDepthTwo: pm.NegativeDepthTwo,
 		PositiveDepthTwo: pm.PositiveDepthTwo,
 	}
 
-	return types.NewFeed(
+	feed := types.NewFeed(
 		ticker,
 		providerConfig,
 		pm.QuoteVolume,
 		pm.ReferencePrice,
 		liquidityInfo,
 		cmcInfo,
-	), nil
+	)
+	feed.TradingStatus = pm.TradingStatus.OrTrading()
+
+	return feed, nil
+}
+
+// resolveAssetAliases resolves wrapped assets of the ticker to their native assets using the configured asset
+// aliases. Aliases that may back the native market replace the asset ID (and symbol, if configured) so that the
+// feed is generated as a feed of the native market. Metadata-only aliases only record the native asset ID.
+func resolveAssetAliases(cfg config.GenerateConfig, ticker *mmtypes.Ticker, cmcInfo *mmutypes.CoinMarketCapInfo) {
+	if alias, found := cfg.AssetAlias(cmcInfo.BaseID); found {
+		if alias.BackNative {
+			cmcInfo.BaseID = alias.NativeCMCID
+			if alias.NativeSymbol != "" {
+				ticker.CurrencyPair.Base = alias.NativeSymbol
+			}
+		} else {
+			cmcInfo.BaseNativeID = alias.NativeCMCID
+		}
+	}
+
+	if alias, found := cfg.AssetAlias(cmcInfo.QuoteID); found {
+		if alias.BackNative {
+			cmcInfo.QuoteID = alias.NativeCMCID
+			if alias.NativeSymbol != "" {
+				ticker.CurrencyPair.Quote = alias.NativeSymbol
+			}
+		} else {
+			cmcInfo.QuoteNativeID = alias.NativeCMCID
+		}
+	}
 }
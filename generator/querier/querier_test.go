@@ -45,6 +45,7 @@ var expectedFeeds = types.Feeds{
 			NegativeDepthTwo: 100,
 			PositiveDepthTwo: 100,
 		},
+		TradingStatus: provider.TradingStatusTrading,
 	},
 	types.Feed{
 		Ticker: mmtypes.Ticker{
@@ -73,6 +74,7 @@ var expectedFeeds = types.Feeds{
 			NegativeDepthTwo: 100,
 			PositiveDepthTwo: 100,
 		},
+		TradingStatus: provider.TradingStatusTrading,
 	},
 }
 
@@ -148,3 +150,79 @@ func createAssets(ctx context.Context, t *testing.T, store provider.Store, asset
 	}
 	return ids
 }
+
+func TestFeedsAssetAliases(t *testing.T) {
+	store := provider.NewMemoryStore()
+	ctx := context.Background()
+
+	const (
+		ethID   = 1027
+		wethID  = 2396
+		usdID   = 2781
+		cbbtcID = 32994
+		btcID   = 1
+	)
+
+	ids := make(map[int64]int32)
+	for symbol, cmcID := range map[string]int64{"ETH": ethID, "WETH": wethID, "USD": usdID, "CBBTC": cbbtcID, "BTC": btcID} {
+		res, err := store.AddAssetInfo(ctx, provider.CreateAssetInfoParams{
+			Symbol:         symbol,
+			MultiAddresses: [][]string{{"UNKNOWN", ""}},
+			CmcID:          cmcID,
+			Rank:           cmcID,
+		})
+		require.NoError(t, err)
+		ids[cmcID] = res.ID
+	}
+
+	for _, market := range []struct {
+		base   string
+		baseID int64
+	}{
+		{base: "WETH", baseID: wethID},
+		{base: "CBBTC", baseID: cbbtcID},
+	} {
+		_, err := store.AddProviderMarket(ctx, provider.CreateProviderMarketParams{
+			TargetBase:       market.base,
+			TargetQuote:      "USD",
+			OffChainTicker:   market.base + "-USD",
+			ProviderName:     "coinbase",
+			BaseAssetInfoID:  ids[market.baseID],
+			QuoteAssetInfoID: ids[usdID],
+			QuoteVolume:      1000,
+			ReferencePrice:   100,
+		})
+		require.NoError(t, err)
+	}
+
+	qr := querier.New(zap.NewNop(), store)
+
+	feeds, err := qr.Feeds(ctx, config.GenerateConfig{
+		Providers: map[string]config.ProviderConfig{"coinbase": {}},
+		AssetAliases: []config.AssetAliasConfig{
+			{WrappedCMCID: wethID, NativeCMCID: ethID, NativeSymbol: "ETH", BackNative: true},
+			{WrappedCMCID: cbbtcID, NativeCMCID: btcID},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, feeds, 2)
+
+	byTicker := make(map[string]types.Feed)
+	for _, feed := range feeds {
+		byTicker[feed.ProviderConfig.OffChainTicker] = feed
+	}
+
+	// WETH backs the native ETH market.
+	weth := byTicker["WETH-USD"]
+	require.Equal(t, "ETH/USD", weth.Ticker.String())
+	require.Equal(t, int64(ethID), weth.CMCInfo.BaseID)
+	require.Equal(t, int64(0), weth.CMCInfo.BaseNativeID)
+	require.Equal(t, "1027-2781", weth.UniqueID())
+
+	// cbbtcID only feeds metadata, so it keeps its own market.
+	cbbtc := byTicker["CBBTC-USD"]
+	require.Equal(t, "CBBTC/USD", cbbtc.Ticker.String())
+	require.Equal(t, int64(cbbtcID), cbbtc.CMCInfo.BaseID)
+	require.Equal(t, int64(btcID), cbbtc.CMCInfo.BaseNativeID)
+	require.Equal(t, int64(btcID), cbbtc.CMCInfo.AggregateBaseID())
+}
@@ -0,0 +1,125 @@
+package rules
+
+import (
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator/types"
+)
+
+// FeedEnv is the set of fields that feed scoped rule expressions are evaluated against.
+type FeedEnv struct {
+	Provider       string  `expr:"provider"`
+	Base           string  `expr:"base"`
+	Quote          string  `expr:"quote"`
+	OffChainTicker string  `expr:"off_chain_ticker"`
+	CMCRank        int64   `expr:"cmc_rank"`
+	QuoteCMCRank   int64   `expr:"quote_cmc_rank"`
+	CMCID          int64   `expr:"cmc_id"`
+	QuoteCMCID     int64   `expr:"quote_cmc_id"`
+	Volume         float64 `expr:"volume"`
+	NegativeDepth  float64 `expr:"negative_depth"`
+	PositiveDepth  float64 `expr:"positive_depth"`
+	Liquidity      float64 `expr:"liquidity"`
+	ReferencePrice float64 `expr:"reference_price"`
+	Invert         bool    `expr:"invert"`
+	IsDefi         bool    `expr:"is_defi"`
+	IsSupplemental bool    `expr:"is_supplemental"`
+}
+
+// NewFeedEnv creates the FeedEnv of a feed.
+func NewFeedEnv(cfg config.GenerateConfig, feed types.Feed) FeedEnv {
+	env := FeedEnv{
+		Provider:       feed.ProviderConfig.Name,
+		Base:           feed.Ticker.CurrencyPair.Base,
+		Quote:          feed.Ticker.CurrencyPair.Quote,
+		OffChainTicker: feed.ProviderConfig.OffChainTicker,
+		CMCRank:        feed.CMCInfo.BaseRank,
+		QuoteCMCRank:   feed.CMCInfo.QuoteRank,
+		CMCID:          feed.CMCInfo.BaseID,
+		QuoteCMCID:     feed.CMCInfo.QuoteID,
+		NegativeDepth:  feed.LiquidityInfo.NegativeDepthTwo,
+		PositiveDepth:  feed.LiquidityInfo.PositiveDepthTwo,
+		Liquidity:      feed.LiquidityInfo.TotalLiquidity(),
+		Invert:         feed.ProviderConfig.Invert,
+		IsDefi:         cfg.IsProviderDefi(feed.ProviderConfig.Name),
+		IsSupplemental: cfg.Providers[feed.ProviderConfig.Name].IsSupplemental,
+	}
+
+	if feed.DailyQuoteVolume != nil {
+		env.Volume, _ = feed.DailyQuoteVolume.Float64()
+	}
+	if feed.ReferencePrice != nil {
+		env.ReferencePrice, _ = feed.ReferencePrice.Float64()
+	}
+
+	return env
+}
+
+// MarketEnv is the set of fields that market scoped rule expressions are evaluated against.
+type MarketEnv struct {
+	Ticker            string   `expr:"ticker"`
+	Base              string   `expr:"base"`
+	Quote             string   `expr:"quote"`
+	Providers         []string `expr:"providers"`
+	ProviderCount     int      `expr:"provider_count"`
+	DefiProviderCount int      `expr:"defi_provider_count"`
+	Enabled           bool     `expr:"enabled"`
+	MinProviderCount  uint64   `expr:"min_provider_count"`
+	Decimals          uint64   `expr:"decimals"`
+}
+
+// NewMarketEnv creates the MarketEnv of a market.
+func NewMarketEnv(cfg config.GenerateConfig, market mmtypes.Market) MarketEnv {
+	providers := make([]string, len(market.ProviderConfigs))
+	defiProviders := 0
+	for i, pc := range market.ProviderConfigs {
+		providers[i] = pc.Name
+		if cfg.IsProviderDefi(pc.Name) {
+			defiProviders++
+		}
+	}
+
+	return MarketEnv{
+		Ticker:            market.Ticker.String(),
+		Base:              market.Ticker.CurrencyPair.Base,
+		Quote:             market.Ticker.CurrencyPair.Quote,
+		Providers:         providers,
+		ProviderCount:     len(providers),
+		DefiProviderCount: defiProviders,
+		Enabled:           market.Ticker.Enabled,
+		MinProviderCount:  market.Ticker.MinProviderCount,
+		Decimals:          market.Ticker.Decimals,
+	}
+}
+
+// ProviderEnv is the set of fields that provider scoped rule expressions are evaluated against.
+// All fields of the provider's market are available as well.
+type ProviderEnv struct {
+	MarketEnv
+
+	Provider       string `expr:"provider"`
+	OffChainTicker string `expr:"off_chain_ticker"`
+	Invert         bool   `expr:"invert"`
+	NormalizeBy    string `expr:"normalize_by"`
+	IsDefi         bool   `expr:"is_defi"`
+	IsSupplemental bool   `expr:"is_supplemental"`
+}
+
+// NewProviderEnv creates the ProviderEnv of a provider config within the market described by marketEnv.
+func NewProviderEnv(cfg config.GenerateConfig, marketEnv MarketEnv, pc mmtypes.ProviderConfig) ProviderEnv {
+	env := ProviderEnv{
+		MarketEnv:      marketEnv,
+		Provider:       pc.Name,
+		OffChainTicker: pc.OffChainTicker,
+		Invert:         pc.Invert,
+		IsDefi:         cfg.IsProviderDefi(pc.Name),
+		IsSupplemental: cfg.Providers[pc.Name].IsSupplemental,
+	}
+
+	if pc.NormalizeByPair != nil {
+		env.NormalizeBy = pc.NormalizeByPair.String()
+	}
+
+	return env
+}
@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator/types"
+)
+
+// Rule is a compiled config.RuleConfig.
+type Rule struct {
+	config.RuleConfig
+
+	program *vm.Program
+}
+
+// Set is the compiled set of rules of a GenerateConfig, grouped by scope and kept in configuration order.
+type Set struct {
+	Feed     []Rule
+	Market   []Rule
+	Provider []Rule
+}
+
+// Empty returns true if the Set contains no rules.
+func (s Set) Empty() bool {
+	return len(s.Feed) == 0 && len(s.Market) == 0 && len(s.Provider) == 0
+}
+
+// envForScope returns the environment that expressions of the given scope are type checked against.
+func envForScope(scope config.RuleScope) (any, error) {
+	switch scope {
+	case config.RuleScopeFeed:
+		return FeedEnv{}, nil
+	case config.RuleScopeMarket:
+		return MarketEnv{}, nil
+	case config.RuleScopeProvider:
+		return ProviderEnv{}, nil
+	default:
+		return nil, fmt.Errorf("invalid scope %q", scope)
+	}
+}
+
+// Compile validates and compiles all rules, type checking each expression against the environment of its scope.
+// Expressions must evaluate to a bool and may not depend on the current time so that generation stays deterministic.
+func Compile(cfgs []config.RuleConfig) (Set, error) {
+	var set Set
+	for _, cfg := range cfgs {
+		if err := cfg.Validate(); err != nil {
+			return Set{}, err
+		}
+
+		env, err := envForScope(cfg.Scope)
+		if err != nil {
+			return Set{}, fmt.Errorf("rule %s: %w", cfg.ID, err)
+		}
+
+		program, err := expr.Compile(cfg.Expression, expr.Env(env), expr.AsBool(), expr.DisableBuiltin("now"))
+		if err != nil {
+			return Set{}, fmt.Errorf("rule %s: failed to compile expression: %w", cfg.ID, err)
+		}
+
+		rule := Rule{RuleConfig: cfg, program: program}
+		switch cfg.Scope {
+		case config.RuleScopeFeed:
+			set.Feed = append(set.Feed, rule)
+		case config.RuleScopeMarket:
+			set.Market = append(set.Market, rule)
+		case config.RuleScopeProvider:
+			set.Provider = append(set.Provider, rule)
+		}
+	}
+
+	return set, nil
+}
+
+// Matches evaluates the rule's expression against the given env.
+func (r Rule) Matches(env any) (bool, error) {
+	out, err := expr.Run(r.program, env)
+	if err != nil {
+		return false, fmt.Errorf("rule %s: failed to evaluate expression: %w", r.ID, err)
+	}
+
+	matched, ok := out.(bool)
+	if !ok {
+		return false, fmt.Errorf("rule %s: expression returned %T, expected bool", r.ID, out)
+	}
+
+	return matched, nil
+}
+
+// Reason returns the removal reason that is recorded by the given stage for a match of the rule.
+func (r Rule) Reason(stage string) types.Reason {
+	return types.NewReason(stage, types.ReasonRuleMatched,
+		fmt.Sprintf("Rule %s: %s (%s)", r.ID, r.Action, r.Expression)).WithRule(r.ID)
+}
+
+// AppliedReason returns the reason that is recorded by the given stage for a match of the rule whose action changed
+// a market or provider without removing it.
+func (r Rule) AppliedReason(stage string) types.Reason {
+	message := fmt.Sprintf("Rule %s: %s (%s)", r.ID, r.Action, r.Expression)
+	if r.Action == config.RuleActionSetMinProviderCount {
+		message = fmt.Sprintf("Rule %s: %s %d (%s)", r.ID, r.Action, r.MinProviderCount, r.Expression)
+	}
+
+	return types.NewReason(stage, types.ReasonRuleApplied, message).WithRule(r.ID)
+}
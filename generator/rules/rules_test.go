@@ -0,0 +1,132 @@
+package rules_test
+
+import (
+	"math/big"
+	"testing"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator/rules"
+	"github.com/skip-mev/connect-mmu/generator/types"
+	mmutypes "github.com/skip-mev/connect-mmu/types"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []config.RuleConfig
+		wantErr bool
+	}{
+		{
+			name: "valid rules of every scope",
+			rules: []config.RuleConfig{
+				{ID: "feed", Scope: config.RuleScopeFeed, Expression: `volume < 1e6 && is_defi`, Action: config.RuleActionDrop},
+				{ID: "market", Scope: config.RuleScopeMarket, Expression: `"mexc_ws" in providers`, Action: config.RuleActionDrop},
+				{ID: "provider", Scope: config.RuleScopeProvider, Expression: `provider == "mexc_ws" && provider_count > 3`, Action: config.RuleActionMarkSupplemental},
+			},
+		},
+		{
+			name: "unknown field for scope",
+			rules: []config.RuleConfig{
+				{ID: "market", Scope: config.RuleScopeMarket, Expression: `volume < 1e6`, Action: config.RuleActionDrop},
+			},
+			wantErr: true,
+		},
+		{
+			name: "non bool expression",
+			rules: []config.RuleConfig{
+				{ID: "feed", Scope: config.RuleScopeFeed, Expression: `volume`, Action: config.RuleActionDrop},
+			},
+			wantErr: true,
+		},
+		{
+			name: "time dependent expression",
+			rules: []config.RuleConfig{
+				{ID: "feed", Scope: config.RuleScopeFeed, Expression: `now().Year() > 2000`, Action: config.RuleActionDrop},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid rule config",
+			rules: []config.RuleConfig{
+				{ID: "feed", Scope: "unknown", Expression: `true`, Action: config.RuleActionDrop},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := rules.Compile(tt.rules)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, set.Feed, 1)
+			require.Len(t, set.Market, 1)
+			require.Len(t, set.Provider, 1)
+		})
+	}
+}
+
+func TestRule_Matches(t *testing.T) {
+	cfg := config.GenerateConfig{
+		Providers: map[string]config.ProviderConfig{
+			"mexc_ws":     {IsSupplemental: true},
+			"raydium_api": {IsDefi: true},
+		},
+	}
+
+	feed := types.NewFeed(
+		mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USDT")},
+		mmtypes.ProviderConfig{Name: "mexc_ws", OffChainTicker: "BTCUSDT"},
+		500_000, 1, mmutypes.LiquidityInfo{NegativeDepthTwo: 10, PositiveDepthTwo: 20},
+		mmutypes.CoinMarketCapInfo{BaseID: 1, QuoteID: 825, BaseRank: 1, QuoteRank: 3},
+	)
+	feed.ReferencePrice = big.NewFloat(60_000)
+
+	set, err := rules.Compile([]config.RuleConfig{
+		{
+			ID: "feed", Scope: config.RuleScopeFeed, Action: config.RuleActionDrop,
+			Expression: `provider == "mexc_ws" && volume < 1e6 && liquidity == 30 && is_supplemental && reference_price > 1e4`,
+		},
+		{
+			ID: "market", Scope: config.RuleScopeMarket, Action: config.RuleActionDrop,
+			Expression: `provider_count == 2 && defi_provider_count == 1 && ticker == "BTC/USD"`,
+		},
+		{
+			ID: "provider", Scope: config.RuleScopeProvider, Action: config.RuleActionDisableProvider,
+			Expression: `is_defi && base == "BTC" && normalize_by == "USDT/USD"`,
+		},
+	})
+	require.NoError(t, err)
+
+	matched, err := set.Feed[0].Matches(rules.NewFeedEnv(cfg, feed))
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	normalizeBy := connecttypes.NewCurrencyPair("USDT", "USD")
+	market := mmtypes.Market{
+		Ticker: mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD")},
+		ProviderConfigs: []mmtypes.ProviderConfig{
+			{Name: "mexc_ws", OffChainTicker: "BTCUSDT"},
+			{Name: "raydium_api", OffChainTicker: "BTC,RAYDIUM,ADDR", NormalizeByPair: &normalizeBy},
+		},
+	}
+	marketEnv := rules.NewMarketEnv(cfg, market)
+
+	matched, err = set.Market[0].Matches(marketEnv)
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	matched, err = set.Provider[0].Matches(rules.NewProviderEnv(cfg, marketEnv, market.ProviderConfigs[0]))
+	require.NoError(t, err)
+	require.False(t, matched)
+
+	matched, err = set.Provider[0].Matches(rules.NewProviderEnv(cfg, marketEnv, market.ProviderConfigs[1]))
+	require.NoError(t, err)
+	require.True(t, matched)
+}
@@ -12,6 +12,7 @@ import (
 	"golang.org/x/exp/maps"
 
 	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator/rules"
 	"github.com/skip-mev/connect-mmu/generator/types"
 )
 
@@ -23,6 +24,9 @@ type TransformFeed func(ctx context.Context, logger *zap.Logger, cfg config.Gene
 // For example, if we have a feed for BTC/USDT with a quote config for USDT indicating to adjustby USDT/USD:
 // - add a NormalizeByPair to the ProviderConfig of USDT/USD.
 // - change the ticker to be BTC/USD.
+//
+// If the quote config has NormalizeByCandidates, the NormalizeByPair is chosen per provider using
+// selectNormalizeByPair. Feeds for which no candidate has any feeds are dropped.
 func NormalizeBy() TransformFeed {
 	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig, feeds types.Feeds) (types.Feeds, types.RemovalReasons, error) {
 		logger.Info("adding normalize by pairs", zap.Int("feeds", len(feeds)))
@@ -35,6 +39,8 @@ func NormalizeBy() TransformFeed {
 
 		logger.Info("using quotes", zap.Any("configs", cfg.Quotes))
 
+		liquidity := newNormalizeByLiquidity(feeds)
+		removals := types.NewRemovalReasons()
 		transformedFeeds := make([]types.Feed, 0, len(feeds))
 		for _, feed := range feeds {
 			ticker := feed.Ticker
@@ -44,17 +50,31 @@ func NormalizeBy() TransformFeed {
 					ticker.CurrencyPair.Quote)
 			}
 
+			normPairString := quoteConfig.NormalizeByPair
+			selected := false
+			if len(quoteConfig.NormalizeByCandidates) > 0 {
+				normPairString, selected = selectNormalizeByPair(quoteConfig.NormalizeByCandidates, feed.ProviderConfig.Name, liquidity)
+				if !selected {
+					logger.Debug("dropping feed without normalization route", zap.Any("feed", feed))
+					removals.AddRemovalReasonFromFeed(feed, feed.ProviderConfig.Name, types.NewReason(NameNormalizeBy,
+						types.ReasonNoNormalizationRoute, fmt.Sprintf("NormalizeBy: none of the candidates %s have any feeds",
+							strings.Join(quoteConfig.NormalizeByCandidates, ","))))
+					continue
+				}
+			}
+
 			// normalize the pair if NormalizeByPair is specified.
-			if quoteConfig.NormalizeByPair != "" {
+			if normPairString != "" {
 				logger.Debug("normalizing by pair", zap.Any("feed", feed))
 
-				normPair, err := connecttypes.CurrencyPairFromString(quoteConfig.NormalizeByPair)
+				normPair, err := connecttypes.CurrencyPairFromString(normPairString)
 				if err != nil {
 					return nil, nil, err
 				}
 				newQuote := normPair.Quote
 				feed.ProviderConfig.NormalizeByPair = &normPair
 				feed.Ticker.CurrencyPair.Quote = newQuote
+				feed.NormalizeBySelected = selected
 
 				adjustPrice, ok := avgRefPrices[normPair.String()]
 				if !ok {
@@ -73,9 +93,75 @@ func NormalizeBy() TransformFeed {
 			transformedFeeds = append(transformedFeeds, feed)
 		}
 
-		logger.Info("added normalize by pairs", zap.Int("remaining feeds", len(feeds)))
-		return transformedFeeds, nil, nil
+		logger.Info("added normalize by pairs", zap.Int("remaining feeds", len(transformedFeeds)))
+		return transformedFeeds, removals, nil
+	}
+}
+
+// normalizeByLiquidity is the liquidity of the feeds available to normalize by.
+type normalizeByLiquidity struct {
+	// perProvider is the liquidity of the most liquid feed of each provider per ticker.
+	perProvider map[string]map[string]float64
+	// total is the total liquidity of all feeds per ticker.
+	total map[string]float64
+}
+
+func newNormalizeByLiquidity(feeds types.Feeds) normalizeByLiquidity {
+	l := normalizeByLiquidity{
+		perProvider: make(map[string]map[string]float64),
+		total:       make(map[string]float64),
+	}
+
+	for _, feed := range feeds {
+		ticker := feed.TickerString()
+		liquidity := feed.LiquidityInfo.TotalLiquidity()
+
+		l.total[ticker] += liquidity
+		if _, found := l.perProvider[ticker]; !found {
+			l.perProvider[ticker] = make(map[string]float64)
+		}
+		if current, found := l.perProvider[ticker][feed.ProviderConfig.Name]; !found || liquidity > current {
+			l.perProvider[ticker][feed.ProviderConfig.Name] = liquidity
+		}
+	}
+
+	return l
+}
+
+// selectNormalizeByPair chooses the candidate to normalize a feed of the given provider by. Only candidates with
+// feeds are eligible. Candidates that the provider has a feed for are preferred, choosing the one with the most
+// liquid feed of the provider. Otherwise, the candidate with the most total liquidity is chosen. Ties are broken by
+// the order of the candidates. False is returned if no candidate is eligible.
+func selectNormalizeByPair(candidates []string, provider string, liquidity normalizeByLiquidity) (string, bool) {
+	var (
+		best      string
+		bestOwn   bool
+		bestScore float64
+	)
+
+	for _, candidate := range candidates {
+		cp, err := connecttypes.CurrencyPairFromString(candidate)
+		if err != nil {
+			continue
+		}
+
+		total, eligible := liquidity.total[cp.String()]
+		if !eligible {
+			continue
+		}
+
+		score := total
+		own, isOwn := liquidity.perProvider[cp.String()][provider]
+		if isOwn {
+			score = own
+		}
+
+		if best == "" || (isOwn && !bestOwn) || (isOwn == bestOwn && score > bestScore) {
+			best, bestOwn, bestScore = cp.String(), isOwn, score
+		}
 	}
+
+	return best, best != ""
 }
 
 // ResolveConflictsForProvider resolves all conflicts between feeds.  Conflicts arise when the feeds have overlapping CurrencyPairs.
@@ -137,9 +223,10 @@ func DropFeedsWithoutAggregatorIDs() TransformFeed {
 				RequireAggregateIDs {
 				out = append(out, feed)
 			} else {
-				removals.AddRemovalReasonFromFeed(feed, feed.ProviderConfig.Name,
+				removals.AddRemovalReasonFromFeed(feed, feed.ProviderConfig.Name, types.NewReason(
+					NameDropFeedsWithoutAggregatorIDs, types.ReasonMissingAggregatorID,
 					fmt.Sprintf("Transform DropFeedsWithoutAggregatorIDs: BaseCMCID: %d, RequireAggregateIDs: %v", feed.CMCInfo.BaseID,
-						providerConfig.RequireAggregateIDs))
+						providerConfig.RequireAggregateIDs)))
 				logger.Info("dropping feed", zap.Any("ticker", feed.Ticker.String()), zap.Any("provider", feed.ProviderConfig.Name))
 			}
 		}
@@ -199,8 +286,9 @@ func InvertOrDrop() TransformFeed {
 				continue
 			}
 
-			removals.AddRemovalReasonFromFeed(feed, feed.ProviderConfig.Name, fmt.Sprintf("Transform InvertOrDrop: %s, "+
-				"feed cannot be inverted to quotes: %s", feed.Ticker.String(), quotes))
+			removals.AddRemovalReasonFromFeed(feed, feed.ProviderConfig.Name, types.NewReason(
+				NameInvertOrDrop, types.ReasonNotInvertible, fmt.Sprintf("Transform InvertOrDrop: %s, "+
+					"feed cannot be inverted to quotes: %s", feed.Ticker.String(), quotes)))
 			logger.Debug("dropping feed", zap.Any("feed", feed))
 		}
 
@@ -212,7 +300,7 @@ func InvertOrDrop() TransformFeed {
 // PruneByLiquidity removes feeds that do not have an associated quote config.
 //
 // If the market has a quote config, the following checks are performed:
-// - check if 24hr liquidity in USD is sufficient.
+// - check if 24hr liquidity in USD is sufficient, using the thresholds of the feed's tier if configured.
 func PruneByLiquidity() TransformFeed {
 	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig, feeds types.Feeds) (types.Feeds,
 		types.RemovalReasons, error,
@@ -230,20 +318,25 @@ func PruneByLiquidity() TransformFeed {
 			}
 
 			ticker := feed.Ticker
-			quoteConfig, found := cfg.Quotes[ticker.CurrencyPair.Quote]
+			quoteConfig, found := cfg.QuoteConfigFor(feed.Tier, ticker.CurrencyPair.Quote)
 			if found && feed.LiquidityInfo.IsSufficient(quoteConfig.MinProviderLiquidity) {
 				out = append(out, feed)
 				continue
 			}
 
-			var reason string
+			var reason types.Reason
 			if !found {
-				reason = "PruneByLiquidity: Not Found"
+				reason = types.NewReason(NamePruneByLiquidity, types.ReasonMissingQuoteConfig, "PruneByLiquidity: Not Found")
 			} else {
-				reason = fmt.Sprintf("PruneByLiquidity: NegativeDepthTwo: %f, PositiveDepthTwo: %f, "+
-					"MinProviderLiquidity: %f",
-					feed.LiquidityInfo.NegativeDepthTwo,
-					feed.LiquidityInfo.PositiveDepthTwo,
+				reason = types.NewReason(NamePruneByLiquidity, types.ReasonInsufficientLiquidity,
+					fmt.Sprintf("PruneByLiquidity: NegativeDepthTwo: %f, PositiveDepthTwo: %f, "+
+						"MinProviderLiquidity: %f",
+						feed.LiquidityInfo.NegativeDepthTwo,
+						feed.LiquidityInfo.PositiveDepthTwo,
+						quoteConfig.MinProviderLiquidity,
+					),
+				).WithThreshold(
+					min(feed.LiquidityInfo.NegativeDepthTwo, feed.LiquidityInfo.PositiveDepthTwo),
 					quoteConfig.MinProviderLiquidity,
 				)
 			}
@@ -260,7 +353,7 @@ func PruneByLiquidity() TransformFeed {
 // PruneByQuoteVolume removes feeds that do not have an associated quote config.
 //
 // If the market has a quote config, the following checks are performed:
-// - check if 24hr quote volume is sufficient.
+// - check if 24hr quote volume is sufficient, using the thresholds of the feed's tier if configured.
 func PruneByQuoteVolume() TransformFeed {
 	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig, feeds types.Feeds) (types.Feeds,
 		types.RemovalReasons, error,
@@ -277,7 +370,7 @@ func PruneByQuoteVolume() TransformFeed {
 			}
 
 			ticker := feed.Ticker
-			quoteConfig, found := cfg.Quotes[ticker.CurrencyPair.Quote]
+			quoteConfig, found := cfg.QuoteConfigFor(feed.Tier, ticker.CurrencyPair.Quote)
 
 			dailyQuoteVolumeFloat, _ := feed.DailyQuoteVolume.Float64()
 			if found && dailyQuoteVolumeFloat >= quoteConfig.MinProviderVolume {
@@ -285,11 +378,13 @@ func PruneByQuoteVolume() TransformFeed {
 				continue
 			}
 
-			var reason string
+			var reason types.Reason
 			if !found {
-				reason = "PruneByQuote: Not Found"
+				reason = types.NewReason(NamePruneByQuoteVolume, types.ReasonMissingQuoteConfig, "PruneByQuote: Not Found")
 			} else {
-				reason = fmt.Sprintf("PruneByQuote: DailyQuoteVolume: %f, MinProviderVolume: %f", feed.DailyQuoteVolume, quoteConfig.MinProviderVolume)
+				reason = types.NewReason(NamePruneByQuoteVolume, types.ReasonInsufficientVolume,
+					fmt.Sprintf("PruneByQuote: DailyQuoteVolume: %f, MinProviderVolume: %f", feed.DailyQuoteVolume, quoteConfig.MinProviderVolume),
+				).WithThreshold(dailyQuoteVolumeFloat, quoteConfig.MinProviderVolume)
 			}
 			removals.AddRemovalReasonFromFeed(feed, feed.ProviderConfig.Name, reason)
 			logger.Debug("dropping feed", zap.Any("feed", feed))
@@ -300,6 +395,33 @@ func PruneByQuoteVolume() TransformFeed {
 	}
 }
 
+// PruneByTradingStatus removes feeds whose provider market has a trading status that is excluded by the
+// trading_statuses of the config. Feeds without a trading status are treated as trading.
+func PruneByTradingStatus() TransformFeed {
+	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig, feeds types.Feeds) (types.Feeds,
+		types.RemovalReasons, error,
+	) {
+		logger.Info("pruning feeds by trading status", zap.Int("feeds", len(feeds)))
+
+		out := make([]types.Feed, 0, len(feeds))
+		removals := types.NewRemovalReasons()
+		for _, feed := range feeds {
+			status := feed.TradingStatus.OrTrading()
+			if cfg.TradingStatusAction(status) != config.TradingStatusExclude {
+				out = append(out, feed)
+				continue
+			}
+
+			removals.AddRemovalReasonFromFeed(feed, feed.ProviderConfig.Name, types.NewReason(NamePruneByTradingStatus,
+				types.ReasonTradingStatus, fmt.Sprintf("PruneByTradingStatus: trading status %s is excluded", status)))
+			logger.Debug("dropping feed", zap.Any("feed", feed))
+		}
+
+		logger.Info("pruned", zap.Int("feeds remaining", len(out)))
+		return out, removals, nil
+	}
+}
+
 // ResolveNamingAliases chooses a canonical set of Feeds that have the same TickerString()
 //
 // Group all feeds with the same TickerString together:
@@ -344,12 +466,12 @@ func ResolveNamingAliases() TransformFeed {
 					removals.AddRemovalReasonFromFeed(
 						feed,
 						feed.ProviderConfig.Name,
-						fmt.Sprintf(
+						types.NewReason(NameResolveNamingAliases, types.ReasonNamingAlias, fmt.Sprintf(
 							"removing due to naming alias for ticker %s, pair %s, CMC pair %s chosen instead",
 							tickerString,
 							feed.UniqueID(),
 							bestGroupID,
-						),
+						)),
 					)
 				}
 			}
@@ -409,14 +531,35 @@ func getHighestRankFeedGroup(feedGroups map[string]types.Feeds) (string, error)
 
 // TopFeedsForProvider chooses only the top N feeds for a provider if it has a filter set.
 // The feeds are sorted by 24hr Quote Volume and then the top N are chosen.
+// If a tier of the feeds has a TopMarkets filter set, only the top N feeds of that tier are chosen as well.
 // If no filter is set, the feeds are sorted, but no feeds will be removed.
 func TopFeedsForProvider() TransformFeed {
+	return TopFeedsForProviderWithOptions(TopFeedsOptions{})
+}
+
+// TopFeedsOptions are the params of the TopFeedsForProvider stage.
+type TopFeedsOptions struct {
+	// TopMarkets is the number of feeds retained for providers that do not set a top_markets filter.
+	// If 0, the feeds of those providers are not limited.
+	TopMarkets uint64 `json:"top_markets"`
+}
+
+// TopFeedsForProviderWithOptions is TopFeedsForProvider, limiting the feeds of providers without a top_markets
+// filter to the TopMarkets of the options.
+func TopFeedsForProviderWithOptions(opts TopFeedsOptions) TransformFeed {
 	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig, feeds types.Feeds,
 	) (types.Feeds, types.RemovalReasons, error) {
 		provFeeds := feeds.ToProviderFeeds()
 
 		removals := types.NewRemovalReasons()
 
+		tierLimits := make(map[string]uint64)
+		for _, tier := range cfg.Tiers {
+			if tier.TopMarkets != 0 {
+				tierLimits[tier.Name] = tier.TopMarkets
+			}
+		}
+
 		for provider, feedsForProvider := range provFeeds {
 			provConfig, ok := cfg.Providers[provider]
 			if !ok {
@@ -428,7 +571,11 @@ func TopFeedsForProvider() TransformFeed {
 			}
 
 			numFeedsToRetain := provConfig.Filters.TopMarkets
-			if numFeedsToRetain == 0 || uint64(len(feedsForProvider)) <= numFeedsToRetain {
+			if numFeedsToRetain == 0 {
+				numFeedsToRetain = opts.TopMarkets
+			}
+			if (numFeedsToRetain == 0 || uint64(len(feedsForProvider)) <= numFeedsToRetain) &&
+				!exceedsTierLimits(feedsForProvider, tierLimits) {
 				// in this case, we have fewer feeds than we are trying to prune to, so just keep them all
 				continue
 			}
@@ -439,21 +586,164 @@ func TopFeedsForProvider() TransformFeed {
 			// this will sort the feeds where feeds[0] has the highest quote volume
 			feedsForProvider.Sort()
 
-			// after sorting, only take top N
-			provFeeds[provider] = feedsForProvider[:numFeedsToRetain]
+			retained := make(types.Feeds, 0, len(feedsForProvider))
+			retainedPerTier := make(map[string]uint64)
+			for _, feed := range feedsForProvider {
+				var reason string
+				limit, limited := tierLimits[feed.Tier]
+				switch {
+				case numFeedsToRetain != 0 && uint64(len(retained)) >= numFeedsToRetain:
+					reason = fmt.Sprintf("only selecting top %d feeds for this provider", numFeedsToRetain)
+				case limited && retainedPerTier[feed.Tier] >= limit:
+					reason = fmt.Sprintf("only selecting top %d feeds of tier %s for this provider", limit, feed.Tier)
+				default:
+					retainedPerTier[feed.Tier]++
+					retained = append(retained, feed)
+					continue
+				}
 
-			// add removal reasons for all markets to be removed
-			for _, feed := range feedsForProvider[numFeedsToRetain:] {
+				// add removal reasons for all markets to be removed
 				logger.Debug("removing feed", zap.Any("feed", feed))
-				removals.AddRemovalReasonFromFeed(feed, provider,
-					fmt.Sprintf("only selecting top %d feeds for this provider", numFeedsToRetain))
+				removals.AddRemovalReasonFromFeed(feed, provider, types.NewReason(NameTopFeedsForProvider, types.ReasonNotTopFeed, reason))
 			}
+
+			provFeeds[provider] = retained
 		}
 
 		return provFeeds.ToFeeds(), removals, nil
 	}
 }
 
+// exceedsTierLimits returns true if the feeds contain more feeds of any tier than the tier's limit.
+func exceedsTierLimits(feeds types.Feeds, tierLimits map[string]uint64) bool {
+	if len(tierLimits) == 0 {
+		return false
+	}
+
+	perTier := make(map[string]uint64)
+	for _, feed := range feeds {
+		perTier[feed.Tier]++
+	}
+
+	for tier, count := range perTier {
+		if limit, found := tierLimits[tier]; found && count > limit {
+			return true
+		}
+	}
+
+	return false
+}
+
 func keyCurrencyPairProviderName(cp, provider string) string {
 	return strings.Join([]string{provider, cp}, "_")
 }
+
+// ApplyFeedRules evaluates the feed scoped Rules of the GenerateConfig against every feed.
+// Feeds that are matched by a drop rule are removed.
+func ApplyFeedRules() TransformFeed {
+	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig, feeds types.Feeds) (types.Feeds,
+		types.RemovalReasons, error,
+	) {
+		set, err := rules.Compile(cfg.Rules)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(set.Feed) == 0 {
+			return feeds, nil, nil
+		}
+
+		logger.Info("applying feed rules", zap.Int("feeds", len(feeds)), zap.Int("rules", len(set.Feed)))
+
+		out := make([]types.Feed, 0, len(feeds))
+		removals := types.NewRemovalReasons()
+
+	FeedLoop:
+		for _, feed := range feeds {
+			env := rules.NewFeedEnv(cfg, feed)
+			for _, rule := range set.Feed {
+				matched, err := rule.Matches(env)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if matched && rule.Action == config.RuleActionDrop {
+					logger.Debug("dropping feed", zap.String("rule", rule.ID), zap.Any("feed", feed))
+					removals.AddRemovalReasonFromFeed(feed, feed.ProviderConfig.Name, rule.Reason(NameApplyFeedRules))
+					continue FeedLoop
+				}
+			}
+
+			out = append(out, feed)
+		}
+
+		logger.Info("applied feed rules", zap.Int("feeds remaining", len(out)))
+		return out, removals, nil
+	}
+}
+
+// AssignTiers assigns each feed to the first configured tier that its market matches.
+//
+// Markets are matched using their ticker after normalization, the best CoinMarketCap rank of their base asset and
+// their total liquidity across all feeds at this stage. Feeds of markets in a tier with provider counts configured
+// use the tier's MinCexProviderCount or MinDexProviderCount as their MinProviderCount.
+func AssignTiers() TransformFeed {
+	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig, feeds types.Feeds) (types.Feeds,
+		types.RemovalReasons, error,
+	) {
+		if len(cfg.Tiers) == 0 {
+			return feeds, nil, nil
+		}
+
+		logger.Info("assigning tiers", zap.Int("feeds", len(feeds)), zap.Int("tiers", len(cfg.Tiers)))
+
+		ranks := make(map[string]int64)
+		liquidity := make(map[string]float64)
+		for _, feed := range feeds {
+			ticker := normalizedTicker(cfg, feed)
+			liquidity[ticker] += feed.LiquidityInfo.TotalLiquidity()
+			if rank, found := ranks[ticker]; feed.CMCInfo.HasRank() && (!found || feed.CMCInfo.BaseRank < rank) {
+				ranks[ticker] = feed.CMCInfo.BaseRank
+			}
+		}
+
+		out := make(types.Feeds, 0, len(feeds))
+		for _, feed := range feeds {
+			ticker := normalizedTicker(cfg, feed)
+			tier, found := cfg.TierFor(ticker, ranks[ticker], liquidity[ticker])
+			if !found {
+				feed.Tier = ""
+				out = append(out, feed)
+				continue
+			}
+
+			feed.Tier = tier.Name
+			minProviderCount := tier.MinCexProviderCount
+			if cfg.IsProviderDefi(feed.ProviderConfig.Name) {
+				minProviderCount = tier.MinDexProviderCount
+			}
+			if minProviderCount != 0 {
+				feed.Ticker.MinProviderCount = minProviderCount
+			}
+
+			logger.Debug("assigned tier", zap.String("ticker", ticker), zap.String("tier", tier.Name),
+				zap.String("provider", feed.ProviderConfig.Name))
+			out = append(out, feed)
+		}
+
+		logger.Info("assigned tiers", zap.Int("feeds", len(out)))
+		return out, nil, nil
+	}
+}
+
+// normalizedTicker returns the ticker of the feed after the NormalizeBy transform. If the normalization pair is
+// chosen from candidates, the quote of the first candidate is used.
+func normalizedTicker(cfg config.GenerateConfig, feed types.Feed) string {
+	cp := feed.Ticker.CurrencyPair
+	if quoteConfig, found := cfg.Quotes[cp.Quote]; found {
+		if quote, normalized := quoteConfig.NormalizesTo(); normalized {
+			cp.Quote = quote
+		}
+	}
+
+	return cp.String()
+}
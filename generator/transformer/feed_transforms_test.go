@@ -15,6 +15,7 @@ import (
 	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/generator/transformer"
 	"github.com/skip-mev/connect-mmu/generator/types"
+	"github.com/skip-mev/connect-mmu/store/provider"
 	mmutypes "github.com/skip-mev/connect-mmu/types"
 )
 
@@ -992,6 +993,64 @@ func TestPruneByLiquidity(t *testing.T) {
 	}
 }
 
+func TestPruneByTradingStatus(t *testing.T) {
+	withStatus := func(providerName string, status provider.TradingStatus) types.Feed {
+		feed := types.NewFeed(btcusdt, mmtypes.ProviderConfig{Name: providerName, OffChainTicker: "BTCUSDT"}, 100, 1,
+			mmutypes.LiquidityInfo{}, mmutypes.CoinMarketCapInfo{})
+		feed.TradingStatus = status
+		return feed
+	}
+
+	feeds := types.Feeds{
+		withStatus(krakenProvider, provider.TradingStatusTrading),
+		withStatus(binanceProvider, provider.TradingStatusHalted),
+		withStatus(bybitProvider, provider.TradingStatusPostOnly),
+		// feeds without a trading status are treated as trading
+		withStatus("okx_ws", ""),
+		withStatus("mexc_ws", provider.TradingStatusDepositWithdrawSuspended),
+	}
+
+	tests := []struct {
+		name      string
+		statuses  map[string]string
+		remaining []string
+	}{
+		{
+			name:      "default statuses",
+			remaining: []string{krakenProvider, "okx_ws", "mexc_ws"},
+		},
+		{
+			name: "configured statuses override defaults",
+			statuses: map[string]string{
+				string(provider.TradingStatusPostOnly):                 config.TradingStatusInclude,
+				string(provider.TradingStatusDepositWithdrawSuspended): config.TradingStatusExclude,
+			},
+			remaining: []string{krakenProvider, bybitProvider, "okx_ws"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.GenerateConfig{TradingStatuses: tc.statuses}
+
+			got, removals, err := transformer.PruneByTradingStatus()(context.Background(), zap.NewNop(), cfg, feeds)
+			require.NoError(t, err)
+
+			remaining := make([]string, 0, len(got))
+			for _, feed := range got {
+				remaining = append(remaining, feed.ProviderConfig.Name)
+			}
+			require.Equal(t, tc.remaining, remaining)
+
+			reasons := removals[btcusdt.String()]
+			require.Len(t, reasons, len(feeds)-len(tc.remaining))
+			for _, reason := range reasons {
+				require.Equal(t, types.ReasonTradingStatus, reason.Code)
+			}
+		})
+	}
+}
+
 func TestTopMarketsForProvider(t *testing.T) {
 	cfg := config.GenerateConfig{
 		Providers: map[string]config.ProviderConfig{
@@ -1153,6 +1212,8 @@ func TestTopMarketsForProvider(t *testing.T) {
 			},
 			wantRemovals: types.RemovalReasons{"BTC/USD": []types.RemovalReason{{
 				Reason:   "only selecting top 2 feeds for this provider",
+				Code:     types.ReasonNotTopFeed,
+				Stage:    transformer.NameTopFeedsForProvider,
 				Provider: krakenProvider,
 				Feed: types.Feed{
 					Ticker:           marketBtcUsd.Ticker,
@@ -1351,3 +1412,224 @@ func TestResolveNamingAliases(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyFeedRules(t *testing.T) {
+	cfg := config.GenerateConfig{
+		Rules: []config.RuleConfig{
+			{ID: "drop-low-volume-kraken", Scope: config.RuleScopeFeed, Expression: `provider == "kraken_ws" && volume < 100`, Action: config.RuleActionDrop},
+		},
+	}
+
+	feeds := types.Feeds{
+		types.NewFeed(btcusdt, mmtypes.ProviderConfig{Name: krakenProvider, OffChainTicker: "btc-usdt"}, 10, 1,
+			mmutypes.LiquidityInfo{}, mmutypes.CoinMarketCapInfo{}),
+		types.NewFeed(btcusdt, mmtypes.ProviderConfig{Name: krakenProvider, OffChainTicker: "btc-usdt"}, 1000, 1,
+			mmutypes.LiquidityInfo{}, mmutypes.CoinMarketCapInfo{}),
+		types.NewFeed(btcusdt, mmtypes.ProviderConfig{Name: binanceProvider, OffChainTicker: "BTCUSDT"}, 10, 1,
+			mmutypes.LiquidityInfo{}, mmutypes.CoinMarketCapInfo{}),
+	}
+
+	got, removals, err := transformer.ApplyFeedRules()(context.Background(), zap.NewNop(), cfg, feeds)
+	require.NoError(t, err)
+	require.Equal(t, feeds[1:], got)
+	require.Len(t, removals[btcusdt.String()], 1)
+	require.Contains(t, removals[btcusdt.String()][0].Reason, "drop-low-volume-kraken")
+}
+
+func TestAssignTiers(t *testing.T) {
+	ethusdt := mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("ETH", "USDT"), Decimals: 8, MinProviderCount: 1}
+	mogusd := mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("MOG", "USD"), Decimals: 8, MinProviderCount: 1}
+
+	cfg := config.GenerateConfig{
+		Providers: map[string]config.ProviderConfig{
+			krakenProvider:  {},
+			binanceProvider: {},
+			"dex":           {IsDefi: true},
+		},
+		Quotes: map[string]config.QuoteConfig{
+			"USD":  {MinProviderVolume: 10},
+			"USDT": {MinProviderVolume: 10, NormalizeByPair: "USDT/USD"},
+		},
+		Tiers: []config.TierConfig{
+			{Name: "core", Tickers: []string{"BTC/USD"}, MinCexProviderCount: 3, MinDexProviderCount: 2},
+			{Name: "mid", MaxCMCRank: 100, MinLiquidity: 1000},
+			{Name: "long-tail"},
+		},
+	}
+
+	feeds := types.Feeds{
+		// BTC/USDT is normalized to BTC/USD, which is explicitly in the core tier
+		types.NewFeed(btcusdt, mmtypes.ProviderConfig{Name: krakenProvider}, 100, 1,
+			mmutypes.LiquidityInfo{}, mmutypes.NewCoinMarketCapInfo(1, 825, 1, 3)),
+		types.NewFeed(btcusd, mmtypes.ProviderConfig{Name: "dex"}, 100, 1,
+			mmutypes.LiquidityInfo{}, mmutypes.NewCoinMarketCapInfo(1, 2781, 1, 0)),
+		// ETH/USD only has sufficient liquidity across both feeds
+		types.NewFeed(ethusdt, mmtypes.ProviderConfig{Name: krakenProvider}, 100, 1,
+			mmutypes.LiquidityInfo{NegativeDepthTwo: 300, PositiveDepthTwo: 300}, mmutypes.NewCoinMarketCapInfo(2, 825, 2, 3)),
+		types.NewFeed(ethusdt, mmtypes.ProviderConfig{Name: binanceProvider}, 100, 1,
+			mmutypes.LiquidityInfo{NegativeDepthTwo: 300, PositiveDepthTwo: 300}, mmutypes.NewCoinMarketCapInfo(2, 825, 2, 3)),
+		types.NewFeed(mogusd, mmtypes.ProviderConfig{Name: binanceProvider}, 100, 1,
+			mmutypes.LiquidityInfo{NegativeDepthTwo: 1e6, PositiveDepthTwo: 1e6}, mmutypes.NewCoinMarketCapInfo(3, 2781, 500, 0)),
+	}
+
+	got, removals, err := transformer.AssignTiers()(context.Background(), zap.NewNop(), cfg, feeds)
+	require.NoError(t, err)
+	require.Empty(t, removals)
+	require.Len(t, got, len(feeds))
+
+	require.Equal(t, "core", got[0].Tier)
+	require.Equal(t, uint64(3), got[0].Ticker.MinProviderCount)
+	require.Equal(t, "core", got[1].Tier)
+	require.Equal(t, uint64(2), got[1].Ticker.MinProviderCount)
+	require.Equal(t, "mid", got[2].Tier)
+	require.Equal(t, uint64(1), got[2].Ticker.MinProviderCount)
+	require.Equal(t, "mid", got[3].Tier)
+	require.Equal(t, "long-tail", got[4].Tier)
+
+	// tickers are not modified
+	require.Equal(t, btcusdt.String(), got[0].Ticker.String())
+
+	// without tiers, the transform is a no-op
+	cfg.Tiers = nil
+	got, _, err = transformer.AssignTiers()(context.Background(), zap.NewNop(), cfg, feeds)
+	require.NoError(t, err)
+	require.Equal(t, feeds, got)
+}
+
+func TestTierThresholdsAndLimits(t *testing.T) {
+	cfg := config.GenerateConfig{
+		Providers: map[string]config.ProviderConfig{
+			krakenProvider: {Filters: config.Filters{TopMarkets: 3}},
+		},
+		Quotes: map[string]config.QuoteConfig{
+			"USD": {MinProviderVolume: 10, MinProviderLiquidity: 10},
+		},
+		Tiers: []config.TierConfig{
+			{
+				Name:   "core",
+				Quotes: map[string]config.TierThresholds{"USD": {MinProviderVolume: 1000, MinProviderLiquidity: 1000}},
+			},
+			{Name: "long-tail", TopMarkets: 1},
+		},
+	}
+
+	newFeed := func(base, tier string, volume float64, rank int64) types.Feed {
+		ticker := mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair(base, "USD"), Decimals: 8, MinProviderCount: 1}
+		feed := types.NewFeed(ticker, mmtypes.ProviderConfig{Name: krakenProvider}, volume, 1,
+			mmutypes.LiquidityInfo{NegativeDepthTwo: 500, PositiveDepthTwo: 500}, mmutypes.NewCoinMarketCapInfo(rank, 2781, rank, 0))
+		feed.Tier = tier
+		return feed
+	}
+
+	t.Run("tier thresholds", func(t *testing.T) {
+		feeds := types.Feeds{
+			newFeed("BTC", "core", 500, 1),
+			newFeed("MOG", "long-tail", 500, 2),
+			newFeed("PEPE", "", 500, 3),
+		}
+
+		got, removals, err := transformer.PruneByQuoteVolume()(context.Background(), zap.NewNop(), cfg, feeds)
+		require.NoError(t, err)
+		require.Equal(t, feeds[1:], got)
+		require.Equal(t, 1000.0, *removals["BTC/USD"][0].Params.Threshold)
+
+		got, removals, err = transformer.PruneByLiquidity()(context.Background(), zap.NewNop(), cfg, feeds)
+		require.NoError(t, err)
+		require.Equal(t, feeds[1:], got)
+		require.Equal(t, types.ReasonInsufficientLiquidity, removals["BTC/USD"][0].Code)
+	})
+
+	t.Run("tier top markets", func(t *testing.T) {
+		feeds := types.Feeds{
+			newFeed("BTC", "core", 500, 1),
+			newFeed("MOG", "long-tail", 500, 2),
+			newFeed("PEPE", "long-tail", 500, 3),
+			newFeed("ETH", "core", 500, 4),
+			newFeed("SOL", "core", 500, 5),
+		}
+
+		got, removals, err := transformer.TopFeedsForProvider()(context.Background(), zap.NewNop(), cfg, feeds)
+		require.NoError(t, err)
+		require.Equal(t, types.Feeds{feeds[0], feeds[1], feeds[3]}, got)
+		require.Contains(t, removals["PEPE/USD"][0].Reason, "tier long-tail")
+		require.Contains(t, removals["SOL/USD"][0].Reason, "top 3 feeds")
+	})
+
+	t.Run("stage top markets for providers without a filter", func(t *testing.T) {
+		cfg := cfg
+		cfg.Tiers = nil
+		cfg.Providers = map[string]config.ProviderConfig{krakenProvider: {}}
+
+		feeds := types.Feeds{
+			newFeed("BTC", "", 500, 1),
+			newFeed("ETH", "", 500, 2),
+		}
+
+		got, _, err := transformer.TopFeedsForProvider()(context.Background(), zap.NewNop(), cfg, feeds)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+
+		transform := transformer.TopFeedsForProviderWithOptions(transformer.TopFeedsOptions{TopMarkets: 1})
+		got, removals, err := transform(context.Background(), zap.NewNop(), cfg, feeds)
+		require.NoError(t, err)
+		require.Equal(t, types.Feeds{feeds[0]}, got)
+		require.Contains(t, removals["ETH/USD"][0].Reason, "top 1 feeds")
+	})
+}
+
+func TestNormalizeByCandidates(t *testing.T) {
+	usdtDeFi := connecttypes.NewCurrencyPair("USDT,UNISWAP_V3,0XDAC17F958D2EE523A2206206994597C13D831EC7", "USD")
+	btcusdc := mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USDC"), Decimals: 8, MinProviderCount: 1}
+
+	cfg := config.GenerateConfig{
+		Quotes: map[string]config.QuoteConfig{
+			"USD":  {},
+			"USDT": {NormalizeByCandidates: []string{"USDT/USD", usdtDeFi.String()}},
+			"USDC": {NormalizeByCandidates: []string{"USDC/USD"}},
+			"EUR":  {},
+		},
+	}
+
+	usdtdefi := mmtypes.Ticker{CurrencyPair: usdtDeFi, Decimals: 8, MinProviderCount: 1}
+	liquidity := func(l float64) mmutypes.LiquidityInfo {
+		return mmutypes.LiquidityInfo{NegativeDepthTwo: l, PositiveDepthTwo: l}
+	}
+
+	feeds := types.Feeds{
+		types.NewFeed(usdtusd, mmtypes.ProviderConfig{Name: binanceProvider, OffChainTicker: "USDTUSD"}, 1, 1,
+			liquidity(1000), mmutypes.CoinMarketCapInfo{}),
+		types.NewFeed(usdtdefi, mmtypes.ProviderConfig{Name: krakenProvider, OffChainTicker: "USDTUSD.DEFI"}, 1, 1,
+			liquidity(10), mmutypes.CoinMarketCapInfo{}),
+		// kraken prefers its own DeFi USDT market over the more liquid USDT/USD market of binance
+		types.NewFeed(btcusdt, mmtypes.ProviderConfig{Name: krakenProvider, OffChainTicker: "XBTUSDT"}, 1, 1,
+			liquidity(10), mmutypes.CoinMarketCapInfo{}),
+		// bybit has neither market, so the most liquid candidate is chosen
+		types.NewFeed(btcusdt, mmtypes.ProviderConfig{Name: bybitProvider, OffChainTicker: "BTCUSDT"}, 1, 1,
+			liquidity(10), mmutypes.CoinMarketCapInfo{}),
+		// no USDC/USD feeds exist, so the feed is dropped
+		types.NewFeed(btcusdc, mmtypes.ProviderConfig{Name: bybitProvider, OffChainTicker: "BTCUSDC"}, 1, 1,
+			liquidity(10), mmutypes.CoinMarketCapInfo{}),
+	}
+
+	got, removals, err := transformer.NormalizeBy()(context.Background(), zap.NewNop(), cfg, feeds)
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+
+	require.Equal(t, "BTC/USD", got[2].Ticker.String())
+	require.Equal(t, usdtDeFi.String(), got[2].ProviderConfig.NormalizeByPair.String())
+	require.True(t, got[2].NormalizeBySelected)
+
+	require.Equal(t, "BTC/USD", got[3].Ticker.String())
+	require.Equal(t, "USDT/USD", got[3].ProviderConfig.NormalizeByPair.String())
+	require.True(t, got[3].NormalizeBySelected)
+
+	require.Len(t, removals[btcusdc.String()], 1)
+	require.Equal(t, types.ReasonNoNormalizationRoute, removals[btcusdc.String()][0].Code)
+
+	// the selected routes are recorded in the ticker metadata
+	mm, err := got.ToMarketMap()
+	require.NoError(t, err)
+	md, err := types.TickerMetadataFromJSON(mm.Markets["BTC/USD"].Ticker.Metadata_JSON)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{krakenProvider: usdtDeFi.String(), bybitProvider: "USDT/USD"}, md.NormalizeBy)
+}
@@ -0,0 +1,98 @@
+package transformer
+
+import (
+	"context"
+	"slices"
+
+	"golang.org/x/exp/maps"
+)
+
+// MarketStates is generator-side state of the markets of a market map that is shared between the market map stages
+// of a Transformer. It is never written to the market map, so that it does not change the markets that are
+// broadcast on chain.
+type MarketStates struct {
+	markets map[string]*marketState
+}
+
+// marketState is the state of a single market, keyed by its ticker in MarketStates.
+type marketState struct {
+	// supplemental are the providers of the market that were marked supplemental by rules.
+	supplemental map[string]struct{}
+	// minProviderCountRule is the ID of the rule that set the MinProviderCount of the market.
+	minProviderCountRule string
+}
+
+type marketStatesKey struct{}
+
+// NewMarketStates creates a new, empty MarketStates instance.
+func NewMarketStates() *MarketStates {
+	return &MarketStates{markets: make(map[string]*marketState)}
+}
+
+// WithMarketStates returns a copy of ctx that carries the given MarketStates to the market map stages run with it.
+func WithMarketStates(ctx context.Context, states *MarketStates) context.Context {
+	return context.WithValue(ctx, marketStatesKey{}, states)
+}
+
+// marketStatesFrom returns the MarketStates carried by ctx. If ctx carries none, empty MarketStates are returned, so
+// that stages run on their own behave as if no rules were applied.
+func marketStatesFrom(ctx context.Context) *MarketStates {
+	if states, ok := ctx.Value(marketStatesKey{}).(*MarketStates); ok && states != nil {
+		return states
+	}
+	return NewMarketStates()
+}
+
+// SupplementalProviders returns the sorted providers of the market that were marked supplemental by rules.
+func (s *MarketStates) SupplementalProviders(ticker string) []string {
+	state, found := s.markets[ticker]
+	if !found || len(state.supplemental) == 0 {
+		return nil
+	}
+
+	providers := maps.Keys(state.supplemental)
+	slices.Sort(providers)
+	return providers
+}
+
+// MinProviderCountRule returns the ID of the rule that set the MinProviderCount of the market, or an empty string if
+// no rule set it.
+func (s *MarketStates) MinProviderCountRule(ticker string) string {
+	if state, found := s.markets[ticker]; found {
+		return state.minProviderCountRule
+	}
+	return ""
+}
+
+// markSupplemental records the providers of the market as supplemental.
+func (s *MarketStates) markSupplemental(ticker string, providers ...string) {
+	state := s.state(ticker)
+	if state.supplemental == nil {
+		state.supplemental = make(map[string]struct{})
+	}
+	for _, provider := range providers {
+		state.supplemental[provider] = struct{}{}
+	}
+}
+
+// setMinProviderCountRule records the ID of the rule that set the MinProviderCount of the market.
+func (s *MarketStates) setMinProviderCountRule(ticker, rule string) {
+	s.state(ticker).minProviderCountRule = rule
+}
+
+// rename moves the state of the market to its new ticker.
+func (s *MarketStates) rename(oldTicker, newTicker string) {
+	if state, found := s.markets[oldTicker]; found && oldTicker != newTicker {
+		delete(s.markets, oldTicker)
+		s.markets[newTicker] = state
+	}
+}
+
+func (s *MarketStates) state(ticker string) *marketState {
+	state, found := s.markets[ticker]
+	if !found {
+		state = &marketState{}
+		s.markets[ticker] = state
+	}
+	return state
+}
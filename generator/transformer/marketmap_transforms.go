@@ -2,6 +2,7 @@ package transformer
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"strings"
@@ -9,9 +10,12 @@ import (
 	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
 
 	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator/rules"
 	"github.com/skip-mev/connect-mmu/generator/types"
+	"github.com/skip-mev/connect-mmu/lib/tickerformat"
 )
 
 // TransformMarketMap is a function that performs some transformation on a marketmap.
@@ -32,16 +36,22 @@ func OverrideMarkets() TransformMarketMap {
 	}
 }
 
-// OverrideMinProviderCount will wholesale replace the MinProviderCount value for each Market's Ticker.
+// OverrideMinProviderCount will wholesale replace the MinProviderCount value for each Market's Ticker, except for
+// markets whose MinProviderCount was set by a rule.
 // This would be run before the OverrideMarkets transform so that specific MinProviderCount values in overridden markets
 // are preserved.
 func OverrideMinProviderCount() TransformMarketMap {
-	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig, mm mmtypes.MarketMap) (mmtypes.MarketMap, types.RemovalReasons, error) {
+	return func(ctx context.Context, logger *zap.Logger, cfg config.GenerateConfig, mm mmtypes.MarketMap) (mmtypes.MarketMap, types.RemovalReasons, error) {
 		if cfg.MinProviderCountOverride == 0 {
 			return mm, nil, nil
 		}
 		logger.Info("overriding min provider count")
+		states := marketStatesFrom(ctx)
 		for name, market := range mm.Markets {
+			if rule := states.MinProviderCountRule(name); rule != "" {
+				logger.Debug("keeping min provider count set by rule", zap.String("market", name), zap.String("rule", rule))
+				continue
+			}
 			market.Ticker.MinProviderCount = cfg.MinProviderCountOverride
 			mm.Markets[name] = market
 		}
@@ -49,18 +59,79 @@ func OverrideMinProviderCount() TransformMarketMap {
 	}
 }
 
+// ApplyMinProviderCountPolicy sets the MinProviderCount of each market using the MinProviderCountPolicy of the
+// GenerateConfig, with the rule of the market's tier if configured. Only non-supplemental providers are counted
+// towards the policy.
+//
+// The result is capped to the number of providers that are guaranteed to remain after PruneNormalizeByPairs, i.e.
+// non-supplemental providers without a NormalizeByPair and non-supplemental providers whose NormalizeByPair market
+// exists and is enabled, so that markets are always able to post prices. The cap is never below 1. This would be
+// run after OverrideMinProviderCount so that the policy takes precedence.
+//
+// Markets whose MinProviderCount was set by a rule keep it.
+func ApplyMinProviderCountPolicy() TransformMarketMap {
+	return func(ctx context.Context, logger *zap.Logger, cfg config.GenerateConfig, mm mmtypes.MarketMap) (mmtypes.MarketMap, types.RemovalReasons, error) {
+		if cfg.MinProviderCountPolicy == nil {
+			return mm, nil, nil
+		}
+
+		logger.Info("applying min provider count policy")
+		states := marketStatesFrom(ctx)
+		for name, market := range mm.Markets {
+			if rule := states.MinProviderCountRule(name); rule != "" {
+				logger.Debug("keeping min provider count set by rule", zap.String("market", name), zap.String("rule", rule))
+				continue
+			}
+
+			supplemental := supplementalProviders(cfg, states, name, market)
+			var cexProviders, dexProviders, guaranteed uint64
+			for _, pc := range market.ProviderConfigs {
+				if _, found := supplemental[pc.Name]; found {
+					continue
+				}
+
+				if pc.NormalizeByPair == nil {
+					guaranteed++
+				} else if norm, found := mm.Markets[pc.NormalizeByPair.String()]; found && norm.Ticker.Enabled {
+					guaranteed++
+				}
+
+				if cfg.IsProviderDefi(pc.Name) {
+					dexProviders++
+				} else {
+					cexProviders++
+				}
+			}
+
+			rule := cfg.MinProviderCountPolicy.RuleFor(name, types.MarketTier(market), market.Ticker.CurrencyPair.Quote)
+			minProviderCount := rule.MinProviderCount(cexProviders, dexProviders)
+			if limit := max(guaranteed, 1); minProviderCount > limit {
+				logger.Debug("capping min provider count to providers remaining after normalize by pruning",
+					zap.String("market", name), zap.Uint64("policy", minProviderCount), zap.Uint64("cap", limit))
+				minProviderCount = limit
+			}
+
+			market.Ticker.MinProviderCount = minProviderCount
+			mm.Markets[name] = market
+		}
+
+		return mm, nil, nil
+	}
+}
+
 // PruneInsufficientlyProvidedMarkets removes markets that did not have the minimum amount of providers.
 func PruneInsufficientlyProvidedMarkets() TransformMarketMap {
-	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig, mm mmtypes.MarketMap) (mmtypes.MarketMap, types.RemovalReasons, error) {
+	return func(ctx context.Context, logger *zap.Logger, cfg config.GenerateConfig, mm mmtypes.MarketMap) (mmtypes.MarketMap, types.RemovalReasons, error) {
 		logger.Info("pruning insufficiently provided markets")
 
 		removals := types.NewRemovalReasons()
+		states := marketStatesFrom(ctx)
 		for key, market := range mm.Markets {
 
+			supplemental := supplementalProviders(cfg, states, key, market)
 			providers := uint64(0)
 			for _, provider := range market.ProviderConfigs {
-				providerConfig := cfg.Providers[provider.Name]
-				if !providerConfig.IsSupplemental {
+				if _, found := supplemental[provider.Name]; !found {
 					providers++
 				}
 			}
@@ -70,8 +141,10 @@ func PruneInsufficientlyProvidedMarkets() TransformMarketMap {
 				for i, providerConfig := range market.ProviderConfigs {
 					providerNames[i] = providerConfig.Name
 				}
-				removals.AddRemovalReasonFromMarket(market, market.Ticker.CurrencyPair.String(),
-					fmt.Sprintf("PruneInsufficientlyProvidedMarkets: insufficient # of providers: %s, min: %d", strings.Join(providerNames, ","), market.Ticker.MinProviderCount))
+				removals.AddRemovalReasonFromMarket(market, market.Ticker.CurrencyPair.String(), types.NewReason(
+					NamePruneInsufficientlyProvidedMarkets, types.ReasonInsufficientProviders,
+					fmt.Sprintf("PruneInsufficientlyProvidedMarkets: insufficient # of providers: %s, min: %d", strings.Join(providerNames, ","), market.Ticker.MinProviderCount),
+				).WithThreshold(float64(providers), float64(market.Ticker.MinProviderCount)))
 				delete(mm.Markets, key)
 			}
 		}
@@ -90,8 +163,9 @@ func PruneMarkets() TransformMarketMap {
 		for name, market := range mm.Markets {
 			if !cfg.IsCurrencyPairAllowed(market.Ticker.CurrencyPair) {
 				logger.Debug("removing market", zap.String("name", name))
-				removals.AddRemovalReasonFromMarket(market, market.Ticker.CurrencyPair.String(),
-					fmt.Sprintf("PruneMarkets: disallowed currency pair: %s", market.Ticker.CurrencyPair.String()))
+				removals.AddRemovalReasonFromMarket(market, market.Ticker.CurrencyPair.String(), types.NewReason(
+					NamePruneMarkets, types.ReasonDisallowedCurrencyPair,
+					fmt.Sprintf("PruneMarkets: disallowed currency pair: %s", market.Ticker.CurrencyPair.String())))
 				delete(mm.Markets, name)
 			}
 		}
@@ -119,7 +193,8 @@ func RemoveDisabledProviders() TransformMarketMap {
 					updatedProviders = append(updatedProviders, provider)
 				} else {
 					logger.Debug("RemoveDisabledProviders: removing provider", zap.String("provider", provider.Name), zap.String("market", ticker))
-					removals.AddRemovalReasonFromMarket(m, provider.Name, fmt.Sprintf("RemoveDisabledProviders: provider %q is disabled for market %q", provider.Name, ticker))
+					removals.AddRemovalReasonFromMarket(m, provider.Name, types.NewReason(NameRemoveDisabledProviders, types.ReasonProviderDisabled,
+						fmt.Sprintf("RemoveDisabledProviders: provider %q is disabled for market %q", provider.Name, ticker)))
 				}
 			}
 			m.ProviderConfigs = updatedProviders
@@ -129,7 +204,32 @@ func RemoveDisabledProviders() TransformMarketMap {
 	}
 }
 
+// ValidateOffChainTickers removes the providers of each market whose off-chain ticker does not match the format
+// registered for the provider, so that malformed tickers from ingesters are caught before they reach the chain.
+func ValidateOffChainTickers() TransformMarketMap {
+	return func(_ context.Context, logger *zap.Logger, _ config.GenerateConfig, mm mmtypes.MarketMap) (mmtypes.MarketMap, types.RemovalReasons, error) {
+		removals := types.NewRemovalReasons()
+		for name, market := range mm.Markets {
+			validProviders := make([]mmtypes.ProviderConfig, 0, len(market.ProviderConfigs))
+			for _, provider := range market.ProviderConfigs {
+				if err := tickerformat.Validate(provider.Name, provider.OffChainTicker); err != nil {
+					logger.Debug("ValidateOffChainTickers: removing provider", zap.String("provider", provider.Name),
+						zap.String("market", name), zap.Error(err))
+					removals.AddRemovalReasonFromMarket(market, provider.Name, types.NewReason(NameValidateOffChainTickers,
+						types.ReasonInvalidOffChainTicker, fmt.Sprintf("ValidateOffChainTickers: %v", err)))
+					continue
+				}
+				validProviders = append(validProviders, provider)
+			}
+			market.ProviderConfigs = validProviders
+			mm.Markets[name] = market
+		}
+		return mm, removals, nil
+	}
+}
+
 // EnableMarkets enabled markets based on the GenerateConfig rules.
+// Markets in a tier with an enablement policy are enabled or disabled according to the tier, regardless of EnableAll.
 func EnableMarkets() TransformMarketMap {
 	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig,
 		mm mmtypes.MarketMap,
@@ -140,8 +240,19 @@ func EnableMarkets() TransformMarketMap {
 				market.Ticker.Enabled = true
 				mm.Markets[name] = market
 			}
+		}
 
-			return mm, nil, nil
+		if len(cfg.Tiers) > 0 {
+			logger.Info("applying tier enablement policies")
+			for name, market := range mm.Markets {
+				tier, found := cfg.Tier(types.MarketTier(market))
+				if !found || tier.Enabled == nil {
+					continue
+				}
+
+				market.Ticker.Enabled = *tier.Enabled
+				mm.Markets[name] = market
+			}
 		}
 
 		return mm, nil, nil
@@ -169,10 +280,11 @@ func replaceNormalizeBy(mm mmtypes.MarketMap, oldNormalizeBy, newNormalizeBy con
 
 // ProcessDefiMarkets adds defi tickers to markets that are defi and only have one provider.
 func ProcessDefiMarkets() TransformMarketMap {
-	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig,
+	return func(ctx context.Context, logger *zap.Logger, cfg config.GenerateConfig,
 		mm mmtypes.MarketMap,
 	) (mmtypes.MarketMap, types.RemovalReasons, error) {
 		logger.Info("processing defi markets", zap.Int("num markets", len(mm.Markets)))
+		states := marketStatesFrom(ctx)
 
 		for name, market := range mm.Markets {
 			if len(market.ProviderConfigs) == 1 && cfg.IsProviderDefi(market.ProviderConfigs[0].Name) {
@@ -201,6 +313,7 @@ func ProcessDefiMarkets() TransformMarketMap {
 
 				market.Ticker.CurrencyPair = cp
 				mm.Markets[market.Ticker.String()] = market
+				states.rename(name, market.Ticker.String())
 				mm = replaceNormalizeBy(mm, oldCp, cp)
 			}
 		}
@@ -209,3 +322,397 @@ func ProcessDefiMarkets() TransformMarketMap {
 		return mm, nil, nil
 	}
 }
+
+// ApplyMarketRules evaluates the market and provider scoped Rules of the GenerateConfig against every market.
+//
+// Market rules are applied first:
+//   - drop removes the market.
+//   - set_min_provider_count sets the MinProviderCount of the market and records the rule in the MarketStates of
+//     the context, so that OverrideMinProviderCount and ApplyMinProviderCountPolicy keep the value.
+//
+// Provider rules are then applied to each provider config of the remaining markets:
+//   - disable_provider removes the provider config from the market.
+//   - mark_supplemental records the provider as supplemental in the MarketStates of the context, which excludes
+//     it from the market's provider count in all later stages. Markets whose remaining non-supplemental providers
+//     fall below their MinProviderCount are removed.
+//
+// Every match is recorded in the returned RemovalReasons with the ID of the rule: matches that remove a market or
+// provider as a RULE_MATCHED removal, and all other matches as RULE_APPLIED.
+func ApplyMarketRules() TransformMarketMap {
+	return func(ctx context.Context, logger *zap.Logger, cfg config.GenerateConfig, mm mmtypes.MarketMap) (mmtypes.MarketMap, types.RemovalReasons, error) {
+		set, err := rules.Compile(cfg.Rules)
+		if err != nil {
+			return mmtypes.MarketMap{}, nil, err
+		}
+		if len(set.Market) == 0 && len(set.Provider) == 0 {
+			return mm, nil, nil
+		}
+
+		logger.Info("applying market rules", zap.Int("markets", len(mm.Markets)),
+			zap.Int("market rules", len(set.Market)), zap.Int("provider rules", len(set.Provider)))
+
+		removals := types.NewRemovalReasons()
+		states := marketStatesFrom(ctx)
+	MarketLoop:
+		for name, market := range mm.Markets {
+			for _, rule := range set.Market {
+				matched, err := rule.Matches(rules.NewMarketEnv(cfg, market))
+				if err != nil {
+					return mmtypes.MarketMap{}, nil, err
+				}
+				if !matched {
+					continue
+				}
+
+				switch rule.Action {
+				case config.RuleActionDrop:
+					logger.Debug("dropping market", zap.String("rule", rule.ID), zap.String("name", name))
+					removals.AddRemovalReasonFromMarket(market, market.Ticker.CurrencyPair.String(), rule.Reason(NameApplyMarketRules))
+					delete(mm.Markets, name)
+					continue MarketLoop
+				case config.RuleActionSetMinProviderCount:
+					logger.Debug("setting min provider count", zap.String("rule", rule.ID), zap.String("name", name),
+						zap.Uint64("min provider count", rule.MinProviderCount))
+					market.Ticker.MinProviderCount = rule.MinProviderCount
+					states.setMinProviderCountRule(name, rule.ID)
+					removals.AddRemovalReasonFromMarket(market, market.Ticker.CurrencyPair.String(), rule.AppliedReason(NameApplyMarketRules))
+				}
+			}
+
+			if len(set.Provider) > 0 {
+				marketEnv := rules.NewMarketEnv(cfg, market)
+				providerConfigs := make([]mmtypes.ProviderConfig, 0, len(market.ProviderConfigs))
+				marked := make(map[string]struct{})
+
+			ProviderLoop:
+				for _, pc := range market.ProviderConfigs {
+					env := rules.NewProviderEnv(cfg, marketEnv, pc)
+					for _, rule := range set.Provider {
+						matched, err := rule.Matches(env)
+						if err != nil {
+							return mmtypes.MarketMap{}, nil, err
+						}
+						if !matched {
+							continue
+						}
+
+						switch rule.Action {
+						case config.RuleActionDisableProvider:
+							logger.Debug("removing provider", zap.String("rule", rule.ID),
+								zap.String("provider", pc.Name), zap.String("name", name))
+							removals.AddRemovalReasonFromMarket(market, pc.Name, rule.Reason(NameApplyMarketRules))
+							continue ProviderLoop
+						case config.RuleActionMarkSupplemental:
+							marked[pc.Name] = struct{}{}
+							removals.AddRemovalReasonFromMarket(market, pc.Name, rule.AppliedReason(NameApplyMarketRules))
+						}
+					}
+
+					providerConfigs = append(providerConfigs, pc)
+				}
+				market.ProviderConfigs = providerConfigs
+
+				if len(marked) > 0 {
+					states.markSupplemental(name, maps.Keys(marked)...)
+
+					supplemental := supplementalProviders(cfg, states, name, market)
+					providers := uint64(0)
+					for _, pc := range market.ProviderConfigs {
+						if _, found := supplemental[pc.Name]; !found {
+							providers++
+						}
+					}
+
+					if providers < market.Ticker.MinProviderCount {
+						logger.Debug("dropping market with insufficient non-supplemental providers", zap.String("name", name))
+						removals.AddRemovalReasonFromMarket(market, market.Ticker.CurrencyPair.String(), types.NewReason(
+							NameApplyMarketRules, types.ReasonInsufficientProviders,
+							fmt.Sprintf("ApplyMarketRules: insufficient # of non-supplemental providers: %d, min: %d",
+								providers, market.Ticker.MinProviderCount),
+						).WithThreshold(float64(providers), float64(market.Ticker.MinProviderCount)))
+						delete(mm.Markets, name)
+						continue
+					}
+				}
+			}
+
+			mm.Markets[name] = market
+		}
+
+		logger.Info("market size after applying market rules", zap.Int("size", len(mm.Markets)))
+		return mm, removals, nil
+	}
+}
+
+// supplementalProviders returns the providers of the market that are not counted towards its provider count: the
+// providers that are configured as supplemental, and the providers marked supplemental by rules.
+func supplementalProviders(cfg config.GenerateConfig, states *MarketStates, name string, market mmtypes.Market) map[string]struct{} {
+	supplemental := make(map[string]struct{})
+	for _, provider := range states.SupplementalProviders(name) {
+		supplemental[provider] = struct{}{}
+	}
+	for _, pc := range market.ProviderConfigs {
+		if cfg.Providers[pc.Name].IsSupplemental {
+			supplemental[pc.Name] = struct{}{}
+		}
+	}
+	return supplemental
+}
+
+// EnforceProviderDiversity enforces the Diversity configuration of the GenerateConfig on the non-supplemental
+// providers of every market:
+//   - DeFi providers of enabled markets are removed, last provider first, until their share no longer exceeds
+//     MaxDefiShare. Markets that are left with fewer providers than their MinProviderCount are removed.
+//   - markets whose providers belong to fewer distinct groups than required for any group dimension are removed.
+func EnforceProviderDiversity() TransformMarketMap {
+	return func(ctx context.Context, logger *zap.Logger, cfg config.GenerateConfig, mm mmtypes.MarketMap) (mmtypes.MarketMap, types.RemovalReasons, error) {
+		if cfg.Diversity == nil {
+			return mm, nil, nil
+		}
+
+		logger.Info("enforcing provider diversity")
+
+		dimensions := maps.Keys(cfg.Diversity.MinDistinctGroups)
+		slices.Sort(dimensions)
+
+		removals := types.NewRemovalReasons()
+		states := marketStatesFrom(ctx)
+		for name, market := range mm.Markets {
+			supplemental := supplementalProviders(cfg, states, name, market)
+			providers := make([]string, 0, len(market.ProviderConfigs))
+			for _, pc := range market.ProviderConfigs {
+				if _, found := supplemental[pc.Name]; !found {
+					providers = append(providers, pc.Name)
+				}
+			}
+
+			if market.Ticker.Enabled && cfg.Diversity.MaxDefiShare > 0 {
+				var trimmed []trimmedProvider
+				market, providers, trimmed = trimDefiProviders(cfg, market, providers)
+				for _, t := range trimmed {
+					logger.Debug("removing defi provider exceeding the max defi share", zap.String("name", name),
+						zap.String("provider", t.name))
+					removals.AddRemovalReasonFromMarket(market, t.name, types.NewReason(NameEnforceProviderDiversity,
+						types.ReasonDefiShareExceeded,
+						fmt.Sprintf("EnforceProviderDiversity: removed defi provider %s, share: %g, max share: %g",
+							t.name, t.share, cfg.Diversity.MaxDefiShare),
+					).WithThreshold(t.share, cfg.Diversity.MaxDefiShare))
+				}
+
+				if len(trimmed) > 0 && uint64(len(providers)) < market.Ticker.MinProviderCount {
+					logger.Debug("removing market with insufficient providers after removing defi providers",
+						zap.String("name", name))
+					removals.AddRemovalReasonFromMarket(market, market.Ticker.CurrencyPair.String(), types.NewReason(
+						NameEnforceProviderDiversity, types.ReasonInsufficientProviders,
+						fmt.Sprintf("EnforceProviderDiversity: insufficient # of providers after removing defi providers: %d, min: %d",
+							len(providers), market.Ticker.MinProviderCount),
+					).WithThreshold(float64(len(providers)), float64(market.Ticker.MinProviderCount)))
+					delete(mm.Markets, name)
+					continue
+				}
+			}
+
+			var reasons []types.Reason
+			for _, dimension := range dimensions {
+				groups := make(map[string]struct{})
+				for _, provider := range providers {
+					groups[cfg.ProviderGroup(provider, dimension)] = struct{}{}
+				}
+
+				minGroups := cfg.Diversity.MinDistinctGroups[dimension]
+				if uint64(len(groups)) < minGroups {
+					reasons = append(reasons, types.NewReason(NameEnforceProviderDiversity, types.ReasonInsufficientProviderDiversity,
+						fmt.Sprintf("EnforceProviderDiversity: providers %s belong to %d distinct %s groups, min: %d",
+							strings.Join(providers, ","), len(groups), dimension, minGroups),
+					).WithThreshold(float64(len(groups)), float64(minGroups)).WithDimension(dimension))
+				}
+			}
+
+			if len(reasons) == 0 {
+				mm.Markets[name] = market
+				continue
+			}
+
+			logger.Debug("removing market with insufficient provider diversity", zap.String("name", name))
+			for _, reason := range reasons {
+				removals.AddRemovalReasonFromMarket(market, market.Ticker.CurrencyPair.String(), reason)
+			}
+			delete(mm.Markets, name)
+		}
+
+		logger.Info("market size after enforcing provider diversity", zap.Int("size", len(mm.Markets)))
+		return mm, removals, nil
+	}
+}
+
+// trimmedProvider is a DeFi provider removed by trimDefiProviders, with the share of DeFi providers before it was
+// removed.
+type trimmedProvider struct {
+	name  string
+	share float64
+}
+
+// trimDefiProviders removes the last DeFi providers of the market until the share of DeFi providers among the given
+// non-supplemental providers no longer exceeds the MaxDefiShare. The market, its remaining non-supplemental
+// providers and the removed providers are returned.
+func trimDefiProviders(cfg config.GenerateConfig, market mmtypes.Market, providers []string) (mmtypes.Market, []string, []trimmedProvider) {
+	var trimmed []trimmedProvider
+	for i := len(providers) - 1; i >= 0 && len(providers) > 0; i-- {
+		share := defiShare(cfg, providers)
+		if share <= cfg.Diversity.MaxDefiShare {
+			break
+		}
+		if !cfg.IsProviderDefi(providers[i]) {
+			continue
+		}
+		trimmed = append(trimmed, trimmedProvider{name: providers[i], share: share})
+		providers = slices.Delete(slices.Clone(providers), i, i+1)
+	}
+
+	if len(trimmed) == 0 {
+		return market, providers, nil
+	}
+
+	market.ProviderConfigs = slices.DeleteFunc(slices.Clone(market.ProviderConfigs), func(pc mmtypes.ProviderConfig) bool {
+		return slices.ContainsFunc(trimmed, func(t trimmedProvider) bool { return t.name == pc.Name })
+	})
+	return market, providers, trimmed
+}
+
+// defiShare returns the share of DeFi providers among the given providers.
+func defiShare(cfg config.GenerateConfig, providers []string) float64 {
+	if len(providers) == 0 {
+		return 0
+	}
+
+	var defiProviders int
+	for _, provider := range providers {
+		if cfg.IsProviderDefi(provider) {
+			defiProviders++
+		}
+	}
+	return float64(defiProviders) / float64(len(providers))
+}
+
+// ApplyMarketPatches applies the market patches of the GenerateConfig in order. Patches that cannot be applied to the
+// generated market map, ex. because their market was not generated, the provider to remove is missing, or the
+// patched market would be invalid, are skipped and reported with the PATCH_CONFLICT code.
+func ApplyMarketPatches() TransformMarketMap {
+	return func(_ context.Context, logger *zap.Logger, cfg config.GenerateConfig, mm mmtypes.MarketMap) (mmtypes.MarketMap, types.RemovalReasons, error) {
+		if len(cfg.MarketPatches) == 0 {
+			return mm, nil, nil
+		}
+
+		logger.Info("applying market patches", zap.Int("patches", len(cfg.MarketPatches)))
+
+		removals := types.NewRemovalReasons()
+		for i, patch := range cfg.MarketPatches {
+			cp, err := connecttypes.CurrencyPairFromString(patch.Ticker)
+			if err != nil {
+				return mmtypes.MarketMap{}, nil, fmt.Errorf("invalid market patch %d: %w", i, err)
+			}
+
+			var patched mmtypes.Market
+			market, found := mm.Markets[cp.String()]
+			if !found {
+				market = mmtypes.Market{Ticker: mmtypes.Ticker{CurrencyPair: cp}}
+				err = fmt.Errorf("market %s was not generated", cp.String())
+			} else {
+				patched, err = applyMarketPatch(market, patch)
+			}
+			if err != nil {
+				logger.Warn("skipping market patch", zap.Int("patch", i), zap.String("ticker", cp.String()),
+					zap.String("op", string(patch.Op)), zap.Error(err))
+				removals.AddRemovalReasonFromMarket(market, patchedProvider(patch), types.NewReason(NameApplyMarketPatches,
+					types.ReasonPatchConflict, fmt.Sprintf("ApplyMarketPatches: patch %d (%s) not applied: %v", i, patch.Op, err)))
+				continue
+			}
+
+			if patch.Op == config.MarketPatchRemoveProvider {
+				removals.AddRemovalReasonFromMarket(market, patch.Provider, types.NewReason(NameApplyMarketPatches,
+					types.ReasonMarketPatch, fmt.Sprintf("ApplyMarketPatches: provider removed by patch %d", i)))
+			}
+
+			logger.Debug("patched market", zap.Int("patch", i), zap.String("ticker", cp.String()),
+				zap.String("op", string(patch.Op)))
+			mm.Markets[cp.String()] = patched
+		}
+
+		return mm, removals, nil
+	}
+}
+
+// applyMarketPatch returns a copy of the market with the patch applied. An error is returned if the patch does not
+// apply to the market or the patched market is invalid.
+func applyMarketPatch(market mmtypes.Market, patch config.MarketPatchConfig) (mmtypes.Market, error) {
+	providers := slices.Clone(market.ProviderConfigs)
+	index := func(name string) int {
+		return slices.IndexFunc(providers, func(pc mmtypes.ProviderConfig) bool { return pc.Name == name })
+	}
+
+	switch patch.Op {
+	case config.MarketPatchAddProvider:
+		if index(patch.ProviderConfig.Name) >= 0 {
+			return market, fmt.Errorf("provider %s already exists", patch.ProviderConfig.Name)
+		}
+		providers = append(providers, *patch.ProviderConfig)
+		slices.SortFunc(providers, func(a, b mmtypes.ProviderConfig) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	case config.MarketPatchRemoveProvider:
+		i := index(patch.Provider)
+		if i < 0 {
+			return market, fmt.Errorf("provider %s does not exist", patch.Provider)
+		}
+		providers = slices.Delete(providers, i, i+1)
+	case config.MarketPatchReplaceProvider:
+		i := index(patch.ProviderConfig.Name)
+		if i < 0 {
+			return market, fmt.Errorf("provider %s does not exist", patch.ProviderConfig.Name)
+		}
+		providers[i] = *patch.ProviderConfig
+	case config.MarketPatchSetDecimals:
+		market.Ticker.Decimals = patch.Decimals
+	case config.MarketPatchSetMinProviderCount:
+		market.Ticker.MinProviderCount = patch.MinProviderCount
+	case config.MarketPatchSetEnabled:
+		market.Ticker.Enabled = *patch.Enabled
+	case config.MarketPatchMergeMetadata:
+		metadata := make(map[string]json.RawMessage)
+		if market.Ticker.Metadata_JSON != "" {
+			if err := json.Unmarshal([]byte(market.Ticker.Metadata_JSON), &metadata); err != nil {
+				return market, fmt.Errorf("failed to unmarshal ticker metadata: %w", err)
+			}
+		}
+		for key, value := range patch.Metadata {
+			metadata[key] = value
+		}
+		bz, err := json.Marshal(metadata)
+		if err != nil {
+			return market, fmt.Errorf("failed to marshal ticker metadata: %w", err)
+		}
+		market.Ticker.Metadata_JSON = string(bz)
+	default:
+		return market, fmt.Errorf("unsupported op %q", patch.Op)
+	}
+	market.ProviderConfigs = providers
+
+	if err := market.ValidateBasic(); err != nil {
+		return market, fmt.Errorf("patched market is invalid: %w", err)
+	}
+
+	return market, nil
+}
+
+// patchedProvider returns the name of the provider targeted by the patch, or an empty string for patches of the
+// ticker.
+func patchedProvider(patch config.MarketPatchConfig) string {
+	switch {
+	case patch.Provider != "":
+		return patch.Provider
+	case patch.ProviderConfig != nil:
+		return patch.ProviderConfig.Name
+	default:
+		return ""
+	}
+}
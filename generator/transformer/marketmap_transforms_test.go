@@ -2,6 +2,7 @@ package transformer_test
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -13,6 +14,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/config"
 	"github.com/skip-mev/connect-mmu/generator/transformer"
+	generatortypes "github.com/skip-mev/connect-mmu/generator/types"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kraken"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/raydium"
 )
@@ -501,6 +503,39 @@ func TestPruneInsufficientlyProvidedMarkets(t *testing.T) {
 	}
 }
 
+func TestValidateOffChainTickers(t *testing.T) {
+	mm := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+		"BTC/USD": {
+			Ticker: mmtypes.Ticker{CurrencyPair: types.NewCurrencyPair("BTC", "USD")},
+			ProviderConfigs: []mmtypes.ProviderConfig{
+				{Name: "coinbase_ws", OffChainTicker: "BTC-USD"},
+				{Name: "binance_ws", OffChainTicker: "BTC-USD"},
+				{Name: "bitfinex_ws", OffChainTicker: "tBTCUSD"},
+				// providers without a registered format are not validated
+				{Name: "foo", OffChainTicker: "anything"},
+			},
+		},
+	}}
+
+	transform := transformer.ValidateOffChainTickers()
+	result, removals, err := transform(context.Background(), zap.NewNop(), config.GenerateConfig{}, mm)
+	require.NoError(t, err)
+
+	providers := make([]string, 0)
+	for _, pc := range result.Markets["BTC/USD"].ProviderConfigs {
+		providers = append(providers, pc.Name)
+	}
+	require.Equal(t, []string{"coinbase_ws", "foo"}, providers)
+
+	require.Len(t, removals["BTC/USD"], 2)
+	for _, removal := range removals["BTC/USD"] {
+		require.Equal(t, generatortypes.ReasonInvalidOffChainTicker, removal.Code)
+		require.Equal(t, transformer.NameValidateOffChainTickers, removal.Stage)
+		require.Contains(t, removal.Reason, "did you mean")
+	}
+	require.Contains(t, removals["BTC/USD"][0].Reason, `did you mean "BTCUSD"`)
+}
+
 func TestRemoveDisabledProviders(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1028,3 +1063,470 @@ func TestProcessDefiMarkets(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyMarketRules(t *testing.T) {
+	cfg := config.GenerateConfig{
+		Providers: map[string]config.ProviderConfig{
+			"provider1": {},
+			"provider2": {},
+			"provider3": {},
+		},
+		Rules: []config.RuleConfig{
+			{ID: "drop-eth", Scope: config.RuleScopeMarket, Expression: `base == "ETH"`, Action: config.RuleActionDrop},
+			{ID: "min-1", Scope: config.RuleScopeMarket, Expression: `base == "BTC"`, Action: config.RuleActionSetMinProviderCount, MinProviderCount: 1},
+			{ID: "no-provider3", Scope: config.RuleScopeProvider, Expression: `provider == "provider3"`, Action: config.RuleActionDisableProvider},
+			{ID: "supp-provider2", Scope: config.RuleScopeProvider, Expression: `provider == "provider2"`, Action: config.RuleActionMarkSupplemental},
+		},
+	}
+
+	mm := mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			"BTC/USD": {
+				Ticker: mmtypes.Ticker{CurrencyPair: types.CurrencyPair{Base: "BTC", Quote: "USD"}, Decimals: 8, MinProviderCount: 2},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "provider1", OffChainTicker: "BTCUSD"},
+					{Name: "provider2", OffChainTicker: "BTC-USD"},
+					{Name: "provider3", OffChainTicker: "btc_usd"},
+				},
+			},
+			"ETH/USD": {
+				Ticker: mmtypes.Ticker{CurrencyPair: types.CurrencyPair{Base: "ETH", Quote: "USD"}, Decimals: 8, MinProviderCount: 1},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "provider1", OffChainTicker: "ETHUSD"},
+				},
+			},
+			"SOL/USD": {
+				Ticker: mmtypes.Ticker{CurrencyPair: types.CurrencyPair{Base: "SOL", Quote: "USD"}, Decimals: 8, MinProviderCount: 2},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "provider1", OffChainTicker: "SOLUSD"},
+					{Name: "provider2", OffChainTicker: "SOL-USD"},
+				},
+			},
+		},
+	}
+
+	states := transformer.NewMarketStates()
+	ctx := transformer.WithMarketStates(context.Background(), states)
+	got, removals, err := transformer.ApplyMarketRules()(ctx, zap.NewNop(), cfg, mm)
+	require.NoError(t, err)
+
+	require.Equal(t, mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			"BTC/USD": {
+				Ticker: mmtypes.Ticker{
+					CurrencyPair:     types.CurrencyPair{Base: "BTC", Quote: "USD"},
+					Decimals:         8,
+					MinProviderCount: 1,
+				},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "provider1", OffChainTicker: "BTCUSD"},
+					{Name: "provider2", OffChainTicker: "BTC-USD"},
+				},
+			},
+		},
+	}, got)
+	// the rule bookkeeping is kept in the market states, not in the ticker metadata
+	require.Equal(t, []string{"provider2"}, states.SupplementalProviders("BTC/USD"))
+	require.Equal(t, "min-1", states.MinProviderCountRule("BTC/USD"))
+
+	// ETH/USD is dropped by rule, SOL/USD has too few non-supplemental providers.
+	require.Len(t, removals["ETH/USD"], 1)
+	require.Contains(t, removals["ETH/USD"][0].Reason, "drop-eth")
+	require.Equal(t, generatortypes.ReasonRuleMatched, removals["ETH/USD"][0].Code)
+	require.Equal(t, "drop-eth", removals["ETH/USD"][0].Params.Rule)
+	require.Equal(t, transformer.NameApplyMarketRules, removals["ETH/USD"][0].Stage)
+	require.Len(t, removals["SOL/USD"], 2)
+	require.Equal(t, generatortypes.ReasonRuleApplied, removals["SOL/USD"][0].Code)
+	require.Equal(t, generatortypes.ReasonInsufficientProviders, removals["SOL/USD"][1].Code)
+	// only the disabled provider is removed from BTC/USD, setting the min provider count and marking a provider
+	// supplemental are recorded as applied rules.
+	require.Len(t, removals["BTC/USD"], 3)
+	for i, expected := range []struct {
+		rule     string
+		provider string
+		code     generatortypes.ReasonCode
+	}{
+		{rule: "min-1", provider: "BTC/USD", code: generatortypes.ReasonRuleApplied},
+		{rule: "supp-provider2", provider: "provider2", code: generatortypes.ReasonRuleApplied},
+		{rule: "no-provider3", provider: "provider3", code: generatortypes.ReasonRuleMatched},
+	} {
+		require.Equal(t, expected.rule, removals["BTC/USD"][i].Params.Rule)
+		require.Equal(t, expected.provider, removals["BTC/USD"][i].Provider)
+		require.Equal(t, expected.code, removals["BTC/USD"][i].Code)
+	}
+
+	// the min provider count set by the rule is kept by the stages that replace it
+	cfg.MinProviderCountOverride = 3
+	cfg.MinProviderCountPolicy = &config.MinProviderCountPolicyConfig{
+		Default: config.MinProviderCountRule{Fraction: &config.MinProviderCountFraction{Fraction: 1, Floor: 1}},
+	}
+	kept, _, err := transformer.OverrideMinProviderCount()(ctx, zap.NewNop(), cfg, got)
+	require.NoError(t, err)
+	kept, _, err = transformer.ApplyMinProviderCountPolicy()(ctx, zap.NewNop(), cfg, kept)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), kept.Markets["BTC/USD"].Ticker.MinProviderCount)
+	cfg.MinProviderCountOverride = 0
+	cfg.MinProviderCountPolicy = nil
+
+	// the marked provider is not counted by later stages
+	btc := withMinProviderCount(got.Markets["BTC/USD"], 2)
+	pruned, removals, err := transformer.PruneInsufficientlyProvidedMarkets()(ctx, zap.NewNop(), cfg,
+		mmtypes.MarketMap{Markets: map[string]mmtypes.Market{"BTC/USD": btc}})
+	require.NoError(t, err)
+	require.NotContains(t, pruned.Markets, "BTC/USD")
+	require.Equal(t, 1.0, *removals["BTC/USD"][0].Params.Observed)
+
+	// stages run without the market states of the rules count every provider
+	pruned, _, err = transformer.PruneInsufficientlyProvidedMarkets()(context.Background(), zap.NewNop(), cfg,
+		mmtypes.MarketMap{Markets: map[string]mmtypes.Market{"BTC/USD": btc}})
+	require.NoError(t, err)
+	require.Contains(t, pruned.Markets, "BTC/USD")
+
+	// a pipeline shares the market states between its stages, without writing them to the market map
+	cfg.MinProviderCountOverride = 3
+	d, err := transformer.NewFromPipeline(zap.NewNop(), transformer.NewDefaultRegistry(), config.PipelineConfig{
+		FeedTransforms: []config.TransformStageConfig{{Name: transformer.NamePruneByTradingStatus}},
+		MarketMapTransforms: []config.TransformStageConfig{
+			{Name: transformer.NameApplyMarketRules},
+			{Name: transformer.NameOverrideMinProviderCount},
+		},
+	})
+	require.NoError(t, err)
+	mm = mmtypes.MarketMap{Markets: map[string]mmtypes.Market{"BTC/USD": withMinProviderCount(btc, 2)}}
+	transformed, _, err := d.TransformMarketMap(context.Background(), cfg, mm)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), transformed.Markets["BTC/USD"].Ticker.MinProviderCount)
+	require.Empty(t, transformed.Markets["BTC/USD"].Ticker.Metadata_JSON)
+}
+
+func withMinProviderCount(market mmtypes.Market, minProviderCount uint64) mmtypes.Market {
+	market.Ticker.MinProviderCount = minProviderCount
+	return market
+}
+
+func TestApplyMinProviderCountPolicy(t *testing.T) {
+	usdt := types.NewCurrencyPair("USDT", "USD")
+	cfg := config.GenerateConfig{
+		Providers: map[string]config.ProviderConfig{
+			"provider1": {},
+			"provider2": {},
+			"provider3": {},
+			"provider4": {IsSupplemental: true},
+			"dex1":      {IsDefi: true},
+		},
+		MinProviderCountPolicy: &config.MinProviderCountPolicyConfig{
+			Default: config.MinProviderCountRule{
+				Fraction: &config.MinProviderCountFraction{Fraction: 1, Floor: 1},
+			},
+			Tiers: map[string]config.MinProviderCountRule{
+				"long-tail": {Ladder: []config.MinProviderCountStep{{Providers: 1, MinProviderCount: 1}}},
+			},
+			Markets: map[string]config.MinProviderCountRule{
+				"USDT/USD": {Ladder: []config.MinProviderCountStep{{Providers: 1, MinProviderCount: 1}}},
+			},
+		},
+	}
+
+	mm := mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			"BTC/USD": {
+				Ticker: mmtypes.Ticker{CurrencyPair: types.NewCurrencyPair("BTC", "USD"), Decimals: 8, MinProviderCount: 1},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "provider1", OffChainTicker: "BTCUSD"},
+					{Name: "provider2", OffChainTicker: "BTC-USD"},
+					{Name: "provider4", OffChainTicker: "btc_usd"},
+				},
+			},
+			"ETH/USD": {
+				Ticker: mmtypes.Ticker{CurrencyPair: types.NewCurrencyPair("ETH", "USD"), Decimals: 8, MinProviderCount: 1},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "provider1", OffChainTicker: "ETHUSD"},
+					// USDT/USD is disabled, so this provider may be pruned by PruneNormalizeByPairs
+					{Name: "provider2", OffChainTicker: "ETH-USDT", NormalizeByPair: &usdt},
+					{Name: "dex1", OffChainTicker: "ETH,DEX,0x"},
+				},
+			},
+			"USDT/USD": {
+				Ticker: mmtypes.Ticker{CurrencyPair: usdt, Decimals: 8, MinProviderCount: 3},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "provider1", OffChainTicker: "USDTUSD"},
+					{Name: "provider3", OffChainTicker: "USDT-USD"},
+				},
+			},
+			"PEPE/USD": {
+				Ticker: mmtypes.Ticker{CurrencyPair: types.NewCurrencyPair("PEPE", "USD"), Decimals: 8, MinProviderCount: 1},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					// no provider is guaranteed to remain
+					{Name: "provider1", OffChainTicker: "PEPE-USDT", NormalizeByPair: &usdt},
+					{Name: "provider3", OffChainTicker: "PEPEUSDT", NormalizeByPair: &usdt},
+				},
+			},
+			"ATOM/USD": {
+				Ticker: mmtypes.Ticker{CurrencyPair: types.NewCurrencyPair("ATOM", "USD"), Decimals: 8, MinProviderCount: 1},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "provider1", OffChainTicker: "ATOM-USDT", NormalizeByPair: &usdt},
+					{Name: "provider2", OffChainTicker: "ATOMUSD"},
+					// supplemental providers are not guaranteed
+					{Name: "provider4", OffChainTicker: "atom_usd"},
+				},
+			},
+			"MOG/USD": {
+				Ticker: mmtypes.Ticker{
+					CurrencyPair:     types.NewCurrencyPair("MOG", "USD"),
+					Decimals:         8,
+					MinProviderCount: 3,
+					Metadata_JSON:    `{"reference_price":0,"liquidity":0,"aggregate_ids":[],"tier":"long-tail"}`,
+				},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: "provider1", OffChainTicker: "MOGUSD"},
+					{Name: "provider2", OffChainTicker: "MOG-USD"},
+					{Name: "provider3", OffChainTicker: "mog_usd"},
+				},
+			},
+		},
+	}
+
+	got, _, err := transformer.ApplyMinProviderCountPolicy()(context.Background(), zap.NewNop(), cfg, mm)
+	require.NoError(t, err)
+
+	// the cap is applied even if no provider is guaranteed, but never below 1
+	require.Equal(t, uint64(1), got.Markets["PEPE/USD"].Ticker.MinProviderCount)
+	// the policy requires 2, but only 1 non-supplemental provider is guaranteed to remain
+	require.Equal(t, uint64(1), got.Markets["ATOM/USD"].Ticker.MinProviderCount)
+	// the rule of the tier takes precedence over the default rule
+	require.Equal(t, uint64(1), got.Markets["MOG/USD"].Ticker.MinProviderCount)
+
+	// the supplemental provider is not counted
+	require.Equal(t, uint64(2), got.Markets["BTC/USD"].Ticker.MinProviderCount)
+	// the policy requires 3, but only 2 providers are guaranteed to remain
+	require.Equal(t, uint64(2), got.Markets["ETH/USD"].Ticker.MinProviderCount)
+	require.Equal(t, uint64(1), got.Markets["USDT/USD"].Ticker.MinProviderCount)
+
+	// without a policy, the transform is a no-op
+	cfg.MinProviderCountPolicy = nil
+	got, _, err = transformer.ApplyMinProviderCountPolicy()(context.Background(), zap.NewNop(), cfg, got)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), got.Markets["USDT/USD"].Ticker.MinProviderCount)
+}
+
+func TestEnableMarketsByTier(t *testing.T) {
+	enabled, disabled := true, false
+	cfg := config.GenerateConfig{
+		EnableAll: true,
+		Tiers: []config.TierConfig{
+			{Name: "core", Enabled: &enabled},
+			{Name: "long-tail", Enabled: &disabled},
+			{Name: "mid"},
+		},
+	}
+
+	market := func(base, tier string) mmtypes.Market {
+		md := ""
+		if tier != "" {
+			md = `{"reference_price":0,"liquidity":0,"aggregate_ids":[],"tier":"` + tier + `"}`
+		}
+		return mmtypes.Market{Ticker: mmtypes.Ticker{CurrencyPair: types.NewCurrencyPair(base, "USD"), Metadata_JSON: md}}
+	}
+
+	mm := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+		"BTC/USD": market("BTC", "core"),
+		"MOG/USD": market("MOG", "long-tail"),
+		"ETH/USD": market("ETH", "mid"),
+		"SOL/USD": market("SOL", ""),
+	}}
+
+	got, _, err := transformer.EnableMarkets()(context.Background(), zap.NewNop(), cfg, mm)
+	require.NoError(t, err)
+	require.True(t, got.Markets["BTC/USD"].Ticker.Enabled)
+	require.False(t, got.Markets["MOG/USD"].Ticker.Enabled)
+	require.True(t, got.Markets["ETH/USD"].Ticker.Enabled)
+	require.True(t, got.Markets["SOL/USD"].Ticker.Enabled)
+
+	// tier policies apply without EnableAll
+	cfg.EnableAll = false
+	mm = mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+		"BTC/USD": market("BTC", "core"),
+		"ETH/USD": market("ETH", "mid"),
+	}}
+	got, _, err = transformer.EnableMarkets()(context.Background(), zap.NewNop(), cfg, mm)
+	require.NoError(t, err)
+	require.True(t, got.Markets["BTC/USD"].Ticker.Enabled)
+	require.False(t, got.Markets["ETH/USD"].Ticker.Enabled)
+}
+
+func TestEnforceProviderDiversity(t *testing.T) {
+	cfg := config.GenerateConfig{
+		Providers: map[string]config.ProviderConfig{
+			"okx_ws":          {Groups: map[string]string{config.GroupOperator: "okx"}},
+			"okx_api":         {Groups: map[string]string{config.GroupOperator: "okx"}},
+			"coinbase_ws":     {Groups: map[string]string{config.GroupOperator: "coinbase"}},
+			"kraken_api":      {IsSupplemental: true, Groups: map[string]string{config.GroupOperator: "kraken"}},
+			"uniswapv3_api":   {IsDefi: true},
+			"raydium_api":     {IsDefi: true},
+			"osmosis_api":     {IsDefi: true},
+			"other_exchange1": {},
+		},
+		Diversity: &config.DiversityConfig{
+			MinDistinctGroups: map[string]uint64{config.GroupOperator: 2},
+			MaxDefiShare:      0.5,
+		},
+	}
+
+	market := func(base string, enabled bool, providers ...string) mmtypes.Market {
+		pcs := make([]mmtypes.ProviderConfig, len(providers))
+		for i, provider := range providers {
+			pcs[i] = mmtypes.ProviderConfig{Name: provider, OffChainTicker: base}
+		}
+		return mmtypes.Market{
+			Ticker:          mmtypes.Ticker{CurrencyPair: types.NewCurrencyPair(base, "USD"), Enabled: enabled},
+			ProviderConfigs: pcs,
+		}
+	}
+
+	doge := market("DOGE", true, "okx_ws", "uniswapv3_api", "osmosis_api")
+	doge.Ticker.MinProviderCount = 3
+
+	mm := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+		"BTC/USD": market("BTC", true, "okx_ws", "coinbase_ws"),
+		// both providers are operated by okx, and the supplemental provider is not counted
+		"ETH/USD": market("ETH", true, "okx_ws", "okx_api", "kraken_api"),
+		// providers without an operator form their own group
+		"SOL/USD": market("SOL", true, "okx_ws", "other_exchange1"),
+		// 2 of 3 providers are defi, so the last defi provider is removed
+		"MOG/USD": market("MOG", true, "okx_ws", "uniswapv3_api", "raydium_api"),
+		// 3 of 4 providers are defi, so the last two defi providers are removed
+		"AVAX/USD": market("AVAX", true, "okx_ws", "uniswapv3_api", "raydium_api", "osmosis_api"),
+		// removing the last defi provider leaves fewer providers than the min provider count
+		"DOGE/USD": doge,
+		// the defi share is only capped for enabled markets
+		"PEPE/USD": market("PEPE", false, "okx_ws", "uniswapv3_api", "raydium_api"),
+	}}
+
+	got, removals, err := transformer.EnforceProviderDiversity()(context.Background(), zap.NewNop(), cfg, mm)
+	require.NoError(t, err)
+	require.Contains(t, got.Markets, "BTC/USD")
+	require.Contains(t, got.Markets, "SOL/USD")
+	require.Contains(t, got.Markets, "PEPE/USD")
+	require.Contains(t, got.Markets, "MOG/USD")
+	require.NotContains(t, got.Markets, "ETH/USD")
+	require.NotContains(t, got.Markets, "DOGE/USD")
+
+	require.Equal(t, mm.Markets["PEPE/USD"].ProviderConfigs, got.Markets["PEPE/USD"].ProviderConfigs)
+	require.Equal(t, []mmtypes.ProviderConfig{
+		{Name: "okx_ws", OffChainTicker: "MOG"},
+		{Name: "uniswapv3_api", OffChainTicker: "MOG"},
+	}, got.Markets["MOG/USD"].ProviderConfigs)
+
+	require.Len(t, removals["ETH/USD"], 1)
+	require.Equal(t, generatortypes.ReasonInsufficientProviderDiversity, removals["ETH/USD"][0].Code)
+	require.Equal(t, config.GroupOperator, removals["ETH/USD"][0].Params.Dimension)
+	require.Equal(t, 1.0, *removals["ETH/USD"][0].Params.Observed)
+
+	require.Len(t, removals["MOG/USD"], 1)
+	require.Equal(t, generatortypes.ReasonDefiShareExceeded, removals["MOG/USD"][0].Code)
+	require.Equal(t, "raydium_api", removals["MOG/USD"][0].Provider)
+	require.InDelta(t, 2.0/3.0, *removals["MOG/USD"][0].Params.Observed, 1e-9)
+	require.Equal(t, 0.5, *removals["MOG/USD"][0].Params.Threshold)
+
+	// each removal reports the defi share before its provider was removed
+	require.Len(t, removals["AVAX/USD"], 2)
+	require.Equal(t, "osmosis_api", removals["AVAX/USD"][0].Provider)
+	require.InDelta(t, 3.0/4.0, *removals["AVAX/USD"][0].Params.Observed, 1e-9)
+	require.Equal(t, "raydium_api", removals["AVAX/USD"][1].Provider)
+	require.InDelta(t, 2.0/3.0, *removals["AVAX/USD"][1].Params.Observed, 1e-9)
+	require.Len(t, got.Markets["AVAX/USD"].ProviderConfigs, 2)
+
+	require.Len(t, removals["DOGE/USD"], 2)
+	require.Equal(t, generatortypes.ReasonDefiShareExceeded, removals["DOGE/USD"][0].Code)
+	require.Equal(t, "osmosis_api", removals["DOGE/USD"][0].Provider)
+	require.Equal(t, generatortypes.ReasonInsufficientProviders, removals["DOGE/USD"][1].Code)
+	require.Equal(t, 2.0, *removals["DOGE/USD"][1].Params.Observed)
+
+	// without a diversity config, the transform is a no-op
+	cfg.Diversity = nil
+	mm = mmtypes.MarketMap{Markets: map[string]mmtypes.Market{"ETH/USD": market("ETH", true, "okx_ws", "okx_api")}}
+	got, removals, err = transformer.EnforceProviderDiversity()(context.Background(), zap.NewNop(), cfg, mm)
+	require.NoError(t, err)
+	require.Contains(t, got.Markets, "ETH/USD")
+	require.Empty(t, removals)
+}
+
+func TestApplyMarketPatches(t *testing.T) {
+	enabled := true
+	newMarketMap := func() mmtypes.MarketMap {
+		return mmtypes.MarketMap{
+			Markets: map[string]mmtypes.Market{
+				"BTC/USD": {
+					Ticker: mmtypes.Ticker{
+						CurrencyPair:     types.NewCurrencyPair("BTC", "USD"),
+						Decimals:         8,
+						MinProviderCount: 1,
+						Metadata_JSON:    `{"reference_price":1,"tier":"core"}`,
+					},
+					ProviderConfigs: []mmtypes.ProviderConfig{
+						{Name: "provider1", OffChainTicker: "BTCUSD"},
+						{Name: "provider3", OffChainTicker: "BTC-USD"},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("patches are applied in order", func(t *testing.T) {
+		cfg := config.GenerateConfig{
+			MarketPatches: []config.MarketPatchConfig{
+				{Ticker: "BTC/USD", Op: config.MarketPatchAddProvider, ProviderConfig: &mmtypes.ProviderConfig{Name: "provider2", OffChainTicker: "btc_usd"}},
+				{Ticker: "BTC/USD", Op: config.MarketPatchReplaceProvider, ProviderConfig: &mmtypes.ProviderConfig{Name: "provider1", OffChainTicker: "XBTUSD"}},
+				{Ticker: "BTC/USD", Op: config.MarketPatchRemoveProvider, Provider: "provider3"},
+				{Ticker: "BTC/USD", Op: config.MarketPatchSetDecimals, Decimals: 10},
+				{Ticker: "BTC/USD", Op: config.MarketPatchSetMinProviderCount, MinProviderCount: 2},
+				{Ticker: "BTC/USD", Op: config.MarketPatchSetEnabled, Enabled: &enabled},
+				{Ticker: "BTC/USD", Op: config.MarketPatchMergeMetadata, Metadata: map[string]json.RawMessage{"tier": []byte(`"pinned"`)}},
+			},
+		}
+
+		got, removals, err := transformer.ApplyMarketPatches()(context.Background(), zap.NewNop(), cfg, newMarketMap())
+		require.NoError(t, err)
+
+		market := got.Markets["BTC/USD"]
+		require.Equal(t, []mmtypes.ProviderConfig{
+			{Name: "provider1", OffChainTicker: "XBTUSD"},
+			{Name: "provider2", OffChainTicker: "btc_usd"},
+		}, market.ProviderConfigs)
+		require.Equal(t, uint64(10), market.Ticker.Decimals)
+		require.Equal(t, uint64(2), market.Ticker.MinProviderCount)
+		require.True(t, market.Ticker.Enabled)
+		require.JSONEq(t, `{"reference_price":1,"tier":"pinned"}`, market.Ticker.Metadata_JSON)
+
+		require.Len(t, removals["BTC/USD"], 1)
+		require.Equal(t, generatortypes.ReasonMarketPatch, removals["BTC/USD"][0].Code)
+		require.Equal(t, "provider3", removals["BTC/USD"][0].Provider)
+	})
+
+	t.Run("conflicting patches are skipped and reported", func(t *testing.T) {
+		cfg := config.GenerateConfig{
+			MarketPatches: []config.MarketPatchConfig{
+				// the market was not generated
+				{Ticker: "ETH/USD", Op: config.MarketPatchSetEnabled, Enabled: &enabled},
+				// the provider already exists
+				{Ticker: "BTC/USD", Op: config.MarketPatchAddProvider, ProviderConfig: &mmtypes.ProviderConfig{Name: "provider1", OffChainTicker: "XBTUSD"}},
+				// the provider does not exist
+				{Ticker: "BTC/USD", Op: config.MarketPatchRemoveProvider, Provider: "provider2"},
+				// the market would have fewer providers than its MinProviderCount
+				{Ticker: "BTC/USD", Op: config.MarketPatchSetMinProviderCount, MinProviderCount: 3},
+			},
+		}
+
+		got, removals, err := transformer.ApplyMarketPatches()(context.Background(), zap.NewNop(), cfg, newMarketMap())
+		require.NoError(t, err)
+		require.Equal(t, newMarketMap(), got)
+
+		require.Len(t, removals["ETH/USD"], 1)
+		require.Len(t, removals["BTC/USD"], 3)
+		for _, reasons := range removals {
+			for _, reason := range reasons {
+				require.Equal(t, generatortypes.ReasonPatchConflict, reason.Code)
+			}
+		}
+	})
+}
@@ -0,0 +1,376 @@
+package transformer
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+// Names of all registered transforms.
+const (
+	NameInvertOrDrop                  = "InvertOrDrop"
+	NamePruneByLiquidity              = "PruneByLiquidity"
+	NamePruneByQuoteVolume            = "PruneByQuoteVolume"
+	NameResolveNamingAliases          = "ResolveNamingAliases"
+	NameNormalizeBy                   = "NormalizeBy"
+	NameDropFeedsWithoutAggregatorIDs = "DropFeedsWithoutAggregatorIDs"
+	NameResolveConflictsForProvider   = "ResolveConflictsForProvider"
+	NameTopFeedsForProvider           = "TopFeedsForProvider"
+	NameApplyFeedRules                = "ApplyFeedRules"
+	NameAssignTiers                   = "AssignTiers"
+	NamePruneByTradingStatus          = "PruneByTradingStatus"
+
+	NamePruneMarkets                       = "PruneMarkets"
+	NameRemoveDisabledProviders            = "RemoveDisabledProviders"
+	NameEnableMarkets                      = "EnableMarkets"
+	NameProcessDefiMarkets                 = "ProcessDefiMarkets"
+	NamePruneInsufficientlyProvidedMarkets = "PruneInsufficientlyProvidedMarkets"
+	NameOverrideMinProviderCount           = "OverrideMinProviderCount"
+	NameOverrideMarkets                    = "OverrideMarkets"
+	NameApplyMarketRules                   = "ApplyMarketRules"
+	NameApplyMinProviderCountPolicy        = "ApplyMinProviderCountPolicy"
+	NameEnforceProviderDiversity           = "EnforceProviderDiversity"
+	NameValidateOffChainTickers            = "ValidateOffChainTickers"
+	NameApplyMarketPatches                 = "ApplyMarketPatches"
+)
+
+// FeedTransformFactory creates a TransformFeed from the parameters of a pipeline stage.
+type FeedTransformFactory func(params map[string]any) (TransformFeed, error)
+
+// MarketMapTransformFactory creates a TransformMarketMap from the parameters of a pipeline stage.
+type MarketMapTransformFactory func(params map[string]any) (TransformMarketMap, error)
+
+// Registry manages the named transforms that can be referenced from a PipelineConfig.
+type Registry struct {
+	mu         sync.RWMutex
+	feeds      map[string]FeedTransformFactory
+	marketMaps map[string]MarketMapTransformFactory
+}
+
+// NewRegistry creates a new, empty Registry instance.
+func NewRegistry() *Registry {
+	return &Registry{
+		feeds:      make(map[string]FeedTransformFactory),
+		marketMaps: make(map[string]MarketMapTransformFactory),
+	}
+}
+
+// NewDefaultRegistry creates a Registry with the transforms of this package registered under their names.
+func NewDefaultRegistry() *Registry {
+	return &Registry{
+		feeds: map[string]FeedTransformFactory{
+			NameInvertOrDrop:                  withoutParams(NameInvertOrDrop, InvertOrDrop),
+			NamePruneByLiquidity:              withoutParams(NamePruneByLiquidity, PruneByLiquidity),
+			NamePruneByQuoteVolume:            withoutParams(NamePruneByQuoteVolume, PruneByQuoteVolume),
+			NameResolveNamingAliases:          withoutParams(NameResolveNamingAliases, ResolveNamingAliases),
+			NameNormalizeBy:                   withoutParams(NameNormalizeBy, NormalizeBy),
+			NameDropFeedsWithoutAggregatorIDs: withoutParams(NameDropFeedsWithoutAggregatorIDs, DropFeedsWithoutAggregatorIDs),
+			NameResolveConflictsForProvider:   withoutParams(NameResolveConflictsForProvider, ResolveConflictsForProvider),
+			NameTopFeedsForProvider:           withParams(NameTopFeedsForProvider, TopFeedsForProviderWithOptions),
+			NameApplyFeedRules:                withoutParams(NameApplyFeedRules, ApplyFeedRules),
+			NameAssignTiers:                   withoutParams(NameAssignTiers, AssignTiers),
+			NamePruneByTradingStatus:          withoutParams(NamePruneByTradingStatus, PruneByTradingStatus),
+		},
+		marketMaps: map[string]MarketMapTransformFactory{
+			NamePruneMarkets:                       withoutParams(NamePruneMarkets, PruneMarkets),
+			NameRemoveDisabledProviders:            withoutParams(NameRemoveDisabledProviders, RemoveDisabledProviders),
+			NameEnableMarkets:                      withoutParams(NameEnableMarkets, EnableMarkets),
+			NameProcessDefiMarkets:                 withoutParams(NameProcessDefiMarkets, ProcessDefiMarkets),
+			NamePruneInsufficientlyProvidedMarkets: withoutParams(NamePruneInsufficientlyProvidedMarkets, PruneInsufficientlyProvidedMarkets),
+			NameOverrideMinProviderCount:           withoutParams(NameOverrideMinProviderCount, OverrideMinProviderCount),
+			NameOverrideMarkets:                    withoutParams(NameOverrideMarkets, OverrideMarkets),
+			NameApplyMarketRules:                   withoutParams(NameApplyMarketRules, ApplyMarketRules),
+			NameApplyMinProviderCountPolicy:        withoutParams(NameApplyMinProviderCountPolicy, ApplyMinProviderCountPolicy),
+			NameEnforceProviderDiversity:           withoutParams(NameEnforceProviderDiversity, EnforceProviderDiversity),
+			NameValidateOffChainTickers:            withoutParams(NameValidateOffChainTickers, ValidateOffChainTickers),
+			NameApplyMarketPatches:                 withoutParams(NameApplyMarketPatches, ApplyMarketPatches),
+		},
+	}
+}
+
+// RegisterFeedTransform registers a named TransformFeed so that it can be referenced from a PipelineConfig.
+func (r *Registry) RegisterFeedTransform(name string, factory FeedTransformFactory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.feeds[name]; found {
+		return fmt.Errorf("feed transform %q is already registered", name)
+	}
+
+	r.feeds[name] = factory
+	return nil
+}
+
+// RegisterMarketMapTransform registers a named TransformMarketMap so that it can be referenced from a PipelineConfig.
+func (r *Registry) RegisterMarketMapTransform(name string, factory MarketMapTransformFactory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.marketMaps[name]; found {
+		return fmt.Errorf("market map transform %q is already registered", name)
+	}
+
+	r.marketMaps[name] = factory
+	return nil
+}
+
+// FeedTransformNames returns the sorted names of all registered feed transforms.
+func (r *Registry) FeedTransformNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortedKeys(r.feeds)
+}
+
+// MarketMapTransformNames returns the sorted names of all registered market map transforms.
+func (r *Registry) MarketMapTransformNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return sortedKeys(r.marketMaps)
+}
+
+// DefaultPipeline returns the PipelineConfig that is used when no pipeline is configured.
+func DefaultPipeline() config.PipelineConfig {
+	return config.PipelineConfig{
+		FeedTransforms: stages(
+			NamePruneByTradingStatus,
+			NameInvertOrDrop, // must invert before normalize
+			NameApplyFeedRules,
+			NameAssignTiers,
+			NamePruneByLiquidity,
+			NamePruneByQuoteVolume,
+			NameResolveNamingAliases,
+			NameNormalizeBy,
+			NameDropFeedsWithoutAggregatorIDs,
+			NameResolveConflictsForProvider,
+			NameTopFeedsForProvider,
+		),
+		MarketMapTransforms: stages(
+			NameValidateOffChainTickers,
+			NamePruneMarkets,
+			NameRemoveDisabledProviders,
+			NameApplyMarketRules,
+			NameEnableMarkets,
+			NameProcessDefiMarkets,
+			NamePruneInsufficientlyProvidedMarkets,
+			NameEnforceProviderDiversity,
+			NameOverrideMinProviderCount,
+			NameApplyMinProviderCountPolicy,
+			NameApplyMarketPatches,
+			// always override after transforms so they are not overwritten
+			NameOverrideMarkets,
+		),
+	}
+}
+
+// orderConstraint requires that the before stage runs prior to the after stage whenever both are in a pipeline.
+// If required is set, the after stage may not be configured without the before stage.
+type orderConstraint struct {
+	before, after string
+	required      bool
+}
+
+var feedOrderConstraints = []orderConstraint{
+	// NormalizeBy fails on feeds with unknown quotes, which InvertOrDrop removes.
+	{before: NameInvertOrDrop, after: NameNormalizeBy, required: true},
+	// NormalizeBy creates the conflicts that are resolved per provider.
+	{before: NameNormalizeBy, after: NameResolveConflictsForProvider},
+	// tiers are matched using the quotes of the inverted feeds.
+	{before: NameInvertOrDrop, after: NameAssignTiers},
+	// tier thresholds and limits are applied using the tiers of the feeds.
+	{before: NameAssignTiers, after: NamePruneByLiquidity},
+	{before: NameAssignTiers, after: NamePruneByQuoteVolume},
+	{before: NameAssignTiers, after: NameTopFeedsForProvider},
+}
+
+var marketMapOrderConstraints = []orderConstraint{
+	// disabled providers must be removed before the provider count of a market is checked.
+	{before: NameRemoveDisabledProviders, after: NamePruneInsufficientlyProvidedMarkets},
+	// rules may disable providers and set the MinProviderCount that markets are pruned by.
+	{before: NameApplyMarketRules, after: NamePruneInsufficientlyProvidedMarkets},
+	// markets are pruned by their providers with valid off-chain tickers.
+	{before: NameValidateOffChainTickers, after: NamePruneInsufficientlyProvidedMarkets},
+	// the policy takes precedence over the global override.
+	{before: NameOverrideMinProviderCount, after: NameApplyMinProviderCountPolicy},
+	// the policy can only guarantee markets can post prices once providers are final.
+	{before: NamePruneInsufficientlyProvidedMarkets, after: NameApplyMinProviderCountPolicy},
+	// diversity is checked on the final providers, and the defi share only applies to enabled markets.
+	{before: NameRemoveDisabledProviders, after: NameEnforceProviderDiversity},
+	{before: NameApplyMarketRules, after: NameEnforceProviderDiversity},
+	{before: NameEnableMarkets, after: NameEnforceProviderDiversity},
+	// the policy can only guarantee markets can post prices once diverse markets are final.
+	{before: NameEnforceProviderDiversity, after: NameApplyMinProviderCountPolicy},
+	// patches are applied to the generated markets so that they are not overwritten by other stages.
+	{before: NameEnableMarkets, after: NameApplyMarketPatches},
+	{before: NamePruneInsufficientlyProvidedMarkets, after: NameApplyMarketPatches},
+	{before: NameOverrideMinProviderCount, after: NameApplyMarketPatches},
+	{before: NameApplyMinProviderCountPolicy, after: NameApplyMarketPatches},
+}
+
+// feedFirstStage is the stage that must always run first, since indexers keep provider markets that are not trading
+// and only this stage removes them.
+const feedFirstStage = NamePruneByTradingStatus
+
+// marketMapLastStage is the stage that must always be last if configured so that overrides are not overwritten.
+const marketMapLastStage = NameOverrideMarkets
+
+// ValidatePipeline checks that all stages of the pipeline are registered, that the hard ordering
+// constraints between stages are respected, that the feed stages start with PruneByTradingStatus, and that the params of every stage are accepted by its transform.
+func (r *Registry) ValidatePipeline(cfg config.PipelineConfig) error {
+	_, _, err := r.build(cfg)
+	return err
+}
+
+// build validates the pipeline and creates the transforms of its stages.
+func (r *Registry) build(cfg config.PipelineConfig) ([]namedFeedTransform, []namedMarketMapTransform, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	feedNames := stageNames(cfg.FeedTransforms)
+	for _, name := range feedNames {
+		if _, found := r.feeds[name]; !found {
+			return nil, nil, fmt.Errorf("unknown feed transform %q: must be one of %v", name, sortedKeys(r.feeds))
+		}
+	}
+	if len(feedNames) == 0 || feedNames[0] != feedFirstStage {
+		return nil, nil, fmt.Errorf("invalid feed_transforms: %s must be the first stage", feedFirstStage)
+	}
+	if err := checkOrder(feedNames, feedOrderConstraints); err != nil {
+		return nil, nil, fmt.Errorf("invalid feed_transforms: %w", err)
+	}
+
+	mmNames := stageNames(cfg.MarketMapTransforms)
+	for _, name := range mmNames {
+		if _, found := r.marketMaps[name]; !found {
+			return nil, nil, fmt.Errorf("unknown market map transform %q: must be one of %v", name, sortedKeys(r.marketMaps))
+		}
+	}
+	if err := checkOrder(mmNames, marketMapOrderConstraints); err != nil {
+		return nil, nil, fmt.Errorf("invalid market_map_transforms: %w", err)
+	}
+	if i := slices.Index(mmNames, marketMapLastStage); i >= 0 && i != len(mmNames)-1 {
+		return nil, nil, fmt.Errorf("invalid market_map_transforms: %s must be the last stage", marketMapLastStage)
+	}
+
+	feedTransforms := make([]namedFeedTransform, 0, len(cfg.FeedTransforms))
+	for _, stage := range cfg.FeedTransforms {
+		t, err := r.feeds[stage.Name](stage.Params)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create feed transform %s: %w", stage.Name, err)
+		}
+		feedTransforms = append(feedTransforms, namedFeedTransform{name: stage.Name, transform: t})
+	}
+
+	mmTransforms := make([]namedMarketMapTransform, 0, len(cfg.MarketMapTransforms))
+	for _, stage := range cfg.MarketMapTransforms {
+		t, err := r.marketMaps[stage.Name](stage.Params)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create market map transform %s: %w", stage.Name, err)
+		}
+		mmTransforms = append(mmTransforms, namedMarketMapTransform{name: stage.Name, transform: t})
+	}
+
+	return feedTransforms, mmTransforms, nil
+}
+
+func checkOrder(names []string, constraints []orderConstraint) error {
+	for _, c := range constraints {
+		afterIdx := slices.Index(names, c.after)
+		if afterIdx < 0 {
+			continue
+		}
+
+		beforeIdx := slices.Index(names, c.before)
+		if beforeIdx < 0 {
+			if c.required {
+				return fmt.Errorf("%s requires %s to be configured before it", c.after, c.before)
+			}
+			continue
+		}
+
+		if beforeIdx > afterIdx {
+			return fmt.Errorf("%s must run before %s", c.before, c.after)
+		}
+	}
+
+	return nil
+}
+
+// DecodeParams decodes the params of a pipeline stage into the options struct O of a transform, using the json
+// tags of O. Params that do not match a field of O are an error.
+func DecodeParams[O any](params map[string]any) (O, error) {
+	var opts O
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:      &opts,
+		TagName:     "json",
+		ErrorUnused: true,
+	})
+	if err != nil {
+		return opts, fmt.Errorf("error creating params decoder: %w", err)
+	}
+
+	if err := decoder.Decode(params); err != nil {
+		return opts, fmt.Errorf("error decoding params %v: %w", params, err)
+	}
+
+	return opts, nil
+}
+
+// withParams adapts a transform constructor that takes an options struct into a factory, decoding the options
+// from the params of the stage.
+func withParams[T, O any](name string, constructor func(O) T) func(map[string]any) (T, error) {
+	return func(params map[string]any) (T, error) {
+		opts, err := DecodeParams[O](params)
+		if err != nil {
+			var zero T
+			return zero, fmt.Errorf("invalid params for transform %s: %w", name, err)
+		}
+		return constructor(opts), nil
+	}
+}
+
+// withoutParams adapts a transform constructor that takes no parameters into a factory.
+func withoutParams[T any](name string, constructor func() T) func(map[string]any) (T, error) {
+	return func(params map[string]any) (T, error) {
+		if len(params) > 0 {
+			var zero T
+			return zero, fmt.Errorf("transform %s does not accept params", name)
+		}
+		return constructor(), nil
+	}
+}
+
+func stages(names ...string) []config.TransformStageConfig {
+	out := make([]config.TransformStageConfig, len(names))
+	for i, name := range names {
+		out[i] = config.TransformStageConfig{Name: name}
+	}
+	return out
+}
+
+func stageNames(stages []config.TransformStageConfig) []string {
+	names := make([]string, len(stages))
+	for i, stage := range stages {
+		names[i] = stage.Name
+	}
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,282 @@
+package transformer_test
+
+import (
+	"context"
+	"testing"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator/transformer"
+	"github.com/skip-mev/connect-mmu/generator/types"
+)
+
+func stagesOf(names ...string) []config.TransformStageConfig {
+	out := make([]config.TransformStageConfig, len(names))
+	for i, name := range names {
+		out[i] = config.TransformStageConfig{Name: name}
+	}
+	return out
+}
+
+func TestValidatePipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		pipeline config.PipelineConfig
+		wantErr  bool
+	}{
+		{
+			name:     "default pipeline is valid",
+			pipeline: transformer.DefaultPipeline(),
+		},
+		{
+			name: "minimal pipeline is valid",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: stagesOf(transformer.NamePruneByTradingStatus),
+			},
+		},
+		{
+			name:     "empty pipeline",
+			pipeline: config.PipelineConfig{},
+			wantErr:  true,
+		},
+		{
+			name: "trading status pruning not first",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: stagesOf(transformer.NameInvertOrDrop, transformer.NamePruneByTradingStatus),
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid reordered pipeline without defi processing",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: stagesOf(
+					transformer.NamePruneByTradingStatus,
+					transformer.NameInvertOrDrop,
+					transformer.NameTopFeedsForProvider,
+					transformer.NameNormalizeBy,
+					transformer.NameResolveConflictsForProvider,
+				),
+				MarketMapTransforms: stagesOf(
+					transformer.NamePruneMarkets,
+					transformer.NamePruneInsufficientlyProvidedMarkets,
+					transformer.NameOverrideMarkets,
+				),
+			},
+		},
+		{
+			name: "unknown feed transform",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: stagesOf(transformer.NamePruneByTradingStatus, "DoesNotExist"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown market map transform",
+			pipeline: config.PipelineConfig{
+				FeedTransforms:      stagesOf(transformer.NamePruneByTradingStatus),
+				MarketMapTransforms: stagesOf(transformer.NameInvertOrDrop),
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate stage",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: stagesOf(transformer.NamePruneByTradingStatus, transformer.NameInvertOrDrop, transformer.NameInvertOrDrop),
+			},
+			wantErr: true,
+		},
+		{
+			name: "normalize before invert",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: stagesOf(transformer.NamePruneByTradingStatus, transformer.NameNormalizeBy, transformer.NameInvertOrDrop),
+			},
+			wantErr: true,
+		},
+		{
+			name: "normalize without invert",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: stagesOf(transformer.NamePruneByTradingStatus, transformer.NameNormalizeBy),
+			},
+			wantErr: true,
+		},
+		{
+			name: "resolve conflicts before normalize",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: stagesOf(
+					transformer.NamePruneByTradingStatus,
+					transformer.NameInvertOrDrop,
+					transformer.NameResolveConflictsForProvider,
+					transformer.NameNormalizeBy,
+				),
+			},
+			wantErr: true,
+		},
+		{
+			name: "override markets not last",
+			pipeline: config.PipelineConfig{
+				FeedTransforms:      stagesOf(transformer.NamePruneByTradingStatus),
+				MarketMapTransforms: stagesOf(transformer.NameOverrideMarkets, transformer.NameEnableMarkets),
+			},
+			wantErr: true,
+		},
+		{
+			name: "params of a transform",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: []config.TransformStageConfig{
+					{Name: transformer.NamePruneByTradingStatus},
+					{Name: transformer.NameTopFeedsForProvider, Params: map[string]any{"top_markets": 10}},
+				},
+			},
+		},
+		{
+			name: "unknown params of a transform",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: []config.TransformStageConfig{
+					{Name: transformer.NamePruneByTradingStatus},
+					{Name: transformer.NameTopFeedsForProvider, Params: map[string]any{"top": 10}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid params of a transform",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: []config.TransformStageConfig{
+					{Name: transformer.NamePruneByTradingStatus},
+					{Name: transformer.NameTopFeedsForProvider, Params: map[string]any{"top_markets": "ten"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "params for a transform without params",
+			pipeline: config.PipelineConfig{
+				FeedTransforms: []config.TransformStageConfig{
+					{Name: transformer.NamePruneByTradingStatus},
+					{Name: transformer.NameInvertOrDrop, Params: map[string]any{"foo": "bar"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := transformer.NewDefaultRegistry()
+			_, err := transformer.NewFromPipeline(zaptest.NewLogger(t), registry, tt.pipeline)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Error(t, registry.ValidatePipeline(tt.pipeline))
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, registry.ValidatePipeline(tt.pipeline))
+		})
+	}
+}
+
+func TestNewFromConfig_CustomPipeline(t *testing.T) {
+	cfg := config.GenerateConfig{
+		Providers: map[string]config.ProviderConfig{
+			krakenProvider: {},
+		},
+		MinCexProviderCount: 1,
+		MinDexProviderCount: 1,
+		Quotes: map[string]config.QuoteConfig{
+			"USD": {},
+		},
+		EnableAll: true,
+		Pipeline: &config.PipelineConfig{
+			FeedTransforms:      stagesOf(transformer.NamePruneByTradingStatus),
+			MarketMapTransforms: stagesOf(transformer.NamePruneMarkets),
+		},
+	}
+
+	mm := mmtypes.MarketMap{
+		Markets: map[string]mmtypes.Market{
+			"BTC/USD": {
+				Ticker: mmtypes.Ticker{
+					CurrencyPair:     connecttypes.NewCurrencyPair("BTC", "USD"),
+					Decimals:         8,
+					MinProviderCount: 1,
+				},
+				ProviderConfigs: []mmtypes.ProviderConfig{
+					{Name: krakenProvider, OffChainTicker: "btc-usd"},
+				},
+			},
+		},
+	}
+
+	d, err := transformer.NewFromConfig(zaptest.NewLogger(t), transformer.NewDefaultRegistry(), cfg)
+	require.NoError(t, err)
+
+	got, _, err := d.TransformMarketMap(context.Background(), cfg, mm)
+	require.NoError(t, err)
+	// EnableMarkets is not part of the pipeline, so EnableAll has no effect
+	require.False(t, got.Markets["BTC/USD"].Ticker.Enabled)
+
+	cfg.Pipeline = nil
+	d, err = transformer.NewFromConfig(zaptest.NewLogger(t), transformer.NewDefaultRegistry(), cfg)
+	require.NoError(t, err)
+
+	got, _, err = d.TransformMarketMap(context.Background(), cfg, mm)
+	require.NoError(t, err)
+	require.True(t, got.Markets["BTC/USD"].Ticker.Enabled)
+
+	// params of stages that take no options are rejected before anything is run
+	cfg.Pipeline = &config.PipelineConfig{
+		FeedTransforms: stagesOf(transformer.NamePruneByTradingStatus),
+		MarketMapTransforms: []config.TransformStageConfig{
+			{Name: transformer.NameEnableMarkets, Params: map[string]any{"enable_all": false}},
+		},
+	}
+	_, err = transformer.NewFromConfig(zaptest.NewLogger(t), transformer.NewDefaultRegistry(), cfg)
+	require.ErrorContains(t, err, "transform EnableMarkets does not accept params")
+}
+
+func TestRegistry(t *testing.T) {
+	disableAll := func(_ map[string]any) (transformer.TransformMarketMap, error) {
+		return func(_ context.Context, _ *zap.Logger, _ config.GenerateConfig, mm mmtypes.MarketMap,
+		) (mmtypes.MarketMap, types.RemovalReasons, error) {
+			for ticker, market := range mm.Markets {
+				market.Ticker.Enabled = false
+				mm.Markets[ticker] = market
+			}
+			return mm, types.NewRemovalReasons(), nil
+		}, nil
+	}
+
+	registry := transformer.NewDefaultRegistry()
+	require.NoError(t, registry.RegisterMarketMapTransform("DisableAll", disableAll))
+	require.Error(t, registry.RegisterMarketMapTransform("DisableAll", disableAll))
+	require.Error(t, registry.RegisterMarketMapTransform(transformer.NameEnableMarkets, disableAll))
+	require.Contains(t, registry.MarketMapTransformNames(), "DisableAll")
+	require.Contains(t, registry.FeedTransformNames(), transformer.NameInvertOrDrop)
+
+	pipeline := config.PipelineConfig{
+		FeedTransforms:      stagesOf(transformer.NamePruneByTradingStatus),
+		MarketMapTransforms: stagesOf(transformer.NameEnableMarkets, "DisableAll"),
+	}
+	require.NoError(t, registry.ValidatePipeline(pipeline))
+
+	// transforms registered on one registry are unknown to the others
+	require.ErrorContains(t, transformer.NewDefaultRegistry().ValidatePipeline(pipeline), `unknown market map transform "DisableAll"`)
+	require.ErrorContains(t, transformer.NewRegistry().ValidatePipeline(transformer.DefaultPipeline()), "unknown feed transform")
+}
+
+func TestDecodeParams(t *testing.T) {
+	opts, err := transformer.DecodeParams[transformer.TopFeedsOptions](map[string]any{"top_markets": 3})
+	require.NoError(t, err)
+	require.Equal(t, transformer.TopFeedsOptions{TopMarkets: 3}, opts)
+
+	opts, err = transformer.DecodeParams[transformer.TopFeedsOptions](nil)
+	require.NoError(t, err)
+	require.Equal(t, transformer.TopFeedsOptions{}, opts)
+
+	_, err = transformer.DecodeParams[transformer.TopFeedsOptions](map[string]any{"foo": "bar"})
+	require.Error(t, err)
+}
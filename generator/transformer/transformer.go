@@ -8,44 +8,87 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/generator/rules"
 	"github.com/skip-mev/connect-mmu/generator/types"
 )
 
 type Transformer struct {
 	logger         *zap.Logger
-	feedTransforms []TransformFeed
-	mmTransforms   []TransformMarketMap
+	feedTransforms []namedFeedTransform
+	mmTransforms   []namedMarketMapTransform
+	observer       Observer
 }
 
-// New creates a new Transformer.
+type namedFeedTransform struct {
+	name      string
+	transform TransformFeed
+}
+
+type namedMarketMapTransform struct {
+	name      string
+	transform TransformMarketMap
+}
+
+// Observer is notified with the output of every stage that a Transformer runs. It is used to trace how
+// feeds and markets change throughout the pipeline.
+type Observer interface {
+	// ObserveFeeds is called with the feeds and removals after the named feed stage has run.
+	ObserveFeeds(stage string, feeds types.Feeds, removals types.RemovalReasons)
+	// ObserveMarketMap is called with the market map and removals after the named market map stage has run.
+	ObserveMarketMap(stage string, mm mmtypes.MarketMap, removals types.RemovalReasons)
+}
+
+// New creates a new Transformer running the DefaultPipeline with the transforms of the default Registry.
 //
 // It performs the following chain of transforms:
 //  1. Add all NormalizeByPairs
 //  2. Resolve any conflicts that may have arisen from prior transformations.
 func New(logger *zap.Logger) Transformer {
-	return Transformer{
-		logger: logger.With(zap.String("service", "transformer")),
-		feedTransforms: []TransformFeed{
-			InvertOrDrop(), // must invert before normalize
-			PruneByLiquidity(),
-			PruneByQuoteVolume(),
-			ResolveNamingAliases(),
-			NormalizeBy(),
-			DropFeedsWithoutAggregatorIDs(),
-			ResolveConflictsForProvider(),
-			TopFeedsForProvider(),
-		},
-		mmTransforms: []TransformMarketMap{
-			PruneMarkets(),
-			RemoveDisabledProviders(),
-			EnableMarkets(),
-			ProcessDefiMarkets(),
-			PruneInsufficientlyProvidedMarkets(),
-			OverrideMinProviderCount(),
-			// always override after transforms so they are not overwritten
-			OverrideMarkets(),
-		},
+	t, err := NewFromPipeline(logger, NewDefaultRegistry(), DefaultPipeline())
+	if err != nil {
+		// the default pipeline only references built-in transforms and is always valid.
+		panic(err)
+	}
+
+	return t
+}
+
+// NewFromConfig creates a new Transformer from the Pipeline of the given GenerateConfig, using the transforms of
+// the registry. If no Pipeline is configured, the DefaultPipeline is used.
+func NewFromConfig(logger *zap.Logger, registry *Registry, cfg config.GenerateConfig) (Transformer, error) {
+	// compile the rules up front so that invalid expressions surface before any data is queried.
+	if _, err := rules.Compile(cfg.Rules); err != nil {
+		return Transformer{}, fmt.Errorf("invalid rules: %w", err)
+	}
+
+	pipeline := DefaultPipeline()
+	if cfg.Pipeline != nil {
+		pipeline = *cfg.Pipeline
 	}
+
+	return NewFromPipeline(logger, registry, pipeline)
+}
+
+// NewFromPipeline creates a new Transformer that runs the stages of the given pipeline in order, using the
+// transforms of the registry. An error is returned if the pipeline references unknown transforms, violates
+// ordering constraints, or configures params that a transform does not accept.
+func NewFromPipeline(logger *zap.Logger, registry *Registry, pipeline config.PipelineConfig) (Transformer, error) {
+	feedTransforms, mmTransforms, err := registry.build(pipeline)
+	if err != nil {
+		return Transformer{}, err
+	}
+
+	return Transformer{
+		logger:         logger.With(zap.String("service", "transformer")),
+		feedTransforms: feedTransforms,
+		mmTransforms:   mmTransforms,
+	}, nil
+}
+
+// WithObserver returns a copy of the Transformer that notifies the given Observer after every stage.
+func (d Transformer) WithObserver(observer Observer) Transformer {
+	d.observer = observer
+	return d
 }
 
 // TransformFeeds runs all feed transformers that are assigned to the Transformer.
@@ -53,12 +96,16 @@ func (d *Transformer) TransformFeeds(ctx context.Context, cfg config.GenerateCon
 	dropped := types.NewRemovalReasons()
 
 	for _, t := range d.feedTransforms {
-		transformFeeds, transformDrops, err := t(ctx, d.logger, cfg, feeds)
+		transformFeeds, transformDrops, err := t.transform(ctx, d.logger, cfg, feeds)
 		if err != nil {
 			return nil, nil, err
 		}
 		feeds = transformFeeds
 		dropped.Merge(transformDrops)
+
+		if d.observer != nil {
+			d.observer.ObserveFeeds(t.name, feeds, transformDrops)
+		}
 	}
 
 	return feeds, dropped, nil
@@ -70,14 +117,21 @@ func (d *Transformer) TransformMarketMap(ctx context.Context, cfg config.Generat
 		return mmtypes.MarketMap{}, nil, fmt.Errorf("markets cannot be nil")
 	}
 
+	// rule bookkeeping is shared between the stages of this run only, and never written to the market map
+	ctx = WithMarketStates(ctx, NewMarketStates())
+
 	dropped := types.NewRemovalReasons()
 	for _, t := range d.mmTransforms {
-		transformMM, transformDrops, err := t(ctx, d.logger, cfg, marketMap)
+		transformMM, transformDrops, err := t.transform(ctx, d.logger, cfg, marketMap)
 		if err != nil {
 			return mmtypes.MarketMap{}, nil, err
 		}
 		marketMap = transformMM
 		dropped.Merge(transformDrops)
+
+		if d.observer != nil {
+			d.observer.ObserveMarketMap(t.name, marketMap, transformDrops)
+		}
 	}
 
 	// validate final transform
@@ -557,14 +557,14 @@ func TestDefaultTransformer_TransformMarketMap(t *testing.T) {
 							},
 							{
 								Name:            binanceProvider,
-								OffChainTicker:  "btc-usd",
+								OffChainTicker:  "BTCUSD",
 								NormalizeByPair: nil,
 								Invert:          false,
 								Metadata_JSON:   "",
 							},
 							{
 								Name:            bybitProvider,
-								OffChainTicker:  "btc-usd",
+								OffChainTicker:  "BTCUSD",
 								NormalizeByPair: nil,
 								Invert:          false,
 								Metadata_JSON:   "",
@@ -596,14 +596,14 @@ func TestDefaultTransformer_TransformMarketMap(t *testing.T) {
 							},
 							{
 								Name:            binanceProvider,
-								OffChainTicker:  "btc-usd",
+								OffChainTicker:  "BTCUSD",
 								NormalizeByPair: nil,
 								Invert:          false,
 								Metadata_JSON:   "",
 							},
 							{
 								Name:            bybitProvider,
-								OffChainTicker:  "btc-usd",
+								OffChainTicker:  "BTCUSD",
 								NormalizeByPair: nil,
 								Invert:          false,
 								Metadata_JSON:   "",
@@ -0,0 +1,197 @@
+package types
+
+import (
+	"sort"
+)
+
+// ReasonCode is a machine-readable code identifying why a feed or market was removed during generation. Codes
+// for which IsRemoval returns false record changes that did not remove anything.
+type ReasonCode string
+
+const (
+	// ReasonUnknown is used for removals that do not specify a code.
+	ReasonUnknown ReasonCode = "UNKNOWN"
+	// ReasonMissingQuoteConfig is used when no quote config exists for the quote of a feed.
+	ReasonMissingQuoteConfig ReasonCode = "MISSING_QUOTE_CONFIG"
+	// ReasonNotInvertible is used when a feed cannot be inverted to any configured quote.
+	ReasonNotInvertible ReasonCode = "NOT_INVERTIBLE"
+	// ReasonInsufficientLiquidity is used when a feed's liquidity is below the quote's minimum.
+	ReasonInsufficientLiquidity ReasonCode = "INSUFFICIENT_LIQUIDITY"
+	// ReasonInsufficientVolume is used when a feed's daily quote volume is below the quote's minimum.
+	ReasonInsufficientVolume ReasonCode = "INSUFFICIENT_VOLUME"
+	// ReasonNamingAlias is used when a feed shares a ticker with a better ranked asset.
+	ReasonNamingAlias ReasonCode = "NAMING_ALIAS"
+	// ReasonMissingAggregatorID is used when a feed has no aggregator ID but its provider requires one.
+	ReasonMissingAggregatorID ReasonCode = "MISSING_AGGREGATOR_ID"
+	// ReasonNotTopFeed is used when a feed is not within the top feeds retained for its provider.
+	ReasonNotTopFeed ReasonCode = "NOT_TOP_FEED"
+	// ReasonDisallowedCurrencyPair is used when a market's currency pair is excluded or not allowed.
+	ReasonDisallowedCurrencyPair ReasonCode = "DISALLOWED_CURRENCY_PAIR"
+	// ReasonProviderDisabled is used when a provider is disabled for a market.
+	ReasonProviderDisabled ReasonCode = "PROVIDER_DISABLED"
+	// ReasonInsufficientProviders is used when a market has fewer providers than its MinProviderCount.
+	ReasonInsufficientProviders ReasonCode = "INSUFFICIENT_PROVIDERS"
+	// ReasonRuleMatched is used when a configured rule matched a feed, market or provider.
+	ReasonRuleMatched ReasonCode = "RULE_MATCHED"
+	// ReasonInsufficientProviderDiversity is used when a market's providers belong to too few distinct groups.
+	ReasonInsufficientProviderDiversity ReasonCode = "INSUFFICIENT_PROVIDER_DIVERSITY"
+	// ReasonDefiShareExceeded is used when the share of DeFi providers of an enabled market exceeds the cap.
+	ReasonDefiShareExceeded ReasonCode = "DEFI_SHARE_EXCEEDED"
+	// ReasonNoNormalizationRoute is used when none of the normalization candidates of a feed's quote have any feeds.
+	ReasonNoNormalizationRoute ReasonCode = "NO_NORMALIZATION_ROUTE"
+	// ReasonInvalidOffChainTicker is used when a provider's off-chain ticker does not match the provider's format.
+	ReasonInvalidOffChainTicker ReasonCode = "INVALID_OFF_CHAIN_TICKER"
+	// ReasonTradingStatus is used when the trading status of a feed's provider market is excluded.
+	ReasonTradingStatus ReasonCode = "TRADING_STATUS"
+	// ReasonMarketPatch is used when a configured market patch removed a provider.
+	ReasonMarketPatch ReasonCode = "MARKET_PATCH"
+	// ReasonPatchConflict is used when a configured market patch could not be applied to the generated market.
+	ReasonPatchConflict ReasonCode = "PATCH_CONFLICT"
+	// ReasonRuleApplied is used when a configured rule matched a market or provider and changed it without removing
+	// it. It is not a removal.
+	ReasonRuleApplied ReasonCode = "RULE_APPLIED"
+)
+
+// IsRemoval returns false for codes that record a change that did not remove a feed, market or provider.
+func (c ReasonCode) IsRemoval() bool {
+	return c != ReasonRuleApplied
+}
+
+// ReasonParams are the structured parameters of a removal. Only the parameters relevant to the code are set.
+type ReasonParams struct {
+	// Threshold is the configured minimum that was not met.
+	Threshold *float64 `json:"threshold,omitempty"`
+	// Observed is the observed value that was compared against the Threshold.
+	Observed *float64 `json:"observed,omitempty"`
+	// Rule is the ID of the rule that matched.
+	Rule string `json:"rule,omitempty"`
+	// Dimension is the provider group dimension that was checked.
+	Dimension string `json:"dimension,omitempty"`
+}
+
+// Reason is a structured description of why a feed or market was removed.
+type Reason struct {
+	// Stage is the name of the generation stage that made the removal.
+	Stage string
+	// Code identifies the kind of removal.
+	Code ReasonCode
+	// Params are the structured parameters of the removal.
+	Params ReasonParams
+	// Message is a human readable description of the removal.
+	Message string
+}
+
+// NewReason creates a new Reason for the given stage and code.
+func NewReason(stage string, code ReasonCode, message string) Reason {
+	return Reason{
+		Stage:   stage,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// WithThreshold returns a copy of the Reason with the observed value and the threshold it was compared against.
+func (r Reason) WithThreshold(observed, threshold float64) Reason {
+	r.Params.Observed = &observed
+	r.Params.Threshold = &threshold
+	return r
+}
+
+// WithRule returns a copy of the Reason with the ID of the matched rule.
+func (r Reason) WithRule(id string) Reason {
+	r.Params.Rule = id
+	return r
+}
+
+// WithDimension returns a copy of the Reason with the provider group dimension that was checked.
+func (r Reason) WithDimension(dimension string) Reason {
+	r.Params.Dimension = dimension
+	return r
+}
+
+// MarketLevelProvider is the provider name removals are grouped under in a RemovalSummary if they apply to a whole
+// market rather than to a single provider.
+const MarketLevelProvider = "market"
+
+// RemovalSummary groups RemovalReasons by code and provider.
+type RemovalSummary struct {
+	// Total is the total number of removals. Codes that are not removals are not counted.
+	Total int `json:"total"`
+	// Tickers is the number of distinct tickers with at least one removal.
+	Tickers int `json:"tickers"`
+	// Codes are the removals and other recorded changes grouped by code, ordered by descending count.
+	Codes []CodeSummary `json:"codes"`
+}
+
+// CodeSummary summarizes all removals of a single code.
+type CodeSummary struct {
+	Code ReasonCode `json:"code"`
+	// Count is the number of removals with this code.
+	Count int `json:"count"`
+	// Tickers is the number of distinct tickers with a removal of this code.
+	Tickers int `json:"tickers"`
+	// Stages is the number of removals with this code per stage.
+	Stages map[string]int `json:"stages"`
+	// Providers is the number of removals with this code per provider.
+	Providers map[string]int `json:"providers"`
+}
+
+// Summarize groups the removal reasons by code, stage and provider.
+func (r RemovalReasons) Summarize() RemovalSummary {
+	summaries := make(map[ReasonCode]*CodeSummary)
+	tickersPerCode := make(map[ReasonCode]map[string]struct{})
+
+	summary := RemovalSummary{Codes: make([]CodeSummary, 0)}
+	for ticker, reasons := range r {
+		removed := false
+		for _, reason := range reasons {
+			code := reason.Code
+			if code == "" {
+				code = ReasonUnknown
+			}
+
+			cs, found := summaries[code]
+			if !found {
+				cs = &CodeSummary{
+					Code:      code,
+					Stages:    make(map[string]int),
+					Providers: make(map[string]int),
+				}
+				summaries[code] = cs
+				tickersPerCode[code] = make(map[string]struct{})
+			}
+
+			provider := reason.Provider
+			// market level removals are recorded with the currency pair as the provider.
+			if reason.Feed.ProviderConfig.Name == "" && provider == reason.Market.Ticker.String() {
+				provider = MarketLevelProvider
+			}
+
+			cs.Count++
+			cs.Stages[reason.Stage]++
+			cs.Providers[provider]++
+			tickersPerCode[code][ticker] = struct{}{}
+			if code.IsRemoval() {
+				removed = true
+				summary.Total++
+			}
+		}
+		if removed {
+			summary.Tickers++
+		}
+	}
+
+	for code, cs := range summaries {
+		cs.Tickers = len(tickersPerCode[code])
+		summary.Codes = append(summary.Codes, *cs)
+	}
+
+	sort.Slice(summary.Codes, func(i, j int) bool {
+		if summary.Codes[i].Count != summary.Codes[j].Count {
+			return summary.Codes[i].Count > summary.Codes[j].Count
+		}
+		return summary.Codes[i].Code < summary.Codes[j].Code
+	})
+
+	return summary
+}
@@ -0,0 +1,75 @@
+package types_test
+
+import (
+	"testing"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/generator/types"
+)
+
+func TestRemovalReasons_Summarize(t *testing.T) {
+	btc := mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD")}
+	eth := mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("ETH", "USD")}
+
+	reasons := types.NewRemovalReasons()
+	reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: btc, ProviderConfig: mmtypes.ProviderConfig{Name: "kraken_ws"}}, "kraken_ws",
+		types.NewReason("PruneByQuoteVolume", types.ReasonInsufficientVolume, "low volume").WithThreshold(10, 100))
+	reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: eth, ProviderConfig: mmtypes.ProviderConfig{Name: "kraken_ws"}}, "kraken_ws",
+		types.NewReason("PruneByQuoteVolume", types.ReasonInsufficientVolume, "low volume").WithThreshold(20, 100))
+	reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: eth, ProviderConfig: mmtypes.ProviderConfig{Name: "okx_ws"}}, "okx_ws",
+		types.NewReason("PruneByQuoteVolume", types.ReasonInsufficientVolume, "low volume").WithThreshold(30, 100))
+	reasons.AddRemovalReasonFromMarket(mmtypes.Market{Ticker: eth}, eth.String(),
+		types.NewReason("PruneMarkets", types.ReasonDisallowedCurrencyPair, "disallowed"))
+	reasons.AddRemovalReasonFromMarket(mmtypes.Market{Ticker: btc}, btc.String(), types.Reason{Message: "legacy"})
+
+	require.Equal(t, 20.0, *reasons[eth.String()][0].Params.Observed)
+	require.Equal(t, 100.0, *reasons[eth.String()][0].Params.Threshold)
+
+	summary := reasons.Summarize()
+	require.Equal(t, 5, summary.Total)
+	require.Equal(t, 2, summary.Tickers)
+	require.Equal(t, []types.CodeSummary{
+		{
+			Code:      types.ReasonInsufficientVolume,
+			Count:     3,
+			Tickers:   2,
+			Stages:    map[string]int{"PruneByQuoteVolume": 3},
+			Providers: map[string]int{"kraken_ws": 2, "okx_ws": 1},
+		},
+		{
+			Code:      types.ReasonDisallowedCurrencyPair,
+			Count:     1,
+			Tickers:   1,
+			Stages:    map[string]int{"PruneMarkets": 1},
+			Providers: map[string]int{types.MarketLevelProvider: 1},
+		},
+		{
+			Code:      types.ReasonUnknown,
+			Count:     1,
+			Tickers:   1,
+			Stages:    map[string]int{"": 1},
+			Providers: map[string]int{types.MarketLevelProvider: 1},
+		},
+	}, summary.Codes)
+}
+
+func TestRemovalReasons_SummarizeSkipsNonRemovals(t *testing.T) {
+	btc := mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD")}
+	sol := mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("SOL", "USD")}
+
+	reasons := types.NewRemovalReasons()
+	reasons.AddRemovalReasonFromMarket(mmtypes.Market{Ticker: btc}, btc.String(),
+		types.NewReason("PruneMarkets", types.ReasonDisallowedCurrencyPair, "disallowed"))
+	reasons.AddRemovalReasonFromMarket(mmtypes.Market{Ticker: sol}, "okx_ws",
+		types.NewReason("ApplyMarketRules", types.ReasonRuleApplied, "supplemental").WithRule("okx-supplemental"))
+
+	summary := reasons.Summarize()
+	require.Equal(t, 1, summary.Total)
+	require.Equal(t, 1, summary.Tickers)
+	require.Len(t, summary.Codes, 2)
+	require.Equal(t, types.ReasonRuleApplied, summary.Codes[1].Code)
+	require.Equal(t, map[string]int{"okx_ws": 1}, summary.Codes[1].Providers)
+}
@@ -1,10 +1,15 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
 	"math/big"
+	"sort"
 	"strconv"
 
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 	"github.com/skip-mev/connect/v2/x/marketmap/types/tickermetadata"
+	"golang.org/x/exp/maps"
 
 	"github.com/skip-mev/connect-mmu/types"
 )
@@ -13,25 +18,94 @@ const (
 	VenueCoinMarketcap = "coinmarketcap"
 )
 
+// TickerMetadata is the dYdX ticker metadata extended with generation details of the market.
+// The extensions are omitted when unset so that the metadata is unchanged for markets that do not use them.
+type TickerMetadata struct {
+	tickermetadata.DyDx
+	// Tier is the name of the configured tier of the market.
+	Tier string `json:"tier,omitempty"`
+	// NormalizeBy is the normalization pair that was selected for each provider of the market, keyed by provider.
+	// Only providers whose normalization pair was selected from candidates are recorded.
+	NormalizeBy map[string]string `json:"normalize_by,omitempty"`
+}
+
+// TickerMetadataFromJSON returns a TickerMetadata instance from a JSON string.
+func TickerMetadataFromJSON(jsonString string) (TickerMetadata, error) {
+	var md TickerMetadata
+	err := json.Unmarshal([]byte(jsonString), &md)
+	return md, err
+}
+
+// MarketTier returns the tier recorded in the ticker metadata of the market. An empty string is returned if the
+// market has no tier or its metadata cannot be parsed.
+func MarketTier(market mmtypes.Market) string {
+	if market.Ticker.Metadata_JSON == "" {
+		return ""
+	}
+
+	md, err := TickerMetadataFromJSON(market.Ticker.Metadata_JSON)
+	if err != nil {
+		return ""
+	}
+
+	return md.Tier
+}
+
 // ToTickerMetadataJSON creates a JSON string from the given database row based on the chain
-// type of this generation run.
-func ToTickerMetadataJSON(feed Feed, referencePrice *big.Float, totalLiquidity float64) (string, error) {
+// type of this generation run. normalizeBy contains the normalization pairs selected per provider, if any.
+func ToTickerMetadataJSON(feed Feed, referencePrice *big.Float, totalLiquidity float64, normalizeBy map[string]string) (string, error) {
 	// scale the price by decimals
-	md := tickermetadata.DyDx{
-		ReferencePrice: types.ScalePriceToUint64(referencePrice),
-		Liquidity:      uint64(totalLiquidity),
-		AggregateIDs:   make([]tickermetadata.AggregatorID, 0),
+	md := TickerMetadata{
+		DyDx: tickermetadata.DyDx{
+			ReferencePrice: types.ScalePriceToUint64(referencePrice),
+			Liquidity:      uint64(totalLiquidity),
+			AggregateIDs:   make([]tickermetadata.AggregatorID, 0),
+		},
+		Tier:        feed.Tier,
+		NormalizeBy: normalizeBy,
 	}
 
 	// Base Asset
 	md.AggregateIDs = append(md.AggregateIDs, tickermetadata.AggregatorID{
 		Venue: VenueCoinMarketcap,
-		ID:    strconv.FormatInt(feed.CMCInfo.BaseID, 10),
+		ID:    strconv.FormatInt(feed.CMCInfo.AggregateBaseID(), 10),
 	})
 
-	bz, err := tickermetadata.MarshalDyDx(md)
+	bz, err := json.Marshal(md)
 	if err != nil {
 		return "", err
 	}
 	return string(bz), nil
 }
+
+// ValidateNormalizeByRoutes checks that the market of every normalization pair recorded in the ticker metadata of
+// the markets exists in the market map, so that selected normalization routes can always be resolved.
+func ValidateNormalizeByRoutes(mm mmtypes.MarketMap) error {
+	names := maps.Keys(mm.Markets)
+	sort.Strings(names)
+
+	for _, name := range names {
+		market := mm.Markets[name]
+		if market.Ticker.Metadata_JSON == "" {
+			continue
+		}
+
+		md, err := TickerMetadataFromJSON(market.Ticker.Metadata_JSON)
+		if err != nil || len(md.NormalizeBy) == 0 {
+			continue
+		}
+
+		for _, pc := range market.ProviderConfigs {
+			if _, selected := md.NormalizeBy[pc.Name]; !selected || pc.NormalizeByPair == nil {
+				continue
+			}
+
+			if _, found := mm.Markets[pc.NormalizeByPair.String()]; !found {
+				return fmt.Errorf("market %s: provider %s is normalized by %s, which is not in the market map",
+					name, pc.Name, pc.NormalizeByPair.String())
+			}
+		}
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 	"golang.org/x/exp/slices"
 
+	"github.com/skip-mev/connect-mmu/store/provider"
 	"github.com/skip-mev/connect-mmu/types"
 )
 
@@ -22,25 +23,31 @@ func NewRemovalReasons() RemovalReasons {
 }
 
 // AddRemovalReasonFromFeed adds a reason for a provider to remove a Feed.
-func (r RemovalReasons) AddRemovalReasonFromFeed(feed Feed, provider string, reason string) {
+func (r RemovalReasons) AddRemovalReasonFromFeed(feed Feed, provider string, reason Reason) {
 	if _, found := r[feed.Ticker.String()]; !found {
 		r[feed.Ticker.String()] = []RemovalReason{}
 	}
 	r[feed.Ticker.String()] = append(r[feed.Ticker.String()], RemovalReason{
+		Reason:   reason.Message,
+		Code:     reason.Code,
+		Stage:    reason.Stage,
+		Params:   reason.Params,
 		Provider: provider,
-		Reason:   reason,
 		Feed:     feed,
 	})
 }
 
 // AddRemovalReasonFromMarket adds a reason for a provider to remove a Market.
-func (r RemovalReasons) AddRemovalReasonFromMarket(market mmtypes.Market, provider string, reason string) {
+func (r RemovalReasons) AddRemovalReasonFromMarket(market mmtypes.Market, provider string, reason Reason) {
 	if _, found := r[market.Ticker.String()]; !found {
 		r[market.Ticker.String()] = []RemovalReason{}
 	}
 	r[market.Ticker.String()] = append(r[market.Ticker.String()], RemovalReason{
+		Reason:   reason.Message,
+		Code:     reason.Code,
+		Stage:    reason.Stage,
+		Params:   reason.Params,
 		Provider: provider,
-		Reason:   reason,
 		Market:   market,
 	})
 }
@@ -55,6 +62,9 @@ func (r RemovalReasons) Merge(other RemovalReasons) {
 // RemovalReason is a struct containing the reason for a (provider, market) / market removal.
 type RemovalReason struct {
 	Reason   string         `json:"reason"`
+	Code     ReasonCode     `json:"code"`
+	Stage    string         `json:"stage"`
+	Params   ReasonParams   `json:"params"`
 	Provider string         `json:"provider"`
 	Market   mmtypes.Market `json:"market,omitempty"`
 	Feed     Feed           `json:"feed,omitempty"`
@@ -75,6 +85,13 @@ type Feed struct {
 	CMCInfo types.CoinMarketCapInfo
 	// LiquidityInfo contains buy and sell side liquidity denominated in USD.
 	LiquidityInfo types.LiquidityInfo
+	// Tier is the name of the configured tier of the Feed's market. Empty if the market is not in any tier.
+	Tier string
+	// NormalizeBySelected is true if the NormalizeByPair of the ProviderConfig was selected from the
+	// NormalizeByCandidates of the quote.
+	NormalizeBySelected bool
+	// TradingStatus is the trading status of the provider market of the Feed.
+	TradingStatus provider.TradingStatus
 }
 
 func NewFeed(
@@ -84,9 +101,6 @@ func NewFeed(
 	liquidityInfo types.LiquidityInfo,
 	cmcInfo types.CoinMarketCapInfo,
 ) Feed {
-	cmcInfo.BaseID = resolveWrappedAssetAliases(cmcInfo.BaseID)
-	cmcInfo.QuoteID = resolveWrappedAssetAliases(cmcInfo.QuoteID)
-
 	return Feed{
 		Ticker:           t,
 		ProviderConfig:   pc,
@@ -100,31 +114,10 @@ func NewFeed(
 // TickerString returns the string representation of the Feed's Market's Ticker.
 func (f *Feed) TickerString() string { return f.Ticker.String() }
 
-// knownWrappedAssetAliases maps the CMC ids of wrapped assets to their native asset CMC ID.
-// This is used to resolve wrapped assets to their native assets to assert that these are
-// _essentially_ the same asset.
-var knownWrappedAssetAliases = map[int64]int64{
-	// Wrapped SOL -> SOL
-	// - SOL:  https://coinmarketcap.com/currencies/solana/
-	// - Wrapped SOL: https://coinmarketcap.com/currencies/wrapped-solana/
-	16116: 5426,
-}
-
-func resolveWrappedAssetAliases(id int64) int64 {
-	if nativeAssetID, found := knownWrappedAssetAliases[id]; found {
-		return nativeAssetID
-	}
-
-	return id
-}
-
 // UniqueID returns an ID that uniquely identifies the asset pair that is being represented using CoinMarketCap IDs
 // ID is of form: "BaseAssetID-QuoteAssetID".
 func (f *Feed) UniqueID() string {
-	resolvedBaseID := resolveWrappedAssetAliases(f.CMCInfo.BaseID)
-	resolvedQuoteID := resolveWrappedAssetAliases(f.CMCInfo.QuoteID)
-
-	return strconv.FormatInt(resolvedBaseID, 10) + "-" + strconv.FormatInt(resolvedQuoteID, 10)
+	return strconv.FormatInt(f.CMCInfo.BaseID, 10) + "-" + strconv.FormatInt(f.CMCInfo.QuoteID, 10)
 }
 
 // Compare compares two Feeds
@@ -221,6 +214,18 @@ func (f Feeds) ToMarketMap() (mmtypes.MarketMap, error) {
 		return mmtypes.MarketMap{}, err
 	}
 
+	// collect the normalization pairs selected per provider so that they are recorded in the ticker metadata
+	normalizeByPerMarket := make(map[string]map[string]string)
+	for _, feed := range f {
+		if !feed.NormalizeBySelected || feed.ProviderConfig.NormalizeByPair == nil {
+			continue
+		}
+		if _, found := normalizeByPerMarket[feed.TickerString()]; !found {
+			normalizeByPerMarket[feed.TickerString()] = make(map[string]string)
+		}
+		normalizeByPerMarket[feed.TickerString()][feed.ProviderConfig.Name] = feed.ProviderConfig.NormalizeByPair.String()
+	}
+
 	mm := mmtypes.MarketMap{Markets: make(map[string]mmtypes.Market)}
 
 	for _, feed := range f {
@@ -236,7 +241,8 @@ func (f Feeds) ToMarketMap() (mmtypes.MarketMap, error) {
 			continue
 		}
 
-		tickerMD, err := ToTickerMetadataJSON(feed, avgRefPrices[feed.TickerString()], liquidityPerMarket[feed.UniqueID()])
+		tickerMD, err := ToTickerMetadataJSON(feed, avgRefPrices[feed.TickerString()], liquidityPerMarket[feed.UniqueID()],
+			normalizeByPerMarket[feed.TickerString()])
 		if err != nil {
 			return mmtypes.MarketMap{}, err
 		}
@@ -292,6 +298,18 @@ func (f *Feed) Equal(feedB Feed) bool {
 		return false
 	}
 
+	if f.Tier != feedB.Tier {
+		return false
+	}
+
+	if f.NormalizeBySelected != feedB.NormalizeBySelected {
+		return false
+	}
+
+	if f.TradingStatus != feedB.TradingStatus {
+		return false
+	}
+
 	return true
 }
 
@@ -110,9 +110,9 @@ func TestRemovalReasons(t *testing.T) {
 			CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD"),
 		}
 
-		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: btc}, "test", "test")
+		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: btc}, "test", types.NewReason("test", types.ReasonUnknown, "test"))
 
-		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: btc}, "test", "test")
+		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: btc}, "test", types.NewReason("test", types.ReasonUnknown, "test"))
 
 		// check that btc has 2 reasons
 		require.Equal(t, 2, len(reasons[btc.String()]))
@@ -128,8 +128,8 @@ func TestRemovalReasons(t *testing.T) {
 			CurrencyPair: connecttypes.NewCurrencyPair("ETH", "USD"),
 		}
 
-		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: btc}, "test", "test")
-		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: eth}, "test", "test")
+		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: btc}, "test", types.NewReason("test", types.ReasonUnknown, "test"))
+		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: eth}, "test", types.NewReason("test", types.ReasonUnknown, "test"))
 
 		// check that btc has 1 reason
 		require.Equal(t, 1, len(reasons[btc.String()]))
@@ -147,13 +147,13 @@ func TestRemovalReasons(t *testing.T) {
 			CurrencyPair: connecttypes.NewCurrencyPair("ETH", "USD"),
 		}
 
-		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: btc}, "test", "test")
+		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: btc}, "test", types.NewReason("test", types.ReasonUnknown, "test"))
 
 		// check that btc has 1 reason
 		require.Equal(t, 1, len(reasons[btc.String()]))
 
 		reasons2 := types.NewRemovalReasons()
-		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: eth}, "test", "test")
+		reasons.AddRemovalReasonFromFeed(types.Feed{Ticker: eth}, "test", types.NewReason("test", types.ReasonUnknown, "test"))
 
 		reasons.Merge(reasons2)
 
@@ -423,3 +423,65 @@ func TestRemovalReasons_Merge(t *testing.T) {
 		})
 	}
 }
+
+func TestToTickerMetadataJSON_Tier(t *testing.T) {
+	feed := types.NewFeed(mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD")}, mmtypes.ProviderConfig{},
+		1, 1, mmutypes.LiquidityInfo{}, mmutypes.NewCoinMarketCapInfo(1, 2825, 1, 2))
+
+	// markets without a tier keep the dYdX metadata unchanged
+	md, err := types.ToTickerMetadataJSON(feed, big.NewFloat(1), 100, nil)
+	require.NoError(t, err)
+	require.NotContains(t, md, "tier")
+	require.Empty(t, types.MarketTier(mmtypes.Market{Ticker: mmtypes.Ticker{Metadata_JSON: md}}))
+
+	feed.Tier = "core"
+	md, err = types.ToTickerMetadataJSON(feed, big.NewFloat(1), 100, nil)
+	require.NoError(t, err)
+	require.Equal(t, "core", types.MarketTier(mmtypes.Market{Ticker: mmtypes.Ticker{Metadata_JSON: md}}))
+
+	parsed, err := types.TickerMetadataFromJSON(md)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), parsed.Liquidity)
+	require.Equal(t, "1", parsed.AggregateIDs[0].ID)
+}
+
+func TestToTickerMetadataJSON_AssetAlias(t *testing.T) {
+	// cbBTC only feeds metadata of BTC
+	cmcInfo := mmutypes.NewCoinMarketCapInfo(32994, 2781, 1, 2)
+	cmcInfo.BaseNativeID = 1
+	feed := types.NewFeed(mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("CBBTC", "USD")}, mmtypes.ProviderConfig{},
+		1, 1, mmutypes.LiquidityInfo{}, cmcInfo)
+	require.Equal(t, "32994-2781", feed.UniqueID())
+
+	md, err := types.ToTickerMetadataJSON(feed, big.NewFloat(1), 100, nil)
+	require.NoError(t, err)
+
+	parsed, err := types.TickerMetadataFromJSON(md)
+	require.NoError(t, err)
+	require.Equal(t, "1", parsed.AggregateIDs[0].ID)
+
+	// the native ID follows the asset when the feed is inverted
+	feed.CMCInfo.Invert()
+	require.Equal(t, int64(2781), feed.CMCInfo.AggregateBaseID())
+	require.Equal(t, int64(1), feed.CMCInfo.QuoteNativeID)
+}
+
+func TestValidateNormalizeByRoutes(t *testing.T) {
+	usdtusd := connecttypes.NewCurrencyPair("USDT", "USD")
+	md := `{"reference_price":0,"liquidity":0,"aggregate_ids":[],"normalize_by":{"kraken_ws":"USDT/USD"}}`
+
+	mm := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+		"BTC/USD": {
+			Ticker: mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD"), Metadata_JSON: md},
+			ProviderConfigs: []mmtypes.ProviderConfig{
+				{Name: "kraken_ws", OffChainTicker: "XBTUSDT", NormalizeByPair: &usdtusd},
+				// not selected from candidates, so it is not validated
+				{Name: "okx_ws", OffChainTicker: "BTC-USDT", NormalizeByPair: &usdtusd},
+			},
+		},
+	}}
+	require.Error(t, types.ValidateNormalizeByRoutes(mm))
+
+	mm.Markets["USDT/USD"] = mmtypes.Market{Ticker: mmtypes.Ticker{CurrencyPair: usdtusd}}
+	require.NoError(t, types.ValidateNormalizeByRoutes(mm))
+}
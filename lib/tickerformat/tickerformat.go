@@ -0,0 +1,275 @@
+package tickerformat
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"golang.org/x/exp/maps"
+)
+
+// Format describes the off-chain ticker format that a Connect provider expects.
+type Format struct {
+	// Description describes the expected format.
+	Description string
+	// Example is a valid off-chain ticker.
+	Example string
+	// Pattern optionally is the regular expression that off-chain tickers must match.
+	Pattern *regexp.Regexp
+	// Check optionally performs additional checks on off-chain tickers, returning an error describing the violation.
+	Check func(offChainTicker string) error
+	// Suggest optionally returns a corrected off-chain ticker for an invalid one. An empty string means there is
+	// no suggestion.
+	Suggest func(offChainTicker string) string
+}
+
+// Validate checks that the off-chain ticker matches the format. The returned error describes the expected format
+// and, if possible, a corrected ticker.
+func (f Format) Validate(offChainTicker string) error {
+	if err := f.check(offChainTicker); err != nil {
+		msg := fmt.Sprintf("off-chain ticker %q is invalid: %v; expected %s, ex. %s",
+			offChainTicker, err, f.Description, f.Example)
+		if suggestion := f.suggest(offChainTicker); suggestion != "" {
+			msg += fmt.Sprintf("; did you mean %q?", suggestion)
+		}
+		return errors.New(msg)
+	}
+
+	return nil
+}
+
+func (f Format) check(offChainTicker string) error {
+	if offChainTicker == "" {
+		return fmt.Errorf("ticker is empty")
+	}
+
+	if f.Pattern != nil && !f.Pattern.MatchString(offChainTicker) {
+		return fmt.Errorf("ticker does not match %s", f.Pattern.String())
+	}
+
+	if f.Check != nil {
+		return f.Check(offChainTicker)
+	}
+
+	return nil
+}
+
+// suggest returns a corrected off-chain ticker that passes the checks of the format. The format's own suggestion
+// is tried first, then the ticker in upper and lower case.
+func (f Format) suggest(offChainTicker string) string {
+	candidates := make([]string, 0, 3)
+	if f.Suggest != nil {
+		candidates = append(candidates, f.Suggest(offChainTicker))
+	}
+	candidates = append(candidates, strings.ToUpper(offChainTicker), strings.ToLower(offChainTicker))
+
+	for _, candidate := range candidates {
+		if candidate != "" && candidate != offChainTicker && f.check(candidate) == nil {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+var (
+	concatenatedUpper = Format{
+		Description: "BASE and QUOTE concatenated in upper case",
+		Example:     "BTCUSDT",
+		Pattern:     regexp.MustCompile(`^[A-Z0-9]+$`),
+		Suggest:     func(t string) string { return strings.ToUpper(removeSeparators(t)) },
+	}
+	concatenatedLower = Format{
+		Description: "BASE and QUOTE concatenated in lower case",
+		Example:     "btcusdt",
+		Pattern:     regexp.MustCompile(`^[a-z0-9]+$`),
+		Suggest:     func(t string) string { return strings.ToLower(removeSeparators(t)) },
+	}
+	dashSeparated = Format{
+		Description: "BASE-QUOTE in upper case",
+		Example:     "BTC-USD",
+		Pattern:     regexp.MustCompile(`^[A-Z0-9]+-[A-Z0-9]+$`),
+		Suggest:     func(t string) string { return replaceSeparators(t, "-") },
+	}
+	underscoreSeparated = Format{
+		Description: "BASE_QUOTE in upper case",
+		Example:     "BTC_USDT",
+		Pattern:     regexp.MustCompile(`^[A-Z0-9]+_[A-Z0-9]+$`),
+		Suggest:     func(t string) string { return replaceSeparators(t, "_") },
+	}
+	slashSeparated = Format{
+		Description: "BASE/QUOTE in upper case",
+		Example:     "BTC/USD",
+		Pattern:     regexp.MustCompile(`^[A-Z0-9]+/[A-Z0-9]+$`),
+		Suggest:     func(t string) string { return replaceSeparators(t, "/") },
+	}
+	bitfinex = Format{
+		Description: "BASE and QUOTE concatenated in upper case without the \"t\" trading prefix, " +
+			"separated by \":\" if either is longer than 3 characters",
+		Example: "BTCUSD",
+		Pattern: regexp.MustCompile(`^[A-Z0-9]+(:[A-Z0-9]+)?$`),
+		Suggest: func(t string) string { return strings.TrimPrefix(t, "t") },
+	}
+	kraken = Format{
+		Description: "the Kraken pair name in upper case",
+		Example:     "XXBTZUSD",
+		Pattern:     regexp.MustCompile(`^[A-Z0-9.]+$`),
+	}
+)
+
+// registry maps provider names to the off-chain ticker format they expect.
+var registry = map[string]Format{
+	"binance_ws":        concatenatedUpper,
+	"binance_api":       concatenatedUpper,
+	"bybit_ws":          concatenatedUpper,
+	"mexc_ws":           concatenatedUpper,
+	"huobi_ws":          concatenatedLower,
+	"coinbase_ws":       dashSeparated,
+	"coinbase_api":      dashSeparated,
+	"okx_ws":            dashSeparated,
+	"kucoin_ws":         dashSeparated,
+	"gate_ws":           underscoreSeparated,
+	"crypto_dot_com_ws": underscoreSeparated,
+	"bitstamp_api":      slashSeparated,
+	"bitfinex_ws":       bitfinex,
+	"kraken_api":        kraken,
+	"raydium_api": defiFormat("RAYDIUM", regexp.MustCompile(`^[1-9A-Z]{32,44}$`), "a base58 mint address",
+		"SOL,RAYDIUM,SO11111111111111111111111111111111111111112/USDC,RAYDIUM,EPJFWDD5AUFQSSQEM2QN1XZYBAPC8G4WEGGKZWYTDT1V"),
+	"uniswapv3_api-ethereum": defiFormat("UNISWAP_V3", evmAddress, "a 0x-prefixed hex address",
+		"WETH,UNISWAP_V3,0XC02AAA39B223FE8D0A0E5C4F27EAD9083C756CC2/USDC,UNISWAP_V3,0XA0B86991C6218B36C1D19D4A2E9EB0CE3606EB48"),
+	"uniswapv3_api-base": defiFormat("UNISWAP_V3_BASE", evmAddress, "a 0x-prefixed hex address",
+		"WETH,UNISWAP_V3_BASE,0X4200000000000000000000000000000000000006/USDC,UNISWAP_V3_BASE,0X833589FCD6EDB6E08F4C7C32D4F71B54BDA02913"),
+}
+
+var evmAddress = regexp.MustCompile(`^0X[0-9A-F]{40}$`)
+
+// Register registers the off-chain ticker format of a provider.
+func Register(provider string, format Format) error {
+	if _, found := registry[provider]; found {
+		return fmt.Errorf("ticker format for provider %q is already registered", provider)
+	}
+
+	registry[provider] = format
+	return nil
+}
+
+// Lookup returns the off-chain ticker format of the provider.
+func Lookup(provider string) (Format, bool) {
+	format, found := registry[provider]
+	return format, found
+}
+
+// Providers returns the sorted names of all providers with a registered format.
+func Providers() []string {
+	providers := maps.Keys(registry)
+	sort.Strings(providers)
+	return providers
+}
+
+// Validate checks the off-chain ticker of the provider against its registered format.
+// Tickers of providers without a registered format are not validated.
+func Validate(provider, offChainTicker string) error {
+	format, found := registry[provider]
+	if !found {
+		return nil
+	}
+
+	if err := format.Validate(offChainTicker); err != nil {
+		return fmt.Errorf("provider %s: %w", provider, err)
+	}
+
+	return nil
+}
+
+// Violation is an off-chain ticker of a market's provider that does not match the provider's format.
+type Violation struct {
+	Market         string `json:"market"`
+	Provider       string `json:"provider"`
+	OffChainTicker string `json:"off_chain_ticker"`
+	Reason         string `json:"reason"`
+}
+
+// String returns a human readable representation of the Violation.
+func (v Violation) String() string {
+	return fmt.Sprintf("market %s: %s", v.Market, v.Reason)
+}
+
+// ValidateMarketMap returns all off-chain tickers of the market map that do not match their provider's format,
+// sorted by market and provider.
+func ValidateMarketMap(mm mmtypes.MarketMap) []Violation {
+	names := maps.Keys(mm.Markets)
+	sort.Strings(names)
+
+	violations := make([]Violation, 0)
+	for _, name := range names {
+		providers := slices.Clone(mm.Markets[name].ProviderConfigs)
+		sort.SliceStable(providers, func(i, j int) bool { return providers[i].Name < providers[j].Name })
+
+		for _, pc := range providers {
+			if err := Validate(pc.Name, pc.OffChainTicker); err != nil {
+				violations = append(violations, Violation{
+					Market:         name,
+					Provider:       pc.Name,
+					OffChainTicker: pc.OffChainTicker,
+					Reason:         err.Error(),
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// defiFormat returns the format of DeFi off-chain tickers, which encode the symbol, venue and token address of the
+// base and quote as SYMBOL,VENUE,ADDRESS/SYMBOL,VENUE,ADDRESS in upper case.
+func defiFormat(venue string, address *regexp.Regexp, addressDescription, example string) Format {
+	return Format{
+		Description: fmt.Sprintf("SYMBOL,%s,ADDRESS/SYMBOL,%s,ADDRESS in upper case", venue, venue),
+		Example:     example,
+		Check: func(offChainTicker string) error {
+			legs := strings.Split(offChainTicker, "/")
+			if len(legs) != 2 {
+				return fmt.Errorf("ticker must have a base and a quote separated by \"/\", got %d parts", len(legs))
+			}
+
+			for i, leg := range legs {
+				side := [2]string{"base", "quote"}[i]
+				fields := strings.Split(leg, ",")
+				if len(fields) != 3 {
+					return fmt.Errorf("%s must have 3 comma-separated fields (symbol, venue, address), got %d", side, len(fields))
+				}
+
+				if fields[0] == "" {
+					return fmt.Errorf("%s symbol is empty", side)
+				}
+				if fields[0] != strings.ToUpper(fields[0]) {
+					return fmt.Errorf("%s symbol %s must be upper case", side, fields[0])
+				}
+
+				if fields[1] != venue {
+					return fmt.Errorf("%s venue must be %s, got %s", side, venue, fields[1])
+				}
+
+				if !address.MatchString(fields[2]) {
+					return fmt.Errorf("%s address %s must be %s in upper case", side, fields[2], addressDescription)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// removeSeparators removes all common base/quote separators from the ticker.
+func removeSeparators(offChainTicker string) string {
+	return strings.NewReplacer("-", "", "_", "", "/", "", ":", "").Replace(offChainTicker)
+}
+
+// replaceSeparators replaces all common base/quote separators in the ticker with the given separator.
+func replaceSeparators(offChainTicker, separator string) string {
+	return strings.ToUpper(strings.NewReplacer("-", separator, "_", separator, "/", separator, ":", separator).Replace(offChainTicker))
+}
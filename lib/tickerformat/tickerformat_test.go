@@ -0,0 +1,118 @@
+package tickerformat_test
+
+import (
+	"regexp"
+	"testing"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/lib/tickerformat"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		provider   string
+		ticker     string
+		wantErr    bool
+		suggestion string
+	}{
+		{name: "valid binance", provider: "binance_ws", ticker: "BTCUSDT"},
+		{name: "binance with separator", provider: "binance_ws", ticker: "BTC-USDT", wantErr: true, suggestion: `"BTCUSDT"`},
+		{name: "valid coinbase", provider: "coinbase_ws", ticker: "BTC-USD"},
+		{name: "coinbase without separator", provider: "coinbase_ws", ticker: "BTCUSD", wantErr: true},
+		{name: "coinbase with wrong separator", provider: "coinbase_ws", ticker: "btc_usd", wantErr: true, suggestion: `"BTC-USD"`},
+		{name: "valid gate", provider: "gate_ws", ticker: "BTC_USDT"},
+		{name: "valid huobi", provider: "huobi_ws", ticker: "btcusdt"},
+		{name: "huobi upper case", provider: "huobi_ws", ticker: "BTCUSDT", wantErr: true, suggestion: `"btcusdt"`},
+		{name: "valid bitfinex", provider: "bitfinex_ws", ticker: "BTCUSD"},
+		{name: "valid long bitfinex", provider: "bitfinex_ws", ticker: "TESTBTC:TESTUSD"},
+		{name: "bitfinex with trading prefix", provider: "bitfinex_ws", ticker: "tBTCUSD", wantErr: true, suggestion: `"BTCUSD"`},
+		{name: "valid kraken", provider: "kraken_api", ticker: "XXBTZUSD"},
+		{name: "valid bitstamp", provider: "bitstamp_api", ticker: "BTC/USD"},
+		{
+			name:     "valid uniswap",
+			provider: "uniswapv3_api-ethereum",
+			ticker:   "WETH,UNISWAP_V3,0XC02AAA39B223FE8D0A0E5C4F27EAD9083C756CC2/USDC,UNISWAP_V3,0XA0B86991C6218B36C1D19D4A2E9EB0CE3606EB48",
+		},
+		{
+			name:       "uniswap in lower case",
+			provider:   "uniswapv3_api-ethereum",
+			ticker:     "WETH,UNISWAP_V3,0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2/USDC,UNISWAP_V3,0XA0B86991C6218B36C1D19D4A2E9EB0CE3606EB48",
+			wantErr:    true,
+			suggestion: "0XC02AAA39B223FE8D0A0E5C4F27EAD9083C756CC2",
+		},
+		{
+			name:     "uniswap with wrong venue",
+			provider: "uniswapv3_api-base",
+			ticker:   "WETH,UNISWAP_V3,0XC02AAA39B223FE8D0A0E5C4F27EAD9083C756CC2/USDC,UNISWAP_V3,0XA0B86991C6218B36C1D19D4A2E9EB0CE3606EB48",
+			wantErr:  true,
+		},
+		{name: "uniswap without quote", provider: "uniswapv3_api-ethereum", ticker: "WETH,UNISWAP_V3,0XC02AAA39B223FE8D0A0E5C4F27EAD9083C756CC2", wantErr: true},
+		{name: "empty ticker", provider: "okx_ws", ticker: "", wantErr: true},
+		{name: "unknown provider", provider: "unknown", ticker: "anything goes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tickerformat.Validate(tt.provider, tt.ticker)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.provider)
+			require.Contains(t, err.Error(), "ex. ")
+			if tt.suggestion != "" {
+				require.Contains(t, err.Error(), "did you mean")
+				require.Contains(t, err.Error(), tt.suggestion)
+			}
+		})
+	}
+}
+
+func TestExamplesAreValid(t *testing.T) {
+	for _, provider := range tickerformat.Providers() {
+		format, found := tickerformat.Lookup(provider)
+		require.True(t, found)
+		require.NoError(t, format.Validate(format.Example), provider)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	format := tickerformat.Format{Description: "upper case", Example: "BTC", Pattern: regexp.MustCompile(`^[A-Z]+$`)}
+	require.NoError(t, tickerformat.Register("test_register_ws", format))
+	require.Error(t, tickerformat.Register("test_register_ws", format))
+	require.Error(t, tickerformat.Validate("test_register_ws", "btc"))
+}
+
+func TestValidateMarketMap(t *testing.T) {
+	mm := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+		"BTC/USD": {
+			Ticker: mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD")},
+			ProviderConfigs: []mmtypes.ProviderConfig{
+				{Name: "okx_ws", OffChainTicker: "BTCUSD"},
+				{Name: "coinbase_ws", OffChainTicker: "BTC-USD"},
+				{Name: "binance_ws", OffChainTicker: "btcusd"},
+			},
+		},
+		"ETH/USD": {
+			Ticker: mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("ETH", "USD")},
+			ProviderConfigs: []mmtypes.ProviderConfig{
+				{Name: "coinbase_ws", OffChainTicker: "ETH-USD"},
+			},
+		},
+	}}
+
+	violations := tickerformat.ValidateMarketMap(mm)
+	require.Len(t, violations, 2)
+	require.Equal(t, "BTC/USD", violations[0].Market)
+	require.Equal(t, "binance_ws", violations[0].Provider)
+	require.Equal(t, "okx_ws", violations[1].Provider)
+
+	// the market map is not modified
+	require.Equal(t, "okx_ws", mm.Markets["BTC/USD"].ProviderConfigs[0].Name)
+}
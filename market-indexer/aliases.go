@@ -0,0 +1,155 @@
+package indexer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/market-indexer/coinmarketcap"
+)
+
+// wrappedSymbolPrefixes are symbol prefixes commonly used by wrapped or bridged tokens, ex. WETH, cbBTC or axlUSDC.
+var wrappedSymbolPrefixes = []string{"W", "CB", "AXL"}
+
+// wrappedSymbolSuffixes are symbol suffixes commonly used by bridged tokens, ex. USDC.e or USDbC.
+var wrappedSymbolSuffixes = []string{".E", "BC"}
+
+// AssetAliasSuggestion is an asset alias proposed from CoinMarketCap data during indexing.
+// Suggestions must be reviewed before being added to the generate config.
+type AssetAliasSuggestion struct {
+	config.AssetAliasConfig
+
+	// WrappedSymbol is the symbol of the wrapped asset.
+	WrappedSymbol string `json:"wrapped_symbol"`
+	// Platform is the platform the wrapped asset is issued on.
+	Platform string `json:"platform"`
+	// Reason describes why the alias was suggested.
+	Reason string `json:"reason"`
+}
+
+// SuggestAssetAliases proposes aliases of wrapped or bridged tokens to their native assets. A token is proposed as an
+// alias if its symbol is the symbol of another asset with a wrapped/bridged prefix or suffix, and it is either issued
+// on the native asset's own chain (ex. WETH on Ethereum) or its name mentions wrapping, bridging or the native asset.
+// Aliases of tokens issued on the native asset's own chain may back the native market. All others may only feed
+// metadata.
+func SuggestAssetAliases(data coinmarketcap.CryptoIDMap) []AssetAliasSuggestion {
+	// the native asset of a symbol is the best ranked asset with that symbol.
+	natives := make(map[string]coinmarketcap.CryptoIDMapData)
+	for _, d := range data {
+		symbol := strings.ToUpper(d.IDMap.Symbol)
+		native, found := natives[symbol]
+		if !found || (d.IDMap.Rank > 0 && (native.Rank == 0 || d.IDMap.Rank < native.Rank)) {
+			natives[symbol] = d.IDMap
+		}
+	}
+
+	suggestions := make([]AssetAliasSuggestion, 0)
+	for _, d := range data {
+		platform := tokenPlatform(d)
+		if platform == "" {
+			// only tokens can be wrapped or bridged assets.
+			continue
+		}
+
+		symbol := strings.ToUpper(d.IDMap.Symbol)
+		for _, candidate := range nativeSymbolCandidates(symbol) {
+			native, found := natives[candidate]
+			if !found || native.ID == d.IDMap.ID {
+				continue
+			}
+
+			backNative := strings.EqualFold(platform, native.Symbol)
+			if !backNative && !mentionsNative(d.IDMap.Name, native.Name) {
+				continue
+			}
+
+			suggestion := AssetAliasSuggestion{
+				AssetAliasConfig: config.AssetAliasConfig{
+					WrappedCMCID: int64(d.IDMap.ID),
+					NativeCMCID:  int64(native.ID),
+					BackNative:   backNative,
+				},
+				WrappedSymbol: d.IDMap.Symbol,
+				Platform:      platform,
+			}
+			if backNative {
+				suggestion.NativeSymbol = candidate
+				suggestion.Reason = fmt.Sprintf("%s is issued on the native chain of %s", d.IDMap.Symbol, native.Symbol)
+			} else {
+				suggestion.Reason = fmt.Sprintf("%s is a wrapped or bridged %s issued on %s; metadata only",
+					d.IDMap.Symbol, native.Symbol, platform)
+			}
+
+			suggestions = append(suggestions, suggestion)
+			break
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].WrappedCMCID < suggestions[j].WrappedCMCID
+	})
+
+	return suggestions
+}
+
+// tokenPlatform returns the symbol of the platform the asset is issued on. An empty string is returned if the
+// asset is not a token.
+func tokenPlatform(d coinmarketcap.WrappedCryptoIDMapData) string {
+	if d.IDMap.Platform != nil {
+		return d.IDMap.Platform.Symbol
+	}
+
+	if len(d.Info.ContractAddress) > 0 {
+		return d.Info.ContractAddress[0].Platform.Coin.Symbol
+	}
+
+	return ""
+}
+
+// nativeSymbolCandidates returns the symbols the given symbol may be a wrapped or bridged variant of.
+func nativeSymbolCandidates(symbol string) []string {
+	candidates := make([]string, 0)
+	for _, prefix := range wrappedSymbolPrefixes {
+		if trimmed := strings.TrimPrefix(symbol, prefix); trimmed != symbol && len(trimmed) > 1 {
+			candidates = append(candidates, trimmed)
+		}
+	}
+
+	for _, suffix := range wrappedSymbolSuffixes {
+		if trimmed := strings.TrimSuffix(symbol, suffix); trimmed != symbol && len(trimmed) > 1 {
+			candidates = append(candidates, trimmed)
+			// ex. USDbC -> USDC
+			if suffix == "BC" {
+				candidates = append(candidates, trimmed+"C")
+			}
+		}
+	}
+
+	return candidates
+}
+
+// mentionsNative reports whether the name of a token indicates that it is a wrapped or bridged native asset.
+func mentionsNative(name, nativeName string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "wrapped") || strings.Contains(name, "bridged") ||
+		strings.Contains(name, strings.ToLower(nativeName))
+}
+
+// AssetAliasSuggestions returns the asset aliases suggested while indexing known assets, excluding wrapped assets
+// that already have an alias in existing.
+func (idx *Indexer) AssetAliasSuggestions(existing []config.AssetAliasConfig) []AssetAliasSuggestion {
+	aliased := make(map[int64]struct{}, len(existing))
+	for _, alias := range existing {
+		aliased[alias.WrappedCMCID] = struct{}{}
+	}
+
+	suggestions := make([]AssetAliasSuggestion, 0, len(idx.assetAliasSuggestions))
+	for _, suggestion := range idx.assetAliasSuggestions {
+		if _, found := aliased[suggestion.WrappedCMCID]; !found {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+
+	return suggestions
+}
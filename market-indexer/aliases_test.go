@@ -0,0 +1,75 @@
+package indexer_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	indexer "github.com/skip-mev/connect-mmu/market-indexer"
+	"github.com/skip-mev/connect-mmu/market-indexer/coinmarketcap"
+)
+
+func cryptoIDMapData(t *testing.T, raw string) coinmarketcap.WrappedCryptoIDMapData {
+	t.Helper()
+
+	var idMap coinmarketcap.CryptoIDMapData
+	require.NoError(t, json.Unmarshal([]byte(raw), &idMap))
+
+	return coinmarketcap.WrappedCryptoIDMapData{IDMap: idMap}
+}
+
+func TestSuggestAssetAliases(t *testing.T) {
+	data := coinmarketcap.CryptoIDMap{
+		cryptoIDMapData(t, `{"id": 1, "rank": 1, "name": "Bitcoin", "symbol": "BTC"}`),
+		cryptoIDMapData(t, `{"id": 1027, "rank": 2, "name": "Ethereum", "symbol": "ETH"}`),
+		cryptoIDMapData(t, `{"id": 3408, "rank": 6, "name": "USDC", "symbol": "USDC",
+			"platform": {"id": 1027, "name": "Ethereum", "symbol": "ETH"}}`),
+		// issued on the native chain, so it may back the native market
+		cryptoIDMapData(t, `{"id": 2396, "rank": 30, "name": "WETH", "symbol": "WETH",
+			"platform": {"id": 1027, "name": "Ethereum", "symbol": "ETH"}}`),
+		// wrapped on another chain, so it may only feed metadata
+		cryptoIDMapData(t, `{"id": 3717, "rank": 15, "name": "Wrapped Bitcoin", "symbol": "WBTC",
+			"platform": {"id": 1027, "name": "Ethereum", "symbol": "ETH"}}`),
+		cryptoIDMapData(t, `{"id": 32994, "rank": 40, "name": "Coinbase Wrapped BTC", "symbol": "cbBTC",
+			"platform": {"id": 1027, "name": "Ethereum", "symbol": "ETH"}}`),
+		cryptoIDMapData(t, `{"id": 19891, "rank": 300, "name": "Bridged USDC", "symbol": "USDC.e",
+			"platform": {"id": 5805, "name": "Avalanche C-Chain", "symbol": "AVAX"}}`),
+		// the name does not indicate a wrapped asset
+		cryptoIDMapData(t, `{"id": 28752, "rank": 50, "name": "dogwifhat", "symbol": "WIF",
+			"platform": {"id": 5426, "name": "Solana", "symbol": "SOL"}}`),
+		cryptoIDMapData(t, `{"id": 1000, "rank": 900, "name": "Impossible Finance", "symbol": "IF"}`),
+	}
+
+	suggestions := indexer.SuggestAssetAliases(data)
+	require.Len(t, suggestions, 4)
+
+	byWrapped := make(map[string]indexer.AssetAliasSuggestion)
+	for _, s := range suggestions {
+		byWrapped[s.WrappedSymbol] = s
+	}
+
+	weth := byWrapped["WETH"]
+	require.Equal(t, int64(2396), weth.WrappedCMCID)
+	require.Equal(t, int64(1027), weth.NativeCMCID)
+	require.Equal(t, "ETH", weth.NativeSymbol)
+	require.True(t, weth.BackNative)
+
+	for _, symbol := range []string{"WBTC", "cbBTC"} {
+		alias := byWrapped[symbol].AssetAliasConfig
+		require.Equal(t, int64(1), alias.NativeCMCID, symbol)
+		require.False(t, alias.BackNative, symbol)
+		require.Empty(t, alias.NativeSymbol, symbol)
+		require.NoError(t, alias.Validate(), symbol)
+	}
+
+	usdce := byWrapped["USDC.e"]
+	require.Equal(t, int64(3408), usdce.NativeCMCID)
+	require.False(t, usdce.BackNative)
+	require.Equal(t, "AVAX", usdce.Platform)
+
+	// suggestions are sorted by wrapped ID
+	for i := 1; i < len(suggestions); i++ {
+		require.Less(t, suggestions[i-1].WrappedCMCID, suggestions[i].WrappedCMCID)
+	}
+}
@@ -69,6 +69,9 @@ func (idx *Indexer) IndexKnownAssetInfo(ctx context.Context) (coinmarketcap.Prov
 		idx.knownAssets.AddAssetFromInfo(info)
 	}
 
+	idx.assetAliasSuggestions = SuggestAssetAliases(cmcCryptoData)
+	idx.logger.Info("suggested asset aliases", zap.Int("count", len(idx.assetAliasSuggestions)))
+
 	// iterate through market pairs we care about and add any extra info to the DB:
 	cmcMarketPairs, err := idx.cmcIndexer.GetProviderMarketsPairs(ctx, idx.config)
 	if err != nil {
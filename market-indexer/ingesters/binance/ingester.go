@@ -58,10 +58,6 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 
 	pms := make([]provider.CreateProviderMarket, 0, len(tickers))
 	for _, ticker := range tickers {
-		// markets that are wound down have first id and last id -1.
-		if ticker.FirstID == -1 && ticker.LastID == -1 {
-			continue
-		}
 		pm, err := ticker.toProviderMarket()
 		if err != nil {
 			return nil, err
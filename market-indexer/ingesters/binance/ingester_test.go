@@ -10,6 +10,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/binance"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/binance/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if binance ingester's products endpoint returns an error, the
@@ -25,7 +26,7 @@ func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 	require.Error(t, err)
 }
 
-func TestIngesterIgnoresSpunDownMarkets(t *testing.T) {
+func TestIngesterRecordsSpunDownMarketsAsHalted(t *testing.T) {
 	client := mocks.NewClient(t)
 	ingester := binance.NewWithClient(zap.NewNop(), client)
 
@@ -58,9 +59,12 @@ func TestIngesterIgnoresSpunDownMarkets(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	require.Len(t, markets, 1)
-	require.Equal(t, "ETH", markets[0].Create.TargetBase)
-	require.Equal(t, "USDT", markets[0].Create.TargetQuote)
+	require.Len(t, markets, 2)
+	require.Equal(t, "BTC", markets[0].Create.TargetBase)
+	require.Equal(t, provider.TradingStatusHalted, markets[0].Create.TradingStatus)
+	require.Equal(t, "ETH", markets[1].Create.TargetBase)
+	require.Equal(t, "USDT", markets[1].Create.TargetQuote)
+	require.Equal(t, provider.TradingStatusTrading, markets[1].Create.TradingStatus)
 }
 
 // Test that the ingester only returns markets that are Trading.
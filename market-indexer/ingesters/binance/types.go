@@ -57,6 +57,15 @@ type TickerData struct {
 	LastID    int    `json:"lastId"`
 }
 
+// tradingStatus returns the trading status of the ticker. The tickers endpoint only reports a status indirectly:
+// markets that are wound down have first id and last id -1.
+func (d *TickerData) tradingStatus() provider.TradingStatus {
+	if d.FirstID == -1 && d.LastID == -1 {
+		return provider.TradingStatusHalted
+	}
+	return provider.TradingStatusTrading
+}
+
 func (d *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
 	quoteVolume, err := strconv.ParseFloat(d.QuoteVolume, 64)
 	if err != nil {
@@ -90,6 +99,7 @@ func (d *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
 			ProviderName:   ProviderName,
 			QuoteVolume:    quoteVolume,
 			ReferencePrice: lastPrice,
+			TradingStatus:  d.tradingStatus(),
 		},
 	}
 
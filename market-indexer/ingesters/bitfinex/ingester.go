@@ -107,6 +107,7 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 					ProviderName:   ProviderName,
 					QuoteVolume:    quoteVolume,
 					ReferencePrice: lastPrice,
+					TradingStatus:  provider.TradingStatusTrading,
 				},
 			}
 			if err := pm.ValidateBasic(); err != nil {
@@ -8,7 +8,9 @@ import (
 )
 
 const (
-	EndpointTickers = "https://www.bitstamp.net/api/v2/ticker/"
+	EndpointTickers      = "https://www.bitstamp.net/api/v2/ticker/"
+	EndpointTradingPairs = "https://www.bitstamp.net/api/v2/trading-pairs-info/"
+	EndpointCurrencies   = "https://www.bitstamp.net/api/v2/currencies/"
 )
 
 var _ Client = &httpClient{}
@@ -20,6 +22,12 @@ var _ Client = &httpClient{}
 type Client interface {
 	// Tickers returns all tickers on Bitstamp.
 	Tickers(context.Context) ([]TickerData, error)
+
+	// TradingPairs returns all trading pairs on Bitstamp with whether trading is enabled.
+	TradingPairs(context.Context) ([]TradingPairData, error)
+
+	// Currencies returns all currencies on Bitstamp with their deposit and withdrawal status.
+	Currencies(context.Context) ([]CurrencyData, error)
 }
 
 type httpClient struct {
@@ -49,3 +57,35 @@ func (h *httpClient) Tickers(ctx context.Context) ([]TickerData, error) {
 
 	return tickersResp, nil
 }
+
+// TradingPairs returns all trading pairs on the Bitstamp API.
+func (h *httpClient) TradingPairs(ctx context.Context) ([]TradingPairData, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointTradingPairs)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pairsResp []TradingPairData
+	if err := json.NewDecoder(resp.Body).Decode(&pairsResp); err != nil {
+		return nil, err
+	}
+
+	return pairsResp, nil
+}
+
+// Currencies returns all currencies on the Bitstamp API.
+func (h *httpClient) Currencies(ctx context.Context) ([]CurrencyData, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointCurrencies)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var currenciesResp []CurrencyData
+	if err := json.NewDecoder(resp.Body).Decode(&currenciesResp); err != nil {
+		return nil, err
+	}
+
+	return currenciesResp, nil
+}
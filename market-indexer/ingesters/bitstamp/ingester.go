@@ -59,11 +59,22 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 
 	ig.logger.Info("fetched data", zap.Int("count", len(tickers)))
 
+	statuses, err := ig.tradingStatuses(ctx)
+	if err != nil {
+		ig.logger.Error("failed to fetch trading statuses", zap.Error(err))
+		return nil, err
+	}
+
 	pms := make([]provider.CreateProviderMarket, 0, len(tickers))
 	for _, ticker := range tickers {
 		ig.logger.Debug("parsing", zap.Any("ticker", ticker))
 
-		pm, err := ticker.toProviderMarket()
+		status, found := statuses[ticker.Pair]
+		if !found {
+			status = provider.TradingStatusUnknown
+		}
+
+		pm, err := ticker.toProviderMarket(status)
 		if err != nil {
 			return nil, err
 		}
@@ -76,6 +87,35 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 	return pms, nil
 }
 
+// tradingStatuses returns the normalized trading status of every trading pair, keyed by its name.
+func (ig *Ingester) tradingStatuses(ctx context.Context) (map[string]provider.TradingStatus, error) {
+	pairs, err := ig.client.TradingPairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currencyList, err := ig.client.Currencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := make(map[string]CurrencyData, len(currencyList))
+	for _, currency := range currencyList {
+		currencies[currency.Currency] = currency
+	}
+
+	statuses := make(map[string]provider.TradingStatus, len(pairs))
+	for _, pair := range pairs {
+		status, err := tradingStatus(pair, currencies)
+		if err != nil {
+			return nil, err
+		}
+		statuses[pair.Name] = status
+	}
+
+	return statuses, nil
+}
+
 // Name returns the Ingester's human-readable name.
 func (ig *Ingester) Name() string {
 	return Name
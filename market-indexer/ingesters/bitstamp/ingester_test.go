@@ -10,6 +10,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bitstamp"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bitstamp/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if bitstamp ingester's products endpoint returns an error, the
@@ -47,6 +48,11 @@ func TestIngesterParse(t *testing.T) {
 			OpenPrice: "15.23",
 		},
 	}, nil)
+	client.On("TradingPairs", ctx).Return([]bitstamp.TradingPairData{
+		{Name: "BTC/USD", Trading: bitstamp.StatusEnabled},
+		{Name: "ETH/USD", Trading: bitstamp.StatusEnabled},
+	}, nil)
+	client.On("Currencies", ctx).Return([]bitstamp.CurrencyData{}, nil)
 
 	markets, err := ingester.GetProviderMarkets(ctx)
 	if err != nil {
@@ -64,3 +70,41 @@ func TestIngesterParse(t *testing.T) {
 	require.Equal(t, 3.60165e+07, markets[1].Create.QuoteVolume)
 	require.Equal(t, 15.23, markets[1].Create.ReferencePrice)
 }
+
+// Test that the ingester records the trading status of the pairs and their currencies.
+func TestIngesterTradingStatus(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := bitstamp.NewWithClient(zap.NewNop(), client)
+
+	ctx := context.Background()
+	client.On("Tickers", ctx).Return([]bitstamp.TickerData{
+		{Pair: "BTC/USD", Volume: "1", High: "1", Low: "1", OpenPrice: "1"},
+		{Pair: "ETH/USD", Volume: "1", High: "1", Low: "1", OpenPrice: "1"},
+		{Pair: "DOGE/USD", Volume: "1", High: "1", Low: "1", OpenPrice: "1"},
+		{Pair: "ATOM/USD", Volume: "1", High: "1", Low: "1", OpenPrice: "1"},
+	}, nil)
+	client.On("TradingPairs", ctx).Return([]bitstamp.TradingPairData{
+		{Name: "BTC/USD", Trading: bitstamp.StatusEnabled},
+		{Name: "ETH/USD", Trading: "Disabled"},
+		{Name: "DOGE/USD", Trading: bitstamp.StatusEnabled},
+	}, nil)
+	client.On("Currencies", ctx).Return([]bitstamp.CurrencyData{
+		{Currency: "BTC", Deposit: bitstamp.StatusEnabled, Withdrawal: bitstamp.StatusEnabled},
+		{Currency: "USD", Deposit: bitstamp.StatusEnabled, Withdrawal: bitstamp.StatusEnabled},
+		{Currency: "DOGE", Deposit: "Disabled", Withdrawal: bitstamp.StatusEnabled},
+	}, nil)
+
+	markets, err := ingester.GetProviderMarkets(ctx)
+	require.NoError(t, err)
+	require.Len(t, markets, 4)
+
+	expected := []provider.TradingStatus{
+		provider.TradingStatusTrading,
+		provider.TradingStatusHalted,
+		provider.TradingStatusDepositWithdrawSuspended,
+		provider.TradingStatusUnknown,
+	}
+	for i, status := range expected {
+		require.Equal(t, status, markets[i].Create.TradingStatus, markets[i].Create.OffChainTicker)
+	}
+}
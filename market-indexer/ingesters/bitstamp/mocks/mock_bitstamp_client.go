@@ -23,6 +23,64 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// Currencies provides a mock function with given fields: _a0
+func (_m *Client) Currencies(_a0 context.Context) ([]bitstamp.CurrencyData, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Currencies")
+	}
+
+	var r0 []bitstamp.CurrencyData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]bitstamp.CurrencyData, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []bitstamp.CurrencyData); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitstamp.CurrencyData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Currencies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Currencies'
+type Client_Currencies_Call struct {
+	*mock.Call
+}
+
+// Currencies is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *Client_Expecter) Currencies(_a0 interface{}) *Client_Currencies_Call {
+	return &Client_Currencies_Call{Call: _e.mock.On("Currencies", _a0)}
+}
+
+func (_c *Client_Currencies_Call) Run(run func(_a0 context.Context)) *Client_Currencies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_Currencies_Call) Return(_a0 []bitstamp.CurrencyData, _a1 error) *Client_Currencies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Currencies_Call) RunAndReturn(run func(context.Context) ([]bitstamp.CurrencyData, error)) *Client_Currencies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Tickers provides a mock function with given fields: _a0
 func (_m *Client) Tickers(_a0 context.Context) ([]bitstamp.TickerData, error) {
 	ret := _m.Called(_a0)
@@ -81,6 +139,64 @@ func (_c *Client_Tickers_Call) RunAndReturn(run func(context.Context) ([]bitstam
 	return _c
 }
 
+// TradingPairs provides a mock function with given fields: _a0
+func (_m *Client) TradingPairs(_a0 context.Context) ([]bitstamp.TradingPairData, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TradingPairs")
+	}
+
+	var r0 []bitstamp.TradingPairData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]bitstamp.TradingPairData, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []bitstamp.TradingPairData); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bitstamp.TradingPairData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_TradingPairs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TradingPairs'
+type Client_TradingPairs_Call struct {
+	*mock.Call
+}
+
+// TradingPairs is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *Client_Expecter) TradingPairs(_a0 interface{}) *Client_TradingPairs_Call {
+	return &Client_TradingPairs_Call{Call: _e.mock.On("TradingPairs", _a0)}
+}
+
+func (_c *Client_TradingPairs_Call) Run(run func(_a0 context.Context)) *Client_TradingPairs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_TradingPairs_Call) Return(_a0 []bitstamp.TradingPairData, _a1 error) *Client_TradingPairs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_TradingPairs_Call) RunAndReturn(run func(context.Context) ([]bitstamp.TradingPairData, error)) *Client_TradingPairs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
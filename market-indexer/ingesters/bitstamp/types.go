@@ -9,7 +9,12 @@ import (
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
-const delimiter = "/"
+const (
+	delimiter = "/"
+
+	// StatusEnabled is the trading, deposit or withdrawal status of a pair or currency that is enabled.
+	StatusEnabled = "Enabled"
+)
 
 // TickerData is the data payload returned from the Bitstamp API
 // in response to a Tickers request.
@@ -45,6 +50,79 @@ type TickerData struct {
 	Volume string `json:"volume"`
 }
 
+// TradingPairData is the data payload returned from the Bitstamp API in response to a TradingPairs request.
+//
+// Docs: https://www.bitstamp.net/api/#tag/Market-info/operation/GetTradingPairsInfo
+//
+// Ex.
+// [
+//
+//	{
+//	  "name": "BTC/USD",
+//	  "url_symbol": "btcusd",
+//	  "base_decimals": 8,
+//	  "counter_decimals": 0,
+//	  "minimum_order": "10 USD",
+//	  "trading": "Enabled",
+//	  "instant_and_market_orders": "Enabled",
+//	  "description": "Bitcoin / U.S. dollar"
+//	}
+//
+// ].
+type TradingPairData struct {
+	Name string `json:"name"`
+	// Trading is Enabled or Disabled.
+	Trading string `json:"trading"`
+}
+
+// CurrencyData is the data payload returned from the Bitstamp API in response to a Currencies request.
+//
+// Docs: https://www.bitstamp.net/api/#tag/Market-info/operation/GetCurrencies
+//
+// Ex.
+// [
+//
+//	{
+//	  "name": "Bitcoin",
+//	  "currency": "BTC",
+//	  "type": "crypto",
+//	  "decimals": 8,
+//	  "deposit": "Enabled",
+//	  "withdrawal": "Enabled"
+//	}
+//
+// ].
+type CurrencyData struct {
+	Currency   string `json:"currency"`
+	Deposit    string `json:"deposit"`
+	Withdrawal string `json:"withdrawal"`
+}
+
+// suspended returns true if the currency cannot be deposited or withdrawn.
+func (cd CurrencyData) suspended() bool {
+	return cd.Deposit != StatusEnabled || cd.Withdrawal != StatusEnabled
+}
+
+// tradingStatus normalizes whether trading of a pair is enabled and the status of its currencies. Bitstamp does not
+// publish scheduled delistings. Currencies that are not found are assumed to have no restrictions.
+func tradingStatus(pair TradingPairData, currencies map[string]CurrencyData) (provider.TradingStatus, error) {
+	if pair.Trading != StatusEnabled {
+		return provider.TradingStatusHalted, nil
+	}
+
+	base, quote, err := symbolToBaseQuote(pair.Name)
+	if err != nil {
+		return "", err
+	}
+	for _, currency := range []string{base, quote} {
+		if cd, found := currencies[currency]; found && cd.suspended() {
+			return provider.TradingStatusDepositWithdrawSuspended, nil
+		}
+	}
+
+	return provider.TradingStatusTrading, nil
+}
+
 func symbolToBaseQuote(symbol string) (string, string, error) {
 	splitSymbol := strings.Split(symbol, delimiter)
 	if len(splitSymbol) != 2 {
@@ -54,7 +132,7 @@ func symbolToBaseQuote(symbol string) (string, string, error) {
 	return splitSymbol[0], splitSymbol[1], nil
 }
 
-func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
+func (td *TickerData) toProviderMarket(status provider.TradingStatus) (provider.CreateProviderMarket, error) {
 	baseVol, err := strconv.ParseFloat(td.Volume, 64)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -100,6 +178,7 @@ func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error)
 			ProviderName:   ProviderName,
 			QuoteVolume:    quoteVol,
 			ReferencePrice: refPrice,
+			TradingStatus:  status,
 		},
 	}
 
@@ -15,9 +15,24 @@ const (
 	Name         = "bybit"
 	ProviderName = Name + types.ProviderNameSuffixWS
 
-	StatusTrading = "Trading"
+	StatusTrading    = "Trading"
+	StatusDelivering = "Delivering"
+	StatusClosed     = "Closed"
 )
 
+// tradingStatus normalizes the status of an instrument. Delivering instruments are being delisted, all statuses other
+// than trading and delivering (ex. PreLaunch, Closed) are considered halted.
+func tradingStatus(status string) provider.TradingStatus {
+	switch status {
+	case StatusTrading:
+		return provider.TradingStatusTrading
+	case StatusDelivering:
+		return provider.TradingStatusDelistingScheduled
+	default:
+		return provider.TradingStatusHalted
+	}
+}
+
 var _ ingesters.Ingester = &Ingester{}
 
 // Ingester is the bybit implementation of a market data Ingester.
@@ -68,12 +83,14 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 
 	pms := make([]provider.CreateProviderMarket, 0, len(instruments.Result.List))
 	for _, item := range instruments.Result.List {
-		if item.Status != StatusTrading {
-			continue
-		}
-
 		ticker, found := tickerMap[item.Symbol]
 		if !found {
+			// instruments that are not trading may not have ticker data
+			if tradingStatus(item.Status) != provider.TradingStatusTrading {
+				ig.logger.Debug("skipping instrument without ticker data", zap.String("symbol", item.Symbol),
+					zap.String("status", item.Status))
+				continue
+			}
 			return nil, fmt.Errorf("ticker not found for symbol %s", item.Symbol)
 		}
 
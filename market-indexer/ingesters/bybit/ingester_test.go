@@ -10,6 +10,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bybit"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/bybit/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if bybit ingester's products endpoint returns an error, the
@@ -25,7 +26,8 @@ func TestIngesterReturnsErrorOnInstrumentsError(t *testing.T) {
 	require.Error(t, err)
 }
 
-// Test that the ingester only returns markets that are Trading.
+// Test that the ingester records the trading status of each market, and skips markets that are not trading
+// without ticker data.
 func TestIngesterGetsValidMarkets(t *testing.T) {
 	client := mocks.NewClient(t)
 	ingester := bybit.NewWithClient(zap.NewNop(), client)
@@ -52,6 +54,12 @@ func TestIngesterGetsValidMarkets(t *testing.T) {
 					BaseCoin:  "ETH",
 					QuoteCoin: "USDT",
 				},
+				{
+					Symbol:    "SOLUSDT",
+					Status:    bybit.StatusDelivering,
+					BaseCoin:  "SOL",
+					QuoteCoin: "USDT",
+				},
 			},
 		},
 	}, nil)
@@ -71,6 +79,13 @@ func TestIngesterGetsValidMarkets(t *testing.T) {
 					Volume24H:    "1000",
 					LastPrice:    "124.32003",
 				},
+				{
+					Symbol:       "SOLUSDT",
+					HighPrice24H: "10",
+					LowPrice24H:  "10",
+					Volume24H:    "10",
+					LastPrice:    "10",
+				},
 			},
 		},
 	}, nil)
@@ -80,11 +95,15 @@ func TestIngesterGetsValidMarkets(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	require.Len(t, markets, 1)
+	require.Len(t, markets, 2)
 	require.Equal(t, "BTC", markets[0].Create.TargetBase)
 	require.Equal(t, "USDT", markets[0].Create.TargetQuote)
 	require.Equal(t, float64(750000), markets[0].Create.QuoteVolume)
 	require.Equal(t, 124.32003, markets[0].Create.ReferencePrice)
+	require.Equal(t, provider.TradingStatusTrading, markets[0].Create.TradingStatus)
+
+	require.Equal(t, "SOL", markets[1].Create.TargetBase)
+	require.Equal(t, provider.TradingStatusDelistingScheduled, markets[1].Create.TradingStatus)
 }
 
 func TestIngesterErrorsOnTickersError(t *testing.T) {
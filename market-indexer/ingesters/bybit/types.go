@@ -104,6 +104,7 @@ func (rd *InstrumentData) toProviderMarket(tickerData TickerData) (provider.Crea
 			ProviderName:   ProviderName,
 			QuoteVolume:    quoteVol,
 			ReferencePrice: refPrice,
+			TradingStatus:  tradingStatus(rd.Status),
 		},
 	}
 
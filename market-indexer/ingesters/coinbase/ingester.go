@@ -40,8 +40,8 @@ func NewWithClient(logger *zap.Logger, client Client) *Ingester {
 }
 
 // GetProviderMarkets fetches the market data from the coinbase API. Specifically
-// we query the volume per ticker on coinbase + meta-data about the ticker. All
-// tickers considered must have trading-enabled + status == "online".
+// we query the volume per ticker on coinbase + meta-data about the ticker. The
+// trading status of each ticker is derived from its status and trading flags.
 func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreateProviderMarket, error) {
 	// query the all tickers endpoint
 	products, err := i.client.Products(ctx)
@@ -62,17 +62,15 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 			continue
 		}
 
-		// only consider trading-enabled tickers
-		if !product.TradingDisabled && product.Status == "online" {
-			markets = append(markets, provider.CreateProviderMarket{
-				Create: provider.CreateProviderMarketParams{
-					TargetBase:     targetBase,
-					TargetQuote:    targetQuote,
-					OffChainTicker: product.ID,
-					ProviderName:   ProviderName,
-				},
-			})
-		}
+		markets = append(markets, provider.CreateProviderMarket{
+			Create: provider.CreateProviderMarketParams{
+				TargetBase:     targetBase,
+				TargetQuote:    targetQuote,
+				OffChainTicker: product.ID,
+				ProviderName:   ProviderName,
+				TradingStatus:  product.TradingStatus(),
+			},
+		})
 	}
 
 	// query the volume per ticker endpoint
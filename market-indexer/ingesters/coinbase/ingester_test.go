@@ -10,6 +10,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/coinbase"
 	coinbasemocks "github.com/skip-mev/connect-mmu/market-indexer/ingesters/coinbase/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if coinbase ingester's products endpoint returns an error, the
@@ -25,9 +26,8 @@ func TestCoinbaseIngestorReturnsErrorOnProductsError(t *testing.T) {
 	require.Error(t, err)
 }
 
-// Test that the coinbase ingester only returns markets with trading enabled
-// and status == "online".
-func TestCoinbaseIngestorIgnoresNonTradingMarkets(t *testing.T) {
+// Test that the coinbase ingester records the trading status of each market.
+func TestCoinbaseIngestorRecordsTradingStatus(t *testing.T) {
 	client := coinbasemocks.NewClient(t)
 	ingester := coinbase.NewWithClient(zap.NewNop(), client)
 
@@ -49,6 +49,13 @@ func TestCoinbaseIngestorIgnoresNonTradingMarkets(t *testing.T) {
 			Status:          "online",
 			TradingDisabled: true, // trading is disabled
 		},
+		{
+			Base:     "SOL",
+			Quote:    "USD",
+			ID:       "SOL-USD",
+			Status:   "online",
+			PostOnly: true,
+		},
 		{
 			Base:            "ETH",
 			Quote:           "BTC",
@@ -62,13 +69,18 @@ func TestCoinbaseIngestorIgnoresNonTradingMarkets(t *testing.T) {
 	client.On("Stats", ctx).Return(coinbase.Stats{}, nil)
 
 	markets, err := ingester.GetProviderMarkets(ctx)
-	if err != nil {
-		require.NoError(t, err)
-	}
+	require.NoError(t, err)
 
-	require.Len(t, markets, 1)
-	require.Equal(t, "ETH", markets[0].Create.TargetBase)
-	require.Equal(t, "BTC", markets[0].Create.TargetQuote)
+	statuses := make(map[string]provider.TradingStatus)
+	for _, market := range markets {
+		statuses[market.Create.OffChainTicker] = market.Create.TradingStatus
+	}
+	require.Equal(t, map[string]provider.TradingStatus{
+		"BTC-USD": provider.TradingStatusHalted,
+		"ETH-USD": provider.TradingStatusHalted,
+		"SOL-USD": provider.TradingStatusPostOnly,
+		"ETH-BTC": provider.TradingStatusTrading,
+	}, statuses)
 }
 
 func TestCoinbaseIngestorErrorsOnStatsError(t *testing.T) {
@@ -132,9 +144,9 @@ func TestCoinbaseIngestorUpdatesVolumes(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	require.Len(t, markets, 1)
-	require.Equal(t, "ETH", markets[0].Create.TargetBase)
-	require.Equal(t, "BTC", markets[0].Create.TargetQuote)
-	require.Equal(t, int64(96), int64(markets[0].Create.QuoteVolume))
-	require.Equal(t, 0.0235, markets[0].Create.ReferencePrice)
+	require.Len(t, markets, 3)
+	require.Equal(t, "ETH", markets[2].Create.TargetBase)
+	require.Equal(t, "BTC", markets[2].Create.TargetQuote)
+	require.Equal(t, int64(96), int64(markets[2].Create.QuoteVolume))
+	require.Equal(t, 0.0235, markets[2].Create.ReferencePrice)
 }
@@ -1,5 +1,10 @@
 package coinbase
 
+import "github.com/skip-mev/connect-mmu/store/provider"
+
+// StatusOnline is the status of products that are online.
+const StatusOnline = "online"
+
 // Product is the representation of a single ticker returned
 // from the coinbase products api (https://api.exchange.coinbase.com/products). The
 // response contains an array of these objects
@@ -13,6 +18,8 @@ package coinbase
 //	    ...
 //	    "status": "online",
 //	    "trading_disabled": false,
+//	    "post_only": false,
+//	    "cancel_only": false,
 //			...
 //	}
 type Product struct {
@@ -21,6 +28,20 @@ type Product struct {
 	Quote           string `json:"quote_currency"`
 	Status          string `json:"status"`
 	TradingDisabled bool   `json:"trading_disabled"`
+	PostOnly        bool   `json:"post_only"`
+	CancelOnly      bool   `json:"cancel_only"`
+}
+
+// TradingStatus returns the normalized trading status of the product.
+func (p Product) TradingStatus() provider.TradingStatus {
+	switch {
+	case p.Status != StatusOnline, p.TradingDisabled, p.CancelOnly:
+		return provider.TradingStatusHalted
+	case p.PostOnly:
+		return provider.TradingStatusPostOnly
+	default:
+		return provider.TradingStatusTrading
+	}
 }
 
 // Products is the response from the coinbase products endpoint.
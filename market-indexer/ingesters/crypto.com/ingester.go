@@ -70,12 +70,17 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 
 	pms := make([]provider.CreateProviderMarket, 0, len(instruments.Result.Data))
 	for _, result := range instruments.Result.Data {
-		if result.InstType != InstrumentTypeCCYPair || !result.Tradable {
+		if result.InstType != InstrumentTypeCCYPair {
 			continue
 		}
 
 		ticker, found := tickerMap[result.Symbol]
 		if !found {
+			// instruments that are not tradable may not have ticker data
+			if !result.Tradable {
+				ig.logger.Debug("skipping instrument without ticker data", zap.String("symbol", result.Symbol))
+				continue
+			}
 			return nil, fmt.Errorf("ticker not found for symbol %s", result.Symbol)
 		}
 
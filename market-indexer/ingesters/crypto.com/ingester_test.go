@@ -10,6 +10,7 @@ import (
 
 	crypto_com "github.com/skip-mev/connect-mmu/market-indexer/ingesters/crypto.com"
 	crypto_com_mocks "github.com/skip-mev/connect-mmu/market-indexer/ingesters/crypto.com/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if coinbase ingester's products endpoint returns an error, the
@@ -95,6 +96,7 @@ func TestIngesterIgnoresPerpMarkets(t *testing.T) {
 	require.Equal(t, "USD", markets[0].Create.TargetQuote)
 	require.Equal(t, float64(3000000), markets[0].Create.QuoteVolume)
 	require.Equal(t, 12.2352356, markets[0].Create.ReferencePrice)
+	require.Equal(t, provider.TradingStatusTrading, markets[0].Create.TradingStatus)
 }
 
 func TestIngesterErrorsOnVolumesError(t *testing.T) {
@@ -62,6 +62,14 @@ type InstrumentsData struct {
 	Tradable    bool   `json:"tradable"`
 }
 
+// tradingStatus returns the trading status of the instrument.
+func (d *InstrumentsData) tradingStatus() provider.TradingStatus {
+	if d.Tradable {
+		return provider.TradingStatusTrading
+	}
+	return provider.TradingStatusHalted
+}
+
 func (d *InstrumentsData) toProviderMarket(ticker TickerData) (provider.CreateProviderMarket, error) {
 	baseVol, err := strconv.ParseFloat(ticker.V, 64)
 	if err != nil {
@@ -103,6 +111,7 @@ func (d *InstrumentsData) toProviderMarket(ticker TickerData) (provider.CreatePr
 			ProviderName:   ProviderName,
 			QuoteVolume:    quoteVol,
 			ReferencePrice: refPrice,
+			TradingStatus:  d.tradingStatus(),
 		},
 	}
 
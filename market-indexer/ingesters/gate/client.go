@@ -8,7 +8,9 @@ import (
 )
 
 const (
-	EndpointTickers = "https://api.gateio.ws/api/v4/spot/tickers"
+	EndpointTickers       = "https://api.gateio.ws/api/v4/spot/tickers"
+	EndpointCurrencyPairs = "https://api.gateio.ws/api/v4/spot/currency_pairs"
+	EndpointCurrencies    = "https://api.gateio.ws/api/v4/spot/currencies"
 )
 
 var _ Client = &httpClient{}
@@ -21,6 +23,12 @@ type Client interface {
 	// Tickers returns all tickers on the
 	// Gate.io.
 	Tickers(context.Context) ([]TickerData, error)
+
+	// CurrencyPairs returns all currency pairs on Gate.io with their trade status.
+	CurrencyPairs(context.Context) ([]CurrencyPairData, error)
+
+	// Currencies returns all currencies on Gate.io with their deposit, withdrawal and trading status.
+	Currencies(context.Context) ([]CurrencyData, error)
 }
 
 type httpClient struct {
@@ -49,3 +57,33 @@ func (h *httpClient) Tickers(ctx context.Context) ([]TickerData, error) {
 
 	return tickers, nil
 }
+
+func (h *httpClient) CurrencyPairs(ctx context.Context) ([]CurrencyPairData, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointCurrencyPairs)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pairs []CurrencyPairData
+	if err := json.NewDecoder(resp.Body).Decode(&pairs); err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+func (h *httpClient) Currencies(ctx context.Context) ([]CurrencyData, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointCurrencies)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var currencies []CurrencyData
+	if err := json.NewDecoder(resp.Body).Decode(&currencies); err != nil {
+		return nil, err
+	}
+
+	return currencies, nil
+}
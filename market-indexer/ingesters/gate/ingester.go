@@ -54,6 +54,11 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 		return nil, err
 	}
 
+	statuses, err := i.tradingStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	pms := make([]provider.CreateProviderMarket, 0, len(tickers))
 	for _, ticker := range tickers {
 		i.logger.Debug("parsing", zap.Any("ticker", ticker))
@@ -63,7 +68,12 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 			continue
 		}
 
-		pm, err := ticker.toProviderMarket()
+		status, found := statuses[ticker.CurrencyPair]
+		if !found {
+			status = provider.TradingStatusUnknown
+		}
+
+		pm, err := ticker.toProviderMarket(status)
 		if err != nil {
 			i.logger.Error("failed to convert ticker to providerMarket", zap.Error(err))
 			continue
@@ -75,6 +85,31 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 	return pms, nil
 }
 
+// tradingStatuses returns the normalized trading status of every currency pair, keyed by its ID.
+func (i *Ingester) tradingStatuses(ctx context.Context) (map[string]provider.TradingStatus, error) {
+	pairs, err := i.client.CurrencyPairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currencyList, err := i.client.Currencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := make(map[string]CurrencyData, len(currencyList))
+	for _, currency := range currencyList {
+		currencies[currency.Currency] = currency
+	}
+
+	statuses := make(map[string]provider.TradingStatus, len(pairs))
+	for _, pair := range pairs {
+		statuses[pair.ID] = tradingStatus(pair, currencies)
+	}
+
+	return statuses, nil
+}
+
 // Name returns the Ingester's human-readable name.
 func (i *Ingester) Name() string {
 	return Name
@@ -10,6 +10,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/gate"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/gate/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if gate.io ingester's products endpoint returns an error, the
@@ -50,6 +51,11 @@ func TestIngesterTickers(t *testing.T) {
 			LastPrice:    "0.0235",
 		},
 	}, nil)
+	client.On("CurrencyPairs", ctx).Return([]gate.CurrencyPairData{
+		{ID: "BTC_USDT", Base: "BTC", Quote: "USDT", TradeStatus: gate.TradeStatusTradable},
+		{ID: "BTC_ETH", Base: "BTC", Quote: "ETH", TradeStatus: gate.TradeStatusTradable},
+	}, nil)
+	client.On("Currencies", ctx).Return([]gate.CurrencyData{}, nil)
 
 	markets, err := ingester.GetProviderMarkets(ctx)
 	if err != nil {
@@ -67,3 +73,46 @@ func TestIngesterTickers(t *testing.T) {
 	require.Equal(t, float64(60000000), markets[1].Create.QuoteVolume)
 	require.Equal(t, 103.235, markets[1].Create.ReferencePrice)
 }
+
+// Test that the ingester records the trading status of the currency pairs and their currencies.
+func TestIngesterTradingStatus(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := gate.NewWithClient(zap.NewNop(), client)
+
+	ctx := context.Background()
+	client.On("Tickers", ctx).Return([]gate.TickerData{
+		{CurrencyPair: "BTC_USDT", QuoteVolume: "1", LastPrice: "1"},
+		{CurrencyPair: "ETH_USDT", QuoteVolume: "1", LastPrice: "1"},
+		{CurrencyPair: "SOL_USDT", QuoteVolume: "1", LastPrice: "1"},
+		{CurrencyPair: "DOGE_USDT", QuoteVolume: "1", LastPrice: "1"},
+		{CurrencyPair: "ATOM_USDT", QuoteVolume: "1", LastPrice: "1"},
+		{CurrencyPair: "PEPE_USDT", QuoteVolume: "1", LastPrice: "1"},
+	}, nil)
+	client.On("CurrencyPairs", ctx).Return([]gate.CurrencyPairData{
+		{ID: "BTC_USDT", Base: "BTC", Quote: "USDT", TradeStatus: gate.TradeStatusTradable},
+		{ID: "ETH_USDT", Base: "ETH", Quote: "USDT", TradeStatus: "sellable"},
+		{ID: "SOL_USDT", Base: "SOL", Quote: "USDT", TradeStatus: gate.TradeStatusTradable, DelistingTime: 1700000000},
+		{ID: "DOGE_USDT", Base: "DOGE", Quote: "USDT", TradeStatus: gate.TradeStatusTradable},
+		{ID: "ATOM_USDT", Base: "ATOM", Quote: "USDT", TradeStatus: gate.TradeStatusTradable},
+	}, nil)
+	client.On("Currencies", ctx).Return([]gate.CurrencyData{
+		{Currency: "DOGE", WithdrawDisabled: true},
+		{Currency: "ATOM", TradeDisabled: true},
+	}, nil)
+
+	markets, err := ingester.GetProviderMarkets(ctx)
+	require.NoError(t, err)
+	require.Len(t, markets, 6)
+
+	expected := []provider.TradingStatus{
+		provider.TradingStatusTrading,
+		provider.TradingStatusHalted,
+		provider.TradingStatusDelistingScheduled,
+		provider.TradingStatusDepositWithdrawSuspended,
+		provider.TradingStatusHalted,
+		provider.TradingStatusUnknown,
+	}
+	for i, status := range expected {
+		require.Equal(t, status, markets[i].Create.TradingStatus, markets[i].Create.OffChainTicker)
+	}
+}
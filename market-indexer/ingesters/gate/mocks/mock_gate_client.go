@@ -5,9 +5,8 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
-
 	gate "github.com/skip-mev/connect-mmu/market-indexer/ingesters/gate"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -23,6 +22,122 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// Currencies provides a mock function with given fields: _a0
+func (_m *Client) Currencies(_a0 context.Context) ([]gate.CurrencyData, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Currencies")
+	}
+
+	var r0 []gate.CurrencyData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]gate.CurrencyData, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []gate.CurrencyData); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gate.CurrencyData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Currencies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Currencies'
+type Client_Currencies_Call struct {
+	*mock.Call
+}
+
+// Currencies is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *Client_Expecter) Currencies(_a0 interface{}) *Client_Currencies_Call {
+	return &Client_Currencies_Call{Call: _e.mock.On("Currencies", _a0)}
+}
+
+func (_c *Client_Currencies_Call) Run(run func(_a0 context.Context)) *Client_Currencies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_Currencies_Call) Return(_a0 []gate.CurrencyData, _a1 error) *Client_Currencies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Currencies_Call) RunAndReturn(run func(context.Context) ([]gate.CurrencyData, error)) *Client_Currencies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CurrencyPairs provides a mock function with given fields: _a0
+func (_m *Client) CurrencyPairs(_a0 context.Context) ([]gate.CurrencyPairData, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CurrencyPairs")
+	}
+
+	var r0 []gate.CurrencyPairData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]gate.CurrencyPairData, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []gate.CurrencyPairData); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gate.CurrencyPairData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_CurrencyPairs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CurrencyPairs'
+type Client_CurrencyPairs_Call struct {
+	*mock.Call
+}
+
+// CurrencyPairs is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *Client_Expecter) CurrencyPairs(_a0 interface{}) *Client_CurrencyPairs_Call {
+	return &Client_CurrencyPairs_Call{Call: _e.mock.On("CurrencyPairs", _a0)}
+}
+
+func (_c *Client_CurrencyPairs_Call) Run(run func(_a0 context.Context)) *Client_CurrencyPairs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_CurrencyPairs_Call) Return(_a0 []gate.CurrencyPairData, _a1 error) *Client_CurrencyPairs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_CurrencyPairs_Call) RunAndReturn(run func(context.Context) ([]gate.CurrencyPairData, error)) *Client_CurrencyPairs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Tickers provides a mock function with given fields: _a0
 func (_m *Client) Tickers(_a0 context.Context) ([]gate.TickerData, error) {
 	ret := _m.Called(_a0)
@@ -9,7 +9,12 @@ import (
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
-const delimiter = "_"
+const (
+	delimiter = "_"
+
+	// TradeStatusTradable is the trade status of a currency pair that can be bought and sold.
+	TradeStatusTradable = "tradable"
+)
 
 // TickerData is the data payload returned from the Gate.io
 // API in response to the Tickers request.
@@ -53,7 +58,83 @@ func (td *TickerData) isSpot() bool {
 	return td.EtfNetValue == "" && td.EtfPreNetValue == "" && td.EtfPreTimestamp == 0 && td.EtfLeverage == ""
 }
 
-func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
+// CurrencyPairData is the data payload returned from the Gate.io API in response to the CurrencyPairs request.
+//
+// Docs: https://www.gate.io/docs/developers/apiv4/#list-all-currency-pairs-supported
+//
+// Ex.
+// [
+//
+//	{
+//	  "id": "ETH_USDT",
+//	  "base": "ETH",
+//	  "quote": "USDT",
+//	  "fee": "0.2",
+//	  "min_base_amount": "0.001",
+//	  "min_quote_amount": "1.0",
+//	  "amount_precision": 3,
+//	  "precision": 6,
+//	  "trade_status": "tradable",
+//	  "sell_start": 1516378650,
+//	  "buy_start": 1516378650,
+//	  "delisting_time": 0
+//	}
+//
+// ].
+type CurrencyPairData struct {
+	ID    string `json:"id"`
+	Base  string `json:"base"`
+	Quote string `json:"quote"`
+	// TradeStatus is one of untradable, buyable, sellable or tradable.
+	TradeStatus string `json:"trade_status"`
+	// DelistingTime is the unix time at which the pair is delisted, or 0 if no delisting is scheduled.
+	DelistingTime int64 `json:"delisting_time"`
+}
+
+// CurrencyData is the data payload returned from the Gate.io API in response to the Currencies request.
+//
+// Docs: https://www.gate.io/docs/developers/apiv4/#list-all-currencies-details
+//
+// Ex.
+// [
+//
+//	{
+//	  "currency": "GT",
+//	  "delisted": false,
+//	  "withdraw_disabled": false,
+//	  "withdraw_delayed": false,
+//	  "deposit_disabled": false,
+//	  "trade_disabled": false
+//	}
+//
+// ].
+type CurrencyData struct {
+	Currency         string `json:"currency"`
+	Delisted         bool   `json:"delisted"`
+	WithdrawDisabled bool   `json:"withdraw_disabled"`
+	DepositDisabled  bool   `json:"deposit_disabled"`
+	TradeDisabled    bool   `json:"trade_disabled"`
+}
+
+// tradingStatus normalizes the trade status of a currency pair and the status of its currencies. Pairs that can
+// only be bought, only be sold, or not be traded at all are considered halted. Currencies that are not found are
+// assumed to have no restrictions.
+func tradingStatus(pair CurrencyPairData, currencies map[string]CurrencyData) provider.TradingStatus {
+	base, quote := currencies[pair.Base], currencies[pair.Quote]
+
+	switch {
+	case pair.TradeStatus != TradeStatusTradable || base.TradeDisabled || quote.TradeDisabled:
+		return provider.TradingStatusHalted
+	case pair.DelistingTime > 0 || base.Delisted || quote.Delisted:
+		return provider.TradingStatusDelistingScheduled
+	case base.DepositDisabled || base.WithdrawDisabled || quote.DepositDisabled || quote.WithdrawDisabled:
+		return provider.TradingStatusDepositWithdrawSuspended
+	default:
+		return provider.TradingStatusTrading
+	}
+}
+
+func (td *TickerData) toProviderMarket(status provider.TradingStatus) (provider.CreateProviderMarket, error) {
 	base, quote, err := symbolToBaseQuote(td.CurrencyPair)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -86,6 +167,7 @@ func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error)
 			ProviderName:   ProviderName,
 			QuoteVolume:    quoteVol,
 			ReferencePrice: refPrice,
+			TradingStatus:  status,
 		},
 	}
 
@@ -152,6 +152,7 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 					QuoteVolume:    quoteVolF64,
 					MetadataJSON:   metaDataBz,
 					ReferencePrice: refPrice,
+					TradingStatus:  provider.TradingStatusTrading,
 				},
 				BaseAddress:  pool.BaseAddress(),
 				QuoteAddress: pool.QuoteAddress(),
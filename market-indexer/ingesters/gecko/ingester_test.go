@@ -110,6 +110,7 @@ func TestGetProviderMarkets(t *testing.T) {
 				QuoteVolume:    281462633.1550315,
 				MetadataJSON:   metaData1Bz,
 				ReferencePrice: 3409.83,
+				TradingStatus:  provider.TradingStatusTrading,
 			},
 			BaseAddress:  pools.Data[0].BaseAddress(),
 			QuoteAddress: pools.Data[0].QuoteAddress(),
@@ -123,6 +124,7 @@ func TestGetProviderMarkets(t *testing.T) {
 				QuoteVolume:    3639.743321519964,
 				MetadataJSON:   metaData2Bz,
 				ReferencePrice: 0.000000001585379138,
+				TradingStatus:  provider.TradingStatusTrading,
 			},
 			BaseAddress:  pools.Data[1].BaseAddress(),
 			QuoteAddress: pools.Data[1].QuoteAddress(),
@@ -7,7 +7,11 @@ import (
 	"github.com/skip-mev/connect-mmu/lib/http"
 )
 
-const EndpointTickers = "https://api.huobi.pro/market/tickers"
+const (
+	EndpointTickers    = "https://api.huobi.pro/market/tickers"
+	EndpointSymbols    = "https://api.huobi.pro/v1/common/symbols"
+	EndpointCurrencies = "https://api.huobi.pro/v2/reference/currencies"
+)
 
 var _ Client = &httpClient{}
 
@@ -18,6 +22,12 @@ var _ Client = &httpClient{}
 type Client interface {
 	// Tickers gets all tickers from Huobi.
 	Tickers(ctx context.Context) (TickersResponse, error)
+
+	// Symbols gets all symbols from Huobi with their trading state.
+	Symbols(ctx context.Context) (SymbolsResponse, error)
+
+	// Currencies gets all currencies from Huobi with their deposit and withdrawal status.
+	Currencies(ctx context.Context) (CurrenciesResponse, error)
 }
 
 type httpClient struct {
@@ -51,3 +61,43 @@ func (h *httpClient) Tickers(ctx context.Context) (TickersResponse, error) {
 
 	return tickerResp, nil
 }
+
+// Symbols returns all symbols on the Huobi API using an HTTP client.
+func (h *httpClient) Symbols(ctx context.Context) (SymbolsResponse, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointSymbols)
+	if err != nil {
+		return SymbolsResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var symbolsResp SymbolsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&symbolsResp); err != nil {
+		return SymbolsResponse{}, err
+	}
+
+	if err := symbolsResp.Validate(); err != nil {
+		return SymbolsResponse{}, err
+	}
+
+	return symbolsResp, nil
+}
+
+// Currencies returns all currencies on the Huobi API using an HTTP client.
+func (h *httpClient) Currencies(ctx context.Context) (CurrenciesResponse, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointCurrencies)
+	if err != nil {
+		return CurrenciesResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var currenciesResp CurrenciesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&currenciesResp); err != nil {
+		return CurrenciesResponse{}, err
+	}
+
+	if err := currenciesResp.Validate(); err != nil {
+		return CurrenciesResponse{}, err
+	}
+
+	return currenciesResp, nil
+}
@@ -54,6 +54,11 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 		return nil, err
 	}
 
+	statuses, err := ig.tradingStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	pms := make([]provider.CreateProviderMarket, 0, len(tickers.Data))
 	for _, ticker := range tickers.Data {
 		ig.logger.Debug("ticker", zap.Any("data", ticker))
@@ -63,7 +68,12 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 			continue
 		}
 
-		pm, err := ticker.toProviderMarket()
+		status, found := statuses[ticker.Symbol]
+		if !found {
+			status = provider.TradingStatusUnknown
+		}
+
+		pm, err := ticker.toProviderMarket(status)
 		if err != nil {
 			ig.logger.Error("failed to convert ticker", zap.Error(err))
 			continue
@@ -75,6 +85,31 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 	return pms, nil
 }
 
+// tradingStatuses returns the normalized trading status of every symbol.
+func (ig *Ingester) tradingStatuses(ctx context.Context) (map[string]provider.TradingStatus, error) {
+	symbolsResp, err := ig.client.Symbols(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currenciesResp, err := ig.client.Currencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := make(map[string]CurrencyData, len(currenciesResp.Data))
+	for _, currency := range currenciesResp.Data {
+		currencies[currency.Currency] = currency
+	}
+
+	statuses := make(map[string]provider.TradingStatus, len(symbolsResp.Data))
+	for _, symbol := range symbolsResp.Data {
+		statuses[symbol.Symbol] = tradingStatus(symbol, currencies)
+	}
+
+	return statuses, nil
+}
+
 // Name returns the Ingester's human-readable name.
 func (ig *Ingester) Name() string {
 	return Name
@@ -10,6 +10,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/huobi"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/huobi/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if huobi ingester's products endpoint returns an error, the
@@ -50,6 +51,14 @@ func TestIngesterTickers(t *testing.T) {
 			},
 		},
 	}, nil)
+	client.On("Symbols", ctx).Return(huobi.SymbolsResponse{
+		Status: huobi.StatusOK,
+		Data: []huobi.SymbolData{
+			{Symbol: "btcusdt", BaseCurrency: "btc", QuoteCurrency: "usdt", State: huobi.StateOnline},
+			{Symbol: "btceth", BaseCurrency: "btc", QuoteCurrency: "eth", State: huobi.StateOnline},
+		},
+	}, nil)
+	client.On("Currencies", ctx).Return(huobi.CurrenciesResponse{Code: huobi.CodeOK}, nil)
 
 	markets, err := ingester.GetProviderMarkets(ctx)
 	if err != nil {
@@ -64,3 +73,66 @@ func TestIngesterTickers(t *testing.T) {
 	require.Equal(t, "ETH", markets[1].Create.TargetQuote)
 	require.Equal(t, float64(60000000), markets[1].Create.QuoteVolume)
 }
+
+// Test that the ingester records the trading status of the symbols and their currencies.
+func TestIngesterTradingStatus(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := huobi.NewWithClient(zap.NewNop(), client)
+
+	ctx := context.Background()
+	client.On("Tickers", ctx).Return(huobi.TickersResponse{
+		Status: huobi.StatusOK,
+		Data: []huobi.TickerData{
+			{Symbol: "btcusdt", Vol: 1},
+			{Symbol: "ethusdt", Vol: 1},
+			{Symbol: "solusdt", Vol: 1},
+			{Symbol: "dogeusdt", Vol: 1},
+			{Symbol: "atomusdt", Vol: 1},
+		},
+	}, nil)
+	client.On("Symbols", ctx).Return(huobi.SymbolsResponse{
+		Status: huobi.StatusOK,
+		Data: []huobi.SymbolData{
+			{Symbol: "btcusdt", BaseCurrency: "btc", QuoteCurrency: "usdt", State: huobi.StateOnline},
+			{Symbol: "ethusdt", BaseCurrency: "eth", QuoteCurrency: "usdt", State: "suspend"},
+			{Symbol: "solusdt", BaseCurrency: "sol", QuoteCurrency: "usdt", State: huobi.StateOnline},
+			{Symbol: "dogeusdt", BaseCurrency: "doge", QuoteCurrency: "usdt", State: huobi.StateOnline},
+		},
+	}, nil)
+	client.On("Currencies", ctx).Return(huobi.CurrenciesResponse{
+		Code: huobi.CodeOK,
+		Data: []huobi.CurrencyData{
+			{
+				Currency:   "usdt",
+				InstStatus: "normal",
+				Chains: []huobi.ChainData{
+					{Chain: "trc20usdt", DepositStatus: "prohibited", WithdrawStatus: huobi.ChainStatusAllowed},
+					{Chain: "usdterc20", DepositStatus: huobi.ChainStatusAllowed, WithdrawStatus: huobi.ChainStatusAllowed},
+				},
+			},
+			{Currency: "sol", InstStatus: huobi.InstStatusDelisted},
+			{
+				Currency:   "doge",
+				InstStatus: "normal",
+				Chains: []huobi.ChainData{
+					{Chain: "doge", DepositStatus: huobi.ChainStatusAllowed, WithdrawStatus: "prohibited"},
+				},
+			},
+		},
+	}, nil)
+
+	markets, err := ingester.GetProviderMarkets(ctx)
+	require.NoError(t, err)
+	require.Len(t, markets, 5)
+
+	expected := []provider.TradingStatus{
+		provider.TradingStatusTrading,
+		provider.TradingStatusHalted,
+		provider.TradingStatusDelistingScheduled,
+		provider.TradingStatusDepositWithdrawSuspended,
+		provider.TradingStatusUnknown,
+	}
+	for i, status := range expected {
+		require.Equal(t, status, markets[i].Create.TradingStatus, markets[i].Create.OffChainTicker)
+	}
+}
@@ -5,9 +5,8 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
-
 	huobi "github.com/skip-mev/connect-mmu/market-indexer/ingesters/huobi"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -23,6 +22,118 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// Currencies provides a mock function with given fields: ctx
+func (_m *Client) Currencies(ctx context.Context) (huobi.CurrenciesResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Currencies")
+	}
+
+	var r0 huobi.CurrenciesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (huobi.CurrenciesResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) huobi.CurrenciesResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(huobi.CurrenciesResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Currencies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Currencies'
+type Client_Currencies_Call struct {
+	*mock.Call
+}
+
+// Currencies is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Client_Expecter) Currencies(ctx interface{}) *Client_Currencies_Call {
+	return &Client_Currencies_Call{Call: _e.mock.On("Currencies", ctx)}
+}
+
+func (_c *Client_Currencies_Call) Run(run func(ctx context.Context)) *Client_Currencies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_Currencies_Call) Return(_a0 huobi.CurrenciesResponse, _a1 error) *Client_Currencies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Currencies_Call) RunAndReturn(run func(context.Context) (huobi.CurrenciesResponse, error)) *Client_Currencies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Symbols provides a mock function with given fields: ctx
+func (_m *Client) Symbols(ctx context.Context) (huobi.SymbolsResponse, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Symbols")
+	}
+
+	var r0 huobi.SymbolsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (huobi.SymbolsResponse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) huobi.SymbolsResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(huobi.SymbolsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Symbols_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Symbols'
+type Client_Symbols_Call struct {
+	*mock.Call
+}
+
+// Symbols is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Client_Expecter) Symbols(ctx interface{}) *Client_Symbols_Call {
+	return &Client_Symbols_Call{Call: _e.mock.On("Symbols", ctx)}
+}
+
+func (_c *Client_Symbols_Call) Run(run func(ctx context.Context)) *Client_Symbols_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_Symbols_Call) Return(_a0 huobi.SymbolsResponse, _a1 error) *Client_Symbols_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Symbols_Call) RunAndReturn(run func(context.Context) (huobi.SymbolsResponse, error)) *Client_Symbols_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Tickers provides a mock function with given fields: ctx
 func (_m *Client) Tickers(ctx context.Context) (huobi.TickersResponse, error) {
 	ret := _m.Called(ctx)
@@ -8,7 +8,18 @@ import (
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
-const StatusOK = "ok"
+const (
+	StatusOK = "ok"
+	// CodeOK is the code of a successful response of the v2 API.
+	CodeOK = 200
+
+	// StateOnline is the state of a symbol that is trading.
+	StateOnline = "online"
+	// InstStatusDelisted is the status of a currency that is being delisted.
+	InstStatusDelisted = "delisted"
+	// ChainStatusAllowed is the deposit or withdrawal status of a chain that allows deposits or withdrawals.
+	ChainStatusAllowed = "allowed"
+)
 
 // TickersResponse is the API response from Huobi for the
 // Tickers request.
@@ -76,7 +87,136 @@ type TickerData struct {
 	Open float64 `json:"open"`
 }
 
-func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
+// SymbolsResponse is the API response from Huobi for the Symbols request.
+type SymbolsResponse struct {
+	Status string       `json:"status"`
+	Data   []SymbolData `json:"data"`
+}
+
+// Validate checks if the SymbolsResponse got an ok status.
+func (sr *SymbolsResponse) Validate() error {
+	if sr.Status != StatusOK {
+		return fmt.Errorf("status is not ok %s", sr.Status)
+	}
+
+	return nil
+}
+
+// SymbolData is the data payload returned from the Huobi API from a Symbols request.
+//
+// Docs: https://huobiapi.github.io/docs/spot/v1/en/#get-all-supported-trading-symbol
+//
+// Ex.
+//
+//	{
+//	   "status":"ok",
+//	   "data":[
+//	       {
+//	           "base-currency":"btc",
+//	           "quote-currency":"usdt",
+//	           "price-precision":2,
+//	           "amount-precision":6,
+//	           "symbol-partition":"main",
+//	           "symbol":"btcusdt",
+//	           "state":"online",
+//	           "api-trading":"enabled"
+//	       }
+//	   ]
+//	}
+type SymbolData struct {
+	Symbol        string `json:"symbol"`
+	BaseCurrency  string `json:"base-currency"`
+	QuoteCurrency string `json:"quote-currency"`
+	// State is one of online, offline, suspend or pre-online.
+	State string `json:"state"`
+}
+
+// CurrenciesResponse is the API response from Huobi for the Currencies request.
+type CurrenciesResponse struct {
+	Code int            `json:"code"`
+	Data []CurrencyData `json:"data"`
+}
+
+// Validate checks if the CurrenciesResponse got an ok code.
+func (cr *CurrenciesResponse) Validate() error {
+	if cr.Code != CodeOK {
+		return fmt.Errorf("code is not ok %d", cr.Code)
+	}
+
+	return nil
+}
+
+// CurrencyData is the data payload returned from the Huobi API from a Currencies request.
+//
+// Docs: https://huobiapi.github.io/docs/spot/v1/en/#apiv2-currency-amp-chains
+//
+// Ex.
+//
+//	{
+//	   "code":200,
+//	   "data":[
+//	       {
+//	           "currency":"usdt",
+//	           "chains":[
+//	               {
+//	                   "chain":"trc20usdt",
+//	                   "depositStatus":"allowed",
+//	                   "withdrawStatus":"allowed"
+//	               }
+//	           ],
+//	           "instStatus":"normal"
+//	       }
+//	   ]
+//	}
+type CurrencyData struct {
+	Currency string      `json:"currency"`
+	Chains   []ChainData `json:"chains"`
+	// InstStatus is normal or delisted.
+	InstStatus string `json:"instStatus"`
+}
+
+// ChainData is the deposit and withdrawal status of a currency on a single chain.
+type ChainData struct {
+	Chain          string `json:"chain"`
+	DepositStatus  string `json:"depositStatus"`
+	WithdrawStatus string `json:"withdrawStatus"`
+}
+
+// suspended returns true if the currency cannot be deposited or cannot be withdrawn on any chain. Currencies
+// without chains are not considered suspended.
+func (cd CurrencyData) suspended() bool {
+	if len(cd.Chains) == 0 {
+		return false
+	}
+
+	deposit, withdraw := false, false
+	for _, chain := range cd.Chains {
+		deposit = deposit || chain.DepositStatus == ChainStatusAllowed
+		withdraw = withdraw || chain.WithdrawStatus == ChainStatusAllowed
+	}
+
+	return !deposit || !withdraw
+}
+
+// tradingStatus normalizes the state of a symbol and the status of its currencies. All states other than online
+// (ex. offline, suspend, pre-online) are considered halted. Currencies that are not found are assumed to have no
+// restrictions.
+func tradingStatus(symbol SymbolData, currencies map[string]CurrencyData) provider.TradingStatus {
+	base, quote := currencies[symbol.BaseCurrency], currencies[symbol.QuoteCurrency]
+
+	switch {
+	case symbol.State != StateOnline:
+		return provider.TradingStatusHalted
+	case base.InstStatus == InstStatusDelisted || quote.InstStatus == InstStatusDelisted:
+		return provider.TradingStatusDelistingScheduled
+	case base.suspended() || quote.suspended():
+		return provider.TradingStatusDepositWithdrawSuspended
+	default:
+		return provider.TradingStatusTrading
+	}
+}
+
+func (td *TickerData) toProviderMarket(status provider.TradingStatus) (provider.CreateProviderMarket, error) {
 	base, quote, err := symbolToBaseQuote(td.Symbol)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -99,6 +239,7 @@ func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error)
 			ProviderName:   ProviderName,
 			QuoteVolume:    td.Vol,
 			ReferencePrice: td.Open,
+			TradingStatus:  status,
 		},
 	}
 
@@ -64,12 +64,14 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 
 	pms := make([]provider.CreateProviderMarket, 0, len(assets.Result))
 	for offChainTicker, result := range assets.Result {
-		if result.Status != StatusOnline {
-			continue
-		}
-
 		data, found := tickers.Result[offChainTicker]
 		if !found {
+			// pairs that are not trading may not have ticker data
+			if tradingStatus(result.Status) != provider.TradingStatusTrading {
+				ig.logger.Debug("skipping pair without ticker data", zap.String("offchain ticker", offChainTicker),
+					zap.String("status", result.Status))
+				continue
+			}
 			return nil, fmt.Errorf("ticker %s not found", offChainTicker)
 		}
 
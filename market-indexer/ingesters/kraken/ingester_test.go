@@ -10,6 +10,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kraken"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kraken/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if kraken ingester's asset pairs endpoint returns an error, the
@@ -25,7 +26,8 @@ func TestIngesterReturnsErrorOnAssetPairsError(t *testing.T) {
 	require.Error(t, err)
 }
 
-// Test that the ingester only returns markets that are enabled.
+// Test that the ingester records the trading status of each market, and skips markets that are not trading
+// without ticker data.
 func TestIngesterGetsValidMarkets(t *testing.T) {
 	client := mocks.NewClient(t)
 	ingester := kraken.NewWithClient(zap.NewNop(), client)
@@ -46,7 +48,13 @@ func TestIngesterGetsValidMarkets(t *testing.T) {
 				Wsname: "LTC/USD",
 				Base:   "LTC",
 				Quote:  "USD",
-				Status: "",
+				Status: kraken.StatusPostOnly,
+			},
+			"XETHZUSD": {
+				Wsname: "ETH/USD",
+				Base:   "ETH",
+				Quote:  "USD",
+				Status: kraken.StatusCancelOnly,
 			},
 		},
 	}, nil)
@@ -75,11 +83,21 @@ func TestIngesterGetsValidMarkets(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	require.Len(t, markets, 1)
-	require.Equal(t, "BTC", markets[0].Create.TargetBase)
-	require.Equal(t, "USD", markets[0].Create.TargetQuote)
-	require.Equal(t, 8.484416466093452e+07, markets[0].Create.QuoteVolume)
-	require.Equal(t, 10.23, markets[0].Create.ReferencePrice)
+	// the cancel-only market without ticker data is skipped
+	require.Len(t, markets, 2)
+	byTicker := make(map[string]provider.CreateProviderMarketParams)
+	for _, market := range markets {
+		byTicker[market.Create.OffChainTicker] = market.Create
+	}
+
+	btc := byTicker["XXBTZUSD"]
+	require.Equal(t, "BTC", btc.TargetBase)
+	require.Equal(t, "USD", btc.TargetQuote)
+	require.Equal(t, 8.484416466093452e+07, btc.QuoteVolume)
+	require.Equal(t, 10.23, btc.ReferencePrice)
+	require.Equal(t, provider.TradingStatusTrading, btc.TradingStatus)
+
+	require.Equal(t, provider.TradingStatusPostOnly, byTicker["XLTCZUSD"].TradingStatus)
 }
 
 func TestIngesterErrorsOnTickersError(t *testing.T) {
@@ -9,9 +9,26 @@ import (
 )
 
 const (
-	StatusOnline = "online"
+	StatusOnline     = "online"
+	StatusPostOnly   = "post_only"
+	StatusLimitOnly  = "limit_only"
+	StatusCancelOnly = "cancel_only"
+	StatusReduceOnly = "reduce_only"
 )
 
+// tradingStatus normalizes the status of an asset pair. Limit-only pairs still trade, all statuses other than
+// online, limit-only and post-only are considered halted.
+func tradingStatus(status string) provider.TradingStatus {
+	switch status {
+	case StatusOnline, StatusLimitOnly:
+		return provider.TradingStatusTrading
+	case StatusPostOnly:
+		return provider.TradingStatusPostOnly
+	default:
+		return provider.TradingStatusHalted
+	}
+}
+
 func (d *AssetData) toProviderMarket(offChainTicker string, data TickerData) (provider.CreateProviderMarket, error) {
 	quoteVol, err := data.volumeInQuote()
 	if err != nil {
@@ -40,6 +57,7 @@ func (d *AssetData) toProviderMarket(offChainTicker string, data TickerData) (pr
 			ProviderName:   ProviderName,
 			QuoteVolume:    quoteVol,
 			ReferencePrice: refPrice,
+			TradingStatus:  tradingStatus(d.Status),
 		},
 	}
 
@@ -8,7 +8,9 @@ import (
 )
 
 const (
-	EndpointTickers = "https://api.kucoin.com/api/v1/market/allTickers"
+	EndpointTickers    = "https://api.kucoin.com/api/v1/market/allTickers"
+	EndpointSymbols    = "https://api.kucoin.com/api/v2/symbols"
+	EndpointCurrencies = "https://api.kucoin.com/api/v3/currencies"
 )
 
 var _ Client = &httpClient{}
@@ -20,6 +22,12 @@ var _ Client = &httpClient{}
 type Client interface {
 	// Tickers returns all tickers on KuCoin.
 	Tickers(context.Context) (TickersResponse, error)
+
+	// Symbols returns all symbols on KuCoin with whether trading is enabled.
+	Symbols(context.Context) (SymbolsResponse, error)
+
+	// Currencies returns all currencies on KuCoin with their deposit and withdrawal status per chain.
+	Currencies(context.Context) (CurrenciesResponse, error)
 }
 
 type httpClient struct {
@@ -48,3 +56,33 @@ func (h *httpClient) Tickers(ctx context.Context) (TickersResponse, error) {
 
 	return tickersResp, nil
 }
+
+func (h *httpClient) Symbols(ctx context.Context) (SymbolsResponse, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointSymbols)
+	if err != nil {
+		return SymbolsResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var symbolsResp SymbolsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&symbolsResp); err != nil {
+		return SymbolsResponse{}, err
+	}
+
+	return symbolsResp, nil
+}
+
+func (h *httpClient) Currencies(ctx context.Context) (CurrenciesResponse, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointCurrencies)
+	if err != nil {
+		return CurrenciesResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var currenciesResp CurrenciesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&currenciesResp); err != nil {
+		return CurrenciesResponse{}, err
+	}
+
+	return currenciesResp, nil
+}
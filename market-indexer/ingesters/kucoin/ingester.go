@@ -58,11 +58,21 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 
 	i.logger.Debug("fetched data", zap.Int("num tickers", len(tickersResp.Data.Tickers)))
 
+	statuses, err := i.tradingStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	pms := make([]provider.CreateProviderMarket, 0, len(tickersResp.Data.Tickers))
 	for _, ticker := range tickersResp.Data.Tickers {
 		i.logger.Debug("parsing", zap.Any("ticker", ticker))
 
-		pm, err := ticker.toProviderMarket()
+		status, found := statuses[ticker.Symbol]
+		if !found {
+			status = provider.TradingStatusUnknown
+		}
+
+		pm, err := ticker.toProviderMarket(status)
 		if err != nil {
 			i.logger.Error("failed to convert ticker to providerMarket", zap.Error(err))
 			continue
@@ -74,6 +84,31 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 	return pms, nil
 }
 
+// tradingStatuses returns the normalized trading status of every symbol.
+func (i *Ingester) tradingStatuses(ctx context.Context) (map[string]provider.TradingStatus, error) {
+	symbolsResp, err := i.client.Symbols(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currenciesResp, err := i.client.Currencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := make(map[string]CurrencyData, len(currenciesResp.Data))
+	for _, currency := range currenciesResp.Data {
+		currencies[currency.Currency] = currency
+	}
+
+	statuses := make(map[string]provider.TradingStatus, len(symbolsResp.Data))
+	for _, symbol := range symbolsResp.Data {
+		statuses[symbol.Symbol] = tradingStatus(symbol, currencies)
+	}
+
+	return statuses, nil
+}
+
 // Name returns the Ingester's human-readable name.
 func (i *Ingester) Name() string {
 	return Name
@@ -10,6 +10,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kucoin"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/kucoin/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if kucoin ingester's products endpoint returns an error, the
@@ -49,6 +50,13 @@ func TestIngesterTickers(t *testing.T) {
 			},
 		},
 	}, nil)
+	client.On("Symbols", ctx).Return(kucoin.SymbolsResponse{
+		Data: []kucoin.SymbolData{
+			{Symbol: "BTC-USDT", BaseCurrency: "BTC", QuoteCurrency: "USDT", EnableTrading: true},
+			{Symbol: "ETH-USDT", BaseCurrency: "ETH", QuoteCurrency: "USDT", EnableTrading: true},
+		},
+	}, nil)
+	client.On("Currencies", ctx).Return(kucoin.CurrenciesResponse{}, nil)
 
 	markets, err := ingester.GetProviderMarkets(ctx)
 	if err != nil {
@@ -66,3 +74,59 @@ func TestIngesterTickers(t *testing.T) {
 	require.Equal(t, float64(60000000), markets[1].Create.QuoteVolume)
 	require.Equal(t, 2309.2390, markets[1].Create.ReferencePrice)
 }
+
+// Test that the ingester records the trading status of the symbols and their currencies.
+func TestIngesterTradingStatus(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := kucoin.NewWithClient(zap.NewNop(), client)
+
+	ctx := context.Background()
+	client.On("Tickers", ctx).Return(kucoin.TickersResponse{
+		Data: kucoin.TickerData{
+			Tickers: []kucoin.Ticker{
+				{Symbol: "BTC-USDT", SymbolName: "BTC-USDT", VolValue: "1", AveragePrice: "1"},
+				{Symbol: "ETH-USDT", SymbolName: "ETH-USDT", VolValue: "1", AveragePrice: "1"},
+				{Symbol: "DOGE-USDT", SymbolName: "DOGE-USDT", VolValue: "1", AveragePrice: "1"},
+				{Symbol: "ATOM-USDT", SymbolName: "ATOM-USDT", VolValue: "1", AveragePrice: "1"},
+			},
+		},
+	}, nil)
+	client.On("Symbols", ctx).Return(kucoin.SymbolsResponse{
+		Data: []kucoin.SymbolData{
+			{Symbol: "BTC-USDT", BaseCurrency: "BTC", QuoteCurrency: "USDT", EnableTrading: true},
+			{Symbol: "ETH-USDT", BaseCurrency: "ETH", QuoteCurrency: "USDT", EnableTrading: false},
+			{Symbol: "DOGE-USDT", BaseCurrency: "DOGE", QuoteCurrency: "USDT", EnableTrading: true},
+		},
+	}, nil)
+	client.On("Currencies", ctx).Return(kucoin.CurrenciesResponse{
+		Data: []kucoin.CurrencyData{
+			{
+				Currency: "USDT",
+				Chains: []kucoin.ChainData{
+					{ChainName: "TRC20", IsDepositEnabled: false, IsWithdrawEnabled: true},
+					{ChainName: "ERC20", IsDepositEnabled: true, IsWithdrawEnabled: true},
+				},
+			},
+			{
+				Currency: "DOGE",
+				Chains: []kucoin.ChainData{
+					{ChainName: "DOGE", IsDepositEnabled: false, IsWithdrawEnabled: true},
+				},
+			},
+		},
+	}, nil)
+
+	markets, err := ingester.GetProviderMarkets(ctx)
+	require.NoError(t, err)
+	require.Len(t, markets, 4)
+
+	expected := []provider.TradingStatus{
+		provider.TradingStatusTrading,
+		provider.TradingStatusHalted,
+		provider.TradingStatusDepositWithdrawSuspended,
+		provider.TradingStatusUnknown,
+	}
+	for i, status := range expected {
+		require.Equal(t, status, markets[i].Create.TradingStatus, markets[i].Create.OffChainTicker)
+	}
+}
@@ -5,9 +5,8 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
-
 	kucoin "github.com/skip-mev/connect-mmu/market-indexer/ingesters/kucoin"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -23,6 +22,118 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// Currencies provides a mock function with given fields: _a0
+func (_m *Client) Currencies(_a0 context.Context) (kucoin.CurrenciesResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Currencies")
+	}
+
+	var r0 kucoin.CurrenciesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (kucoin.CurrenciesResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) kucoin.CurrenciesResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(kucoin.CurrenciesResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Currencies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Currencies'
+type Client_Currencies_Call struct {
+	*mock.Call
+}
+
+// Currencies is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *Client_Expecter) Currencies(_a0 interface{}) *Client_Currencies_Call {
+	return &Client_Currencies_Call{Call: _e.mock.On("Currencies", _a0)}
+}
+
+func (_c *Client_Currencies_Call) Run(run func(_a0 context.Context)) *Client_Currencies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_Currencies_Call) Return(_a0 kucoin.CurrenciesResponse, _a1 error) *Client_Currencies_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Currencies_Call) RunAndReturn(run func(context.Context) (kucoin.CurrenciesResponse, error)) *Client_Currencies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Symbols provides a mock function with given fields: _a0
+func (_m *Client) Symbols(_a0 context.Context) (kucoin.SymbolsResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Symbols")
+	}
+
+	var r0 kucoin.SymbolsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (kucoin.SymbolsResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) kucoin.SymbolsResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(kucoin.SymbolsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Symbols_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Symbols'
+type Client_Symbols_Call struct {
+	*mock.Call
+}
+
+// Symbols is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *Client_Expecter) Symbols(_a0 interface{}) *Client_Symbols_Call {
+	return &Client_Symbols_Call{Call: _e.mock.On("Symbols", _a0)}
+}
+
+func (_c *Client_Symbols_Call) Run(run func(_a0 context.Context)) *Client_Symbols_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_Symbols_Call) Return(_a0 kucoin.SymbolsResponse, _a1 error) *Client_Symbols_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Symbols_Call) RunAndReturn(run func(context.Context) (kucoin.SymbolsResponse, error)) *Client_Symbols_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Tickers provides a mock function with given fields: _a0
 func (_m *Client) Tickers(_a0 context.Context) (kucoin.TickersResponse, error) {
 	ret := _m.Called(_a0)
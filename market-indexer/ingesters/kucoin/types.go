@@ -61,7 +61,99 @@ type Ticker struct {
 	AveragePrice string `json:"averagePrice"`
 }
 
-func (td *Ticker) toProviderMarket() (provider.CreateProviderMarket, error) {
+// SymbolsResponse is the response returned from the KuCoin API to a Symbols request.
+type SymbolsResponse struct {
+	Code string       `json:"code"`
+	Data []SymbolData `json:"data"`
+}
+
+// SymbolData is the data payload included in a SymbolsResponse.
+//
+// Docs: https://www.kucoin.com/docs/rest/spot-trading/market-data/get-symbols-list
+//
+// Ex.
+//
+//	{
+//	  "symbol": "BTC-USDT",
+//	  "name": "BTC-USDT",
+//	  "baseCurrency": "BTC",
+//	  "quoteCurrency": "USDT",
+//	  "market": "USDS",
+//	  "enableTrading": true
+//	}
+type SymbolData struct {
+	Symbol        string `json:"symbol"`
+	BaseCurrency  string `json:"baseCurrency"`
+	QuoteCurrency string `json:"quoteCurrency"`
+	EnableTrading bool   `json:"enableTrading"`
+}
+
+// CurrenciesResponse is the response returned from the KuCoin API to a Currencies request.
+type CurrenciesResponse struct {
+	Code string         `json:"code"`
+	Data []CurrencyData `json:"data"`
+}
+
+// CurrencyData is the data payload included in a CurrenciesResponse.
+//
+// Docs: https://www.kucoin.com/docs/rest/spot-trading/market-data/get-currency-list
+//
+// Ex.
+//
+//	{
+//	  "currency": "BTC",
+//	  "name": "BTC",
+//	  "fullName": "Bitcoin",
+//	  "chains": [
+//	    {
+//	      "chainName": "BTC",
+//	      "isWithdrawEnabled": true,
+//	      "isDepositEnabled": true
+//	    }
+//	  ]
+//	}
+type CurrencyData struct {
+	Currency string      `json:"currency"`
+	Chains   []ChainData `json:"chains"`
+}
+
+// ChainData is the deposit and withdrawal status of a currency on a single chain.
+type ChainData struct {
+	ChainName         string `json:"chainName"`
+	IsWithdrawEnabled bool   `json:"isWithdrawEnabled"`
+	IsDepositEnabled  bool   `json:"isDepositEnabled"`
+}
+
+// suspended returns true if the currency cannot be deposited or cannot be withdrawn on any chain. Currencies
+// without chains are not considered suspended.
+func (cd CurrencyData) suspended() bool {
+	if len(cd.Chains) == 0 {
+		return false
+	}
+
+	deposit, withdraw := false, false
+	for _, chain := range cd.Chains {
+		deposit = deposit || chain.IsDepositEnabled
+		withdraw = withdraw || chain.IsWithdrawEnabled
+	}
+
+	return !deposit || !withdraw
+}
+
+// tradingStatus normalizes whether trading of a symbol is enabled and the status of its currencies. KuCoin does not
+// publish scheduled delistings. Currencies that are not found are assumed to have no restrictions.
+func tradingStatus(symbol SymbolData, currencies map[string]CurrencyData) provider.TradingStatus {
+	switch {
+	case !symbol.EnableTrading:
+		return provider.TradingStatusHalted
+	case currencies[symbol.BaseCurrency].suspended() || currencies[symbol.QuoteCurrency].suspended():
+		return provider.TradingStatusDepositWithdrawSuspended
+	default:
+		return provider.TradingStatusTrading
+	}
+}
+
+func (td *Ticker) toProviderMarket(status provider.TradingStatus) (provider.CreateProviderMarket, error) {
 	quoteVol, err := strconv.ParseFloat(td.VolValue, 64)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -98,6 +190,7 @@ func (td *Ticker) toProviderMarket() (provider.CreateProviderMarket, error) {
 			ProviderName:   ProviderName,
 			QuoteVolume:    quoteVol,
 			ReferencePrice: refPrice,
+			TradingStatus:  status,
 		},
 	}
 
@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	EndpointTickers = "https://api.mexc.com/api/v3/ticker/24hr"
+	EndpointTickers      = "https://api.mexc.com/api/v3/ticker/24hr"
+	EndpointExchangeInfo = "https://api.mexc.com/api/v3/exchangeInfo"
 )
 
 var _ Client = &httpClient{}
@@ -21,6 +22,9 @@ type Client interface {
 	// Tickers returns all tickers on the
 	// Mexc.
 	Tickers(context.Context) ([]TickerData, error)
+
+	// ExchangeInfo returns all symbols on Mexc with their trading status.
+	ExchangeInfo(context.Context) (ExchangeInfoResponse, error)
 }
 
 type httpClient struct {
@@ -49,3 +53,18 @@ func (h *httpClient) Tickers(ctx context.Context) ([]TickerData, error) {
 
 	return tickersResp, nil
 }
+
+func (h *httpClient) ExchangeInfo(ctx context.Context) (ExchangeInfoResponse, error) {
+	resp, err := h.client.GetWithContext(ctx, EndpointExchangeInfo)
+	if err != nil {
+		return ExchangeInfoResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	var exchangeInfoResp ExchangeInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&exchangeInfoResp); err != nil {
+		return ExchangeInfoResponse{}, err
+	}
+
+	return exchangeInfoResp, nil
+}
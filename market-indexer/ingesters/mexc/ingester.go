@@ -17,7 +17,7 @@ const (
 
 var _ ingesters.Ingester = &Ingester{}
 
-// Ingester is the kraken implementation of a market data Ingester.
+// Ingester is the mexc implementation of a market data Ingester.
 type Ingester struct {
 	logger *zap.Logger
 
@@ -59,10 +59,26 @@ func (i *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePro
 
 	i.logger.Info("fetched data", zap.Int("count", len(tickers)))
 
+	exchangeInfo, err := i.client.ExchangeInfo(ctx)
+	if err != nil {
+		i.logger.Error("failed to fetch exchange info", zap.Error(err))
+		return nil, err
+	}
+
+	statuses := make(map[string]provider.TradingStatus, len(exchangeInfo.Symbols))
+	for _, symbol := range exchangeInfo.Symbols {
+		statuses[symbol.Symbol] = symbol.tradingStatus()
+	}
+
 	pms := make([]provider.CreateProviderMarket, 0, len(tickers))
 	for _, ticker := range tickers {
 		i.logger.Debug("parsing", zap.Any("ticker", ticker))
-		pm, err := ticker.toProviderMarket()
+		status, found := statuses[ticker.Symbol]
+		if !found {
+			status = provider.TradingStatusUnknown
+		}
+
+		pm, err := ticker.toProviderMarket(status)
 		if err != nil {
 			i.logger.Error("failed to parse ticker", zap.Error(err))
 			continue
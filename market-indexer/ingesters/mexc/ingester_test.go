@@ -10,6 +10,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/mexc"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/mexc/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if gate.io ingester's products endpoint returns an error, the
@@ -43,6 +44,12 @@ func TestIngesterTickers(t *testing.T) {
 			OpenPrice:   "0.023",
 		},
 	}, nil)
+	client.On("ExchangeInfo", ctx).Return(mexc.ExchangeInfoResponse{
+		Symbols: []mexc.SymbolData{
+			{Symbol: "BTCUSDT", Status: mexc.StatusOnline, IsSpotTradingAllowed: true},
+			{Symbol: "BTCETH", Status: mexc.StatusOnline, IsSpotTradingAllowed: true},
+		},
+	}, nil)
 
 	markets, err := ingester.GetProviderMarkets(ctx)
 	if err != nil {
@@ -60,3 +67,41 @@ func TestIngesterTickers(t *testing.T) {
 	require.Equal(t, float64(60000000), markets[1].Create.QuoteVolume)
 	require.Equal(t, 0.023, markets[1].Create.ReferencePrice)
 }
+
+// Test that the ingester records the trading status of the symbols.
+func TestIngesterTradingStatus(t *testing.T) {
+	client := mocks.NewClient(t)
+	ingester := mexc.NewWithClient(zap.NewNop(), client)
+
+	ctx := context.Background()
+	client.On("Tickers", ctx).Return([]mexc.TickerData{
+		{Symbol: "BTCUSDT", QuoteVolume: "1", OpenPrice: "1"},
+		{Symbol: "ETHUSDT", QuoteVolume: "1", OpenPrice: "1"},
+		{Symbol: "SOLUSDT", QuoteVolume: "1", OpenPrice: "1"},
+		{Symbol: "DOGEUSDT", QuoteVolume: "1", OpenPrice: "1"},
+		{Symbol: "ATOMUSDT", QuoteVolume: "1", OpenPrice: "1"},
+	}, nil)
+	client.On("ExchangeInfo", ctx).Return(mexc.ExchangeInfoResponse{
+		Symbols: []mexc.SymbolData{
+			{Symbol: "BTCUSDT", Status: mexc.StatusOnline, IsSpotTradingAllowed: true},
+			{Symbol: "ETHUSDT", Status: "2", IsSpotTradingAllowed: true},
+			{Symbol: "SOLUSDT", Status: mexc.StatusEnabled, IsSpotTradingAllowed: true},
+			{Symbol: "DOGEUSDT", Status: mexc.StatusOnline, IsSpotTradingAllowed: false},
+		},
+	}, nil)
+
+	markets, err := ingester.GetProviderMarkets(ctx)
+	require.NoError(t, err)
+	require.Len(t, markets, 5)
+
+	expected := []provider.TradingStatus{
+		provider.TradingStatusTrading,
+		provider.TradingStatusHalted,
+		provider.TradingStatusTrading,
+		provider.TradingStatusHalted,
+		provider.TradingStatusUnknown,
+	}
+	for i, status := range expected {
+		require.Equal(t, status, markets[i].Create.TradingStatus, markets[i].Create.OffChainTicker)
+	}
+}
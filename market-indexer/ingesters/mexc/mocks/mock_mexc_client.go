@@ -5,9 +5,8 @@ package mocks
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
-
 	mexc "github.com/skip-mev/connect-mmu/market-indexer/ingesters/mexc"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -23,6 +22,62 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// ExchangeInfo provides a mock function with given fields: _a0
+func (_m *Client) ExchangeInfo(_a0 context.Context) (mexc.ExchangeInfoResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExchangeInfo")
+	}
+
+	var r0 mexc.ExchangeInfoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (mexc.ExchangeInfoResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) mexc.ExchangeInfoResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(mexc.ExchangeInfoResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_ExchangeInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExchangeInfo'
+type Client_ExchangeInfo_Call struct {
+	*mock.Call
+}
+
+// ExchangeInfo is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *Client_Expecter) ExchangeInfo(_a0 interface{}) *Client_ExchangeInfo_Call {
+	return &Client_ExchangeInfo_Call{Call: _e.mock.On("ExchangeInfo", _a0)}
+}
+
+func (_c *Client_ExchangeInfo_Call) Run(run func(_a0 context.Context)) *Client_ExchangeInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_ExchangeInfo_Call) Return(_a0 mexc.ExchangeInfoResponse, _a1 error) *Client_ExchangeInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_ExchangeInfo_Call) RunAndReturn(run func(context.Context) (mexc.ExchangeInfoResponse, error)) *Client_ExchangeInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Tickers provides a mock function with given fields: _a0
 func (_m *Client) Tickers(_a0 context.Context) ([]mexc.TickerData, error) {
 	ret := _m.Called(_a0)
@@ -46,7 +46,50 @@ type TickerData struct {
 	OpenPrice   string `json:"openPrice"`
 }
 
-func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error) {
+const (
+	// StatusOnline is the status of a symbol that is trading.
+	StatusOnline = "1"
+	// StatusEnabled is the legacy status of a symbol that is trading.
+	StatusEnabled = "ENABLED"
+)
+
+// ExchangeInfoResponse is the response returned from the mexc API on an ExchangeInfo request.
+type ExchangeInfoResponse struct {
+	Symbols []SymbolData `json:"symbols"`
+}
+
+// SymbolData is the data payload of a single symbol in an ExchangeInfoResponse.
+//
+// Docs: https://mexcdevelop.github.io/apidocs/spot_v3_en/#exchange-information
+//
+// Ex.
+//
+//	{
+//	  "symbol": "BTCUSDT",
+//	  "status": "1",
+//	  "baseAsset": "BTC",
+//	  "quoteAsset": "USDT",
+//	  "isSpotTradingAllowed": true,
+//	  "isMarginTradingAllowed": false
+//	}
+type SymbolData struct {
+	Symbol string `json:"symbol"`
+	// Status is 1 (online), 2 (pause) or 3 (offline).
+	Status               string `json:"status"`
+	IsSpotTradingAllowed bool   `json:"isSpotTradingAllowed"`
+}
+
+// tradingStatus normalizes the status of a symbol. Symbols that are paused, offline or do not allow spot trading are
+// considered halted. Mexc does not publish scheduled delistings or the deposit and withdrawal status of currencies
+// without authentication.
+func (sd SymbolData) tradingStatus() provider.TradingStatus {
+	if (sd.Status == StatusOnline || sd.Status == StatusEnabled) && sd.IsSpotTradingAllowed {
+		return provider.TradingStatusTrading
+	}
+	return provider.TradingStatusHalted
+}
+
+func (td *TickerData) toProviderMarket(status provider.TradingStatus) (provider.CreateProviderMarket, error) {
 	base, quote, err := symbolToBaseQuote(td.Symbol)
 	if err != nil {
 		return provider.CreateProviderMarket{}, err
@@ -79,6 +122,7 @@ func (td *TickerData) toProviderMarket() (provider.CreateProviderMarket, error)
 			ProviderName:   ProviderName,
 			QuoteVolume:    quoteVol,
 			ReferencePrice: refPrice,
+			TradingStatus:  status,
 		},
 	}
 
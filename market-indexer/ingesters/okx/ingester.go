@@ -69,12 +69,14 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 	pms := make([]provider.CreateProviderMarket, 0, len(instruments.Data))
 	tickerMap := tickers.toMap()
 	for _, data := range instruments.Data {
-		if data.State != StateLive {
-			continue
-		}
-
 		ticker, found := tickerMap[data.InstID]
 		if !found {
+			// instruments that are not trading may not have ticker data
+			if tradingStatus(data.State) != provider.TradingStatusTrading {
+				ig.logger.Debug("skipping instrument without ticker data", zap.String("instrument", data.InstID),
+					zap.String("state", data.State))
+				continue
+			}
 			return nil, fmt.Errorf("ticker %s not found in ticker map", data.InstID)
 		}
 
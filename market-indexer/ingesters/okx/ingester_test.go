@@ -10,6 +10,7 @@ import (
 
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/okx"
 	"github.com/skip-mev/connect-mmu/market-indexer/ingesters/okx/mocks"
+	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
 // Test that if okx ingester's products endpoint returns an error, the
@@ -58,7 +59,7 @@ func TestIngesterIgnoresPerpMarkets(t *testing.T) {
 				InstID:   "LTC-USD",
 				InstType: "SPOT",
 				QuoteCcy: "USD",
-				State:    "",
+				State:    okx.StateSuspend,
 			},
 		},
 	}, nil)
@@ -96,7 +97,7 @@ func TestIngesterIgnoresPerpMarkets(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	require.Len(t, markets, 2)
+	require.Len(t, markets, 3)
 	require.Equal(t, "BTC", markets[0].Create.TargetBase)
 	require.Equal(t, "USD", markets[0].Create.TargetQuote)
 	require.Equal(t, float64(3000000), markets[0].Create.QuoteVolume)
@@ -106,6 +107,11 @@ func TestIngesterIgnoresPerpMarkets(t *testing.T) {
 	require.Equal(t, "USD", markets[1].Create.TargetQuote)
 	require.Equal(t, float64(6000000), markets[1].Create.QuoteVolume)
 	require.Equal(t, 3200.32, markets[1].Create.ReferencePrice)
+	require.Equal(t, provider.TradingStatusTrading, markets[1].Create.TradingStatus)
+
+	// suspended instruments are recorded as halted
+	require.Equal(t, "LTC", markets[2].Create.TargetBase)
+	require.Equal(t, provider.TradingStatusHalted, markets[2].Create.TradingStatus)
 }
 
 func TestIngesterErrorsOnTickersError(t *testing.T) {
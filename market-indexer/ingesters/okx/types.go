@@ -8,7 +8,19 @@ import (
 	"github.com/skip-mev/connect-mmu/store/provider"
 )
 
-const StateLive = "live"
+const (
+	StateLive    = "live"
+	StateSuspend = "suspend"
+)
+
+// tradingStatus normalizes the state of an instrument. All states other than live (ex. suspend, preopen) are
+// considered halted.
+func tradingStatus(state string) provider.TradingStatus {
+	if state == StateLive {
+		return provider.TradingStatusTrading
+	}
+	return provider.TradingStatusHalted
+}
 
 // Response is a common shared field for all responses from the
 // okx API.
@@ -114,6 +126,7 @@ func (ir *InstrumentData) toProviderMarket(td TickerData) (provider.CreateProvid
 			ProviderName:   ProviderName,
 			QuoteVolume:    volume,
 			ReferencePrice: refPrice,
+			TradingStatus:  tradingStatus(ir.State),
 		},
 	}
 
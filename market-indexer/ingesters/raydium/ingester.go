@@ -158,6 +158,7 @@ func (ig *Ingester) GetProviderMarkets(ctx context.Context) ([]provider.CreatePr
 				ReferencePrice:   pair.Price,
 				PositiveDepthTwo: pair.Liquidity / 2,
 				NegativeDepthTwo: pair.Liquidity / 2,
+				TradingStatus:    provider.TradingStatusTrading,
 			},
 			BaseAddress:  pair.BaseMint,
 			QuoteAddress: pair.QuoteMint,
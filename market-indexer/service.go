@@ -42,6 +42,9 @@ type Indexer struct {
 
 	// knownAssets is a local cache of the known assets in the AssetsInfo table.
 	knownAssets utils.AssetMap
+
+	// assetAliasSuggestions are the asset aliases suggested from the indexed CoinMarketCap data.
+	assetAliasSuggestions []AssetAliasSuggestion
 }
 
 const coinMarketCapKey = "CMC_API_KEY"
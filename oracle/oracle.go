@@ -0,0 +1,121 @@
+package oracle
+
+import (
+	"sort"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"golang.org/x/exp/maps"
+
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+// Config is a partial Connect oracle config. Connect merges it with the defaults of each provider, so only the
+// fields derived from the provider templates are set.
+type Config struct {
+	Providers map[string]ProviderConfig `json:"providers"`
+}
+
+// ProviderConfig is the partial oracle config of a provider. Exactly one of API and WebSocket is set.
+type ProviderConfig struct {
+	API       *APIConfig       `json:"api,omitempty"`
+	WebSocket *WebSocketConfig `json:"webSocket,omitempty"`
+}
+
+// APIConfig is the partial config of an API provider.
+type APIConfig struct {
+	Interval  string     `json:"interval,omitempty"`
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+}
+
+// WebSocketConfig is the partial config of a websocket provider.
+type WebSocketConfig struct {
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+}
+
+// Endpoint is a provider endpoint.
+type Endpoint struct {
+	URL            string          `json:"url"`
+	Authentication *Authentication `json:"authentication,omitempty"`
+}
+
+// Authentication is the API key authentication of an endpoint.
+type Authentication struct {
+	APIKeyHeader string `json:"apiKeyHeader"`
+	APIKey       string `json:"apiKey"`
+}
+
+// ProvidersInUse returns the sorted names of all providers of the markets in the market map.
+func ProvidersInUse(mm mmtypes.MarketMap) []string {
+	providers := make(map[string]struct{})
+	for _, market := range mm.Markets {
+		for _, pc := range market.ProviderConfigs {
+			providers[pc.Name] = struct{}{}
+		}
+	}
+
+	names := maps.Keys(providers)
+	sort.Strings(names)
+	return names
+}
+
+// Result is a derived oracle config along with the details that need the attention of the user.
+type Result struct {
+	// Config is the derived oracle config.
+	Config Config
+	// Untemplated are the providers in use without a template. They use the Connect defaults.
+	Untemplated []string
+	// MissingAPIKeys are the environment variables of API keys that are not set.
+	MissingAPIKeys []string
+}
+
+// FromMarketMap derives the oracle config of exactly the providers used by the market map from the provider
+// templates. API keys are read from the environment variables configured in the templates using lookupEnv.
+func FromMarketMap(
+	mm mmtypes.MarketMap,
+	cfg config.OracleConfig,
+	lookupEnv func(string) (string, bool),
+) Result {
+	res := Result{
+		Config:         Config{Providers: make(map[string]ProviderConfig)},
+		Untemplated:    make([]string, 0),
+		MissingAPIKeys: make([]string, 0),
+	}
+
+	missing := make(map[string]struct{})
+	for _, name := range ProvidersInUse(mm) {
+		template, found := cfg.Providers[name]
+		if !found {
+			res.Untemplated = append(res.Untemplated, name)
+			continue
+		}
+
+		endpoints := make([]Endpoint, 0, len(template.Endpoints))
+		for _, et := range template.Endpoints {
+			endpoint := Endpoint{URL: et.URL}
+			if et.APIKeyHeader != "" {
+				endpoint.Authentication = &Authentication{APIKeyHeader: et.APIKeyHeader}
+			}
+
+			if et.APIKeyEnv != "" {
+				key, ok := lookupEnv(et.APIKeyEnv)
+				if !ok || key == "" {
+					missing[et.APIKeyEnv] = struct{}{}
+				}
+				endpoint.Authentication.APIKey = key
+			}
+
+			endpoints = append(endpoints, endpoint)
+		}
+
+		if config.IsWebSocketProvider(name) {
+			res.Config.Providers[name] = ProviderConfig{WebSocket: &WebSocketConfig{Endpoints: endpoints}}
+		} else {
+			res.Config.Providers[name] = ProviderConfig{API: &APIConfig{Interval: template.Interval, Endpoints: endpoints}}
+		}
+	}
+
+	res.MissingAPIKeys = maps.Keys(missing)
+	sort.Strings(res.MissingAPIKeys)
+
+	return res
+}
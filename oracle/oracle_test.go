@@ -0,0 +1,77 @@
+package oracle_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/oracle"
+)
+
+func TestFromMarketMap(t *testing.T) {
+	mm := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+		"BTC/USD": {
+			Ticker: mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD")},
+			ProviderConfigs: []mmtypes.ProviderConfig{
+				{Name: "coinbase_ws", OffChainTicker: "BTC-USD"},
+				{Name: "binance_ws", OffChainTicker: "BTCUSDT"},
+			},
+		},
+		"ETH/USD": {
+			Ticker: mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("ETH", "USD")},
+			ProviderConfigs: []mmtypes.ProviderConfig{
+				{Name: "coinbase_ws", OffChainTicker: "ETH-USD"},
+				{Name: "uniswapv3_api-ethereum", OffChainTicker: "WETH,UNISWAP_V3,0X0/USDC,UNISWAP_V3,0X1"},
+			},
+		},
+	}}
+
+	cfg := config.OracleConfig{Providers: map[string]config.OracleProviderTemplate{
+		"coinbase_ws": {Endpoints: []config.OracleEndpointTemplate{{URL: "wss://ws-feed.exchange.coinbase.com"}}},
+		"uniswapv3_api-ethereum": {
+			Interval: "2s",
+			Endpoints: []config.OracleEndpointTemplate{
+				{URL: "https://eth.a.com", APIKeyHeader: "x-api-key", APIKeyEnv: "ETH_A_KEY"},
+				{URL: "https://eth.b.com", APIKeyHeader: "x-api-key", APIKeyEnv: "ETH_B_KEY"},
+			},
+		},
+		// not in use
+		"raydium_api": {Endpoints: []config.OracleEndpointTemplate{{URL: "https://solana.com"}}},
+	}}
+
+	env := map[string]string{"ETH_A_KEY": "secret"}
+	res := oracle.FromMarketMap(mm, cfg, func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	})
+
+	require.Equal(t, []string{"binance_ws"}, res.Untemplated)
+	require.Equal(t, []string{"ETH_B_KEY"}, res.MissingAPIKeys)
+	require.Len(t, res.Config.Providers, 2)
+
+	coinbase := res.Config.Providers["coinbase_ws"]
+	require.Nil(t, coinbase.API)
+	require.Equal(t, "wss://ws-feed.exchange.coinbase.com", coinbase.WebSocket.Endpoints[0].URL)
+	require.Nil(t, coinbase.WebSocket.Endpoints[0].Authentication)
+
+	uniswap := res.Config.Providers["uniswapv3_api-ethereum"]
+	require.Nil(t, uniswap.WebSocket)
+	require.Equal(t, "2s", uniswap.API.Interval)
+	require.Equal(t, &oracle.Authentication{APIKeyHeader: "x-api-key", APIKey: "secret"}, uniswap.API.Endpoints[0].Authentication)
+	require.Equal(t, &oracle.Authentication{APIKeyHeader: "x-api-key"}, uniswap.API.Endpoints[1].Authentication)
+
+	// the config is written in the format read by Connect
+	bz, err := json.Marshal(res.Config)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"providers": {
+		"coinbase_ws": {"webSocket": {"endpoints": [{"url": "wss://ws-feed.exchange.coinbase.com"}]}},
+		"uniswapv3_api-ethereum": {"api": {"interval": "2s", "endpoints": [
+			{"url": "https://eth.a.com", "authentication": {"apiKeyHeader": "x-api-key", "apiKey": "secret"}},
+			{"url": "https://eth.b.com", "authentication": {"apiKeyHeader": "x-api-key", "apiKey": ""}}
+		]}}
+	}}`, string(bz))
+}
@@ -0,0 +1,371 @@
+package override
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/skip-mev/connect-mmu/config"
+	libhttp "github.com/skip-mev/connect-mmu/lib/http"
+	"github.com/skip-mev/connect-mmu/override/update"
+)
+
+const (
+	// ProtectedMarketsTypeName is the chain type of the ProtectedMarketsOverride.
+	ProtectedMarketsTypeName = "protected_markets"
+
+	// ProtectedMarketsEndpointREST queries the protected tickers from a REST endpoint of the chain.
+	ProtectedMarketsEndpointREST = "rest"
+	// ProtectedMarketsEndpointGRPC queries the protected tickers from a gRPC method of the chain.
+	ProtectedMarketsEndpointGRPC = "grpc"
+)
+
+var (
+	_ Factory         = NewProtectedMarketsOverrideFromConfig
+	_ ConfigValidator = ValidateProtectedMarketsConfig
+)
+
+// ProtectedMarketsClient queries the tickers of the markets that are referenced by another module of the chain, and
+// must not be changed by an override.
+type ProtectedMarketsClient interface {
+	ProtectedMarkets(ctx context.Context) ([]string, error)
+}
+
+// ProtectedMarketsConfig is the config of a ProtectedMarketsOverride.
+type ProtectedMarketsConfig struct {
+	// Endpoint is the type of endpoint the protected tickers are queried from (rest or grpc).
+	Endpoint string `json:"endpoint"`
+
+	// Path is the path of the REST query, relative to the REST address of the chain, or the full name of the gRPC
+	// method (ex: /neutron.dex.Query/...). The query is sent without parameters.
+	Path string `json:"path"`
+
+	// TickersField is the dot-separated path of the list of tickers in the JSON response of a REST query. If empty,
+	// the response is the list of tickers.
+	TickersField string `json:"tickers_field,omitempty"`
+
+	// TickersFieldNumber is the number of the repeated string field of the tickers in the response of a gRPC query.
+	// Defaults to 1.
+	TickersFieldNumber int `json:"tickers_field_number,omitempty"`
+}
+
+func (c *ProtectedMarketsConfig) Validate() error {
+	switch c.Endpoint {
+	case ProtectedMarketsEndpointREST:
+		if c.TickersFieldNumber != 0 {
+			return fmt.Errorf("tickers_field_number is only valid for %s endpoints", ProtectedMarketsEndpointGRPC)
+		}
+	case ProtectedMarketsEndpointGRPC:
+		if c.TickersField != "" {
+			return fmt.Errorf("tickers_field is only valid for %s endpoints", ProtectedMarketsEndpointREST)
+		}
+		if c.TickersFieldNumber < 0 || c.TickersFieldNumber > int(protowire.MaxValidNumber) {
+			return fmt.Errorf("invalid tickers_field_number %d", c.TickersFieldNumber)
+		}
+	default:
+		return fmt.Errorf("endpoint must be one of (%s, %s)", ProtectedMarketsEndpointREST, ProtectedMarketsEndpointGRPC)
+	}
+
+	if c.Path == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+
+	return nil
+}
+
+// ValidateProtectedMarketsConfig checks that cfg is a valid ProtectedMarketsConfig.
+func ValidateProtectedMarketsConfig(cfg any) error {
+	_, err := decodeProtectedMarketsConfig(cfg)
+	return err
+}
+
+// decodeProtectedMarketsConfig decodes and validates the ProtectedMarketsConfig in cfg.
+func decodeProtectedMarketsConfig(cfg any) (ProtectedMarketsConfig, error) {
+	var overrideCfg ProtectedMarketsConfig
+	decoderCfg := mapstructure.DecoderConfig{
+		Result:  &overrideCfg,
+		TagName: "json",
+	}
+
+	decoder, err := mapstructure.NewDecoder(&decoderCfg)
+	if err != nil {
+		return ProtectedMarketsConfig{}, fmt.Errorf("error creating protected markets override config decoder %v: %w", cfg, err)
+	}
+	if err := decoder.Decode(cfg); err != nil {
+		return ProtectedMarketsConfig{}, fmt.Errorf("error decoding protected markets override config %v: %w", cfg, err)
+	}
+
+	if err := overrideCfg.Validate(); err != nil {
+		return ProtectedMarketsConfig{}, fmt.Errorf("invalid protected markets override config: %w", err)
+	}
+
+	return overrideCfg, nil
+}
+
+// ProtectedMarketsOverride is a MarketMapOverride for chains with modules that reference markets of the market map.
+// Markets referenced by another module are never changed.
+type ProtectedMarketsOverride struct {
+	base   MarketMapOverride
+	client ProtectedMarketsClient
+}
+
+var _ MarketMapOverride = (*ProtectedMarketsOverride)(nil)
+
+// NewProtectedMarketsOverride creates a ProtectedMarketsOverride that protects the markets returned by the client
+// from the market map overridden by base.
+func NewProtectedMarketsOverride(base MarketMapOverride, client ProtectedMarketsClient) (MarketMapOverride, error) {
+	if base == nil {
+		return nil, fmt.Errorf("base override cannot be nil")
+	}
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	return &ProtectedMarketsOverride{
+		base:   base,
+		client: client,
+	}, nil
+}
+
+// NewProtectedMarketsOverrideFromConfig creates a ProtectedMarketsOverride over the CoreOverride, querying the
+// protected tickers from the chain as described by the ProtectedMarketsConfig in cfg.
+func NewProtectedMarketsOverrideFromConfig(cfg any, chainCfg config.ChainConfig) (MarketMapOverride, error) {
+	overrideCfg, err := decodeProtectedMarketsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var client ProtectedMarketsClient
+	switch overrideCfg.Endpoint {
+	case ProtectedMarketsEndpointREST:
+		client = NewRESTProtectedMarketsClient(
+			strings.TrimSuffix(chainCfg.RESTAddress, "/")+"/"+strings.TrimPrefix(overrideCfg.Path, "/"),
+			overrideCfg.TickersField,
+		)
+	case ProtectedMarketsEndpointGRPC:
+		conn, err := grpc.NewClient(chainCfg.GRPCAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create chain gRPC client: %w", err)
+		}
+		client = NewGRPCProtectedMarketsClient(conn, overrideCfg.Path, protowire.Number(overrideCfg.TickersFieldNumber))
+	}
+
+	return NewProtectedMarketsOverride(NewCoreOverride(), client)
+}
+
+// OverrideGeneratedMarkets does the following:
+// - overrides the generated market map with the base override
+// - ensures that all protected markets on-chain are equal to the actual market map (no change).
+func (o *ProtectedMarketsOverride) OverrideGeneratedMarkets(
+	ctx context.Context,
+	logger *zap.Logger,
+	actual, generated mmtypes.MarketMap,
+	options update.Options,
+) (mmtypes.MarketMap, error) {
+	overridden, err := o.base.OverrideGeneratedMarkets(ctx, logger, actual, generated, options)
+	if err != nil {
+		return mmtypes.MarketMap{}, err
+	}
+
+	protected, err := o.client.ProtectedMarkets(ctx)
+	if err != nil {
+		return mmtypes.MarketMap{}, fmt.Errorf("failed to query protected markets: %w", err)
+	}
+
+	logger.Info("got protected markets", zap.Int("count", len(protected)))
+
+	for _, ticker := range protected {
+		cp, err := connecttypes.CurrencyPairFromString(ticker)
+		if err != nil {
+			return mmtypes.MarketMap{}, fmt.Errorf("invalid protected ticker %s: %w", ticker, err)
+		}
+
+		// markets that are not on-chain cannot be referenced yet, so they are left as generated
+		actualMarket, ok := actual.Markets[cp.String()]
+		if !ok {
+			logger.Debug("protected market not found in actual", zap.String("ticker", cp.String()))
+			continue
+		}
+
+		if overriddenMarket, ok := overridden.Markets[cp.String()]; ok && !overriddenMarket.Equal(actualMarket) {
+			logger.Info(
+				"reverting change to protected market",
+				zap.String("ticker", cp.String()),
+				zap.String("generated", overriddenMarket.String()),
+				zap.String("actual", actualMarket.String()),
+			)
+		}
+
+		overridden.Markets[cp.String()] = actualMarket
+	}
+
+	return overridden, nil
+}
+
+// RESTProtectedMarketsClient queries the protected tickers from a REST endpoint.
+type RESTProtectedMarketsClient struct {
+	url          string
+	tickersField string
+	client       *libhttp.Client
+}
+
+var _ ProtectedMarketsClient = (*RESTProtectedMarketsClient)(nil)
+
+// NewRESTProtectedMarketsClient creates a client that queries the list of tickers at the dot-separated tickersField
+// of the JSON response of url. If tickersField is empty, the response is the list of tickers.
+func NewRESTProtectedMarketsClient(url, tickersField string) *RESTProtectedMarketsClient {
+	return &RESTProtectedMarketsClient{
+		url:          url,
+		tickersField: tickersField,
+		client:       libhttp.NewClient(),
+	}
+}
+
+func (c *RESTProtectedMarketsClient) ProtectedMarkets(ctx context.Context) ([]string, error) {
+	resp, err := c.client.GetWithContext(ctx, c.url, libhttp.WithJSONAccept())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result any
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding response: %w", err)
+	}
+
+	if c.tickersField != "" {
+		for _, field := range strings.Split(c.tickersField, ".") {
+			object, ok := result.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("field %s of %s is not an object", field, c.tickersField)
+			}
+			if result, ok = object[field]; !ok {
+				return nil, fmt.Errorf("field %s of %s not found in response", field, c.tickersField)
+			}
+		}
+	}
+
+	list, ok := result.([]any)
+	if !ok {
+		return nil, fmt.Errorf("tickers of response are not a list")
+	}
+
+	tickers := make([]string, len(list))
+	for i, item := range list {
+		if tickers[i], ok = item.(string); !ok {
+			return nil, fmt.Errorf("ticker %v of response is not a string", item)
+		}
+	}
+
+	return tickers, nil
+}
+
+// GRPCProtectedMarketsClient queries the protected tickers from a gRPC method that takes no parameters and returns
+// the tickers in a repeated string field.
+type GRPCProtectedMarketsClient struct {
+	conn        grpc.ClientConnInterface
+	method      string
+	fieldNumber protowire.Number
+}
+
+var _ ProtectedMarketsClient = (*GRPCProtectedMarketsClient)(nil)
+
+// NewGRPCProtectedMarketsClient creates a client that invokes method on conn, and returns the repeated string field
+// fieldNumber of the response. fieldNumber defaults to 1.
+func NewGRPCProtectedMarketsClient(
+	conn grpc.ClientConnInterface,
+	method string,
+	fieldNumber protowire.Number,
+) *GRPCProtectedMarketsClient {
+	if fieldNumber == 0 {
+		fieldNumber = 1
+	}
+
+	return &GRPCProtectedMarketsClient{
+		conn:        conn,
+		method:      method,
+		fieldNumber: fieldNumber,
+	}
+}
+
+func (c *GRPCProtectedMarketsClient) ProtectedMarkets(ctx context.Context) ([]string, error) {
+	res := &protectedMarketsResponse{fieldNumber: c.fieldNumber}
+	if err := c.conn.Invoke(ctx, c.method, &protectedMarketsRequest{}, res); err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", c.method, err)
+	}
+
+	return res.Tickers, nil
+}
+
+// protectedMarketsRequest is the empty request of a protected markets gRPC query.
+type protectedMarketsRequest struct{}
+
+func (m *protectedMarketsRequest) Reset()                   { *m = protectedMarketsRequest{} }
+func (m *protectedMarketsRequest) String() string           { return "" }
+func (*protectedMarketsRequest) ProtoMessage()              {}
+func (m *protectedMarketsRequest) Marshal() ([]byte, error) { return nil, nil }
+func (m *protectedMarketsRequest) Unmarshal([]byte) error   { return nil }
+
+// protectedMarketsResponse decodes the tickers of a protected markets gRPC query from the repeated string field
+// fieldNumber, ignoring all other fields.
+type protectedMarketsResponse struct {
+	fieldNumber protowire.Number
+	Tickers     []string
+}
+
+func (m *protectedMarketsResponse) Reset() {
+	*m = protectedMarketsResponse{fieldNumber: m.fieldNumber}
+}
+func (m *protectedMarketsResponse) String() string { return strings.Join(m.Tickers, ",") }
+func (*protectedMarketsResponse) ProtoMessage()    {}
+
+func (m *protectedMarketsResponse) Marshal() ([]byte, error) {
+	var bz []byte
+	for _, ticker := range m.Tickers {
+		bz = protowire.AppendTag(bz, m.fieldNumber, protowire.BytesType)
+		bz = protowire.AppendString(bz, ticker)
+	}
+	return bz, nil
+}
+
+func (m *protectedMarketsResponse) Unmarshal(bz []byte) error {
+	m.Tickers = nil
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		bz = bz[n:]
+
+		if num != m.fieldNumber || typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, bz)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			bz = bz[n:]
+			continue
+		}
+
+		value, n := protowire.ConsumeString(bz)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		bz = bz[n:]
+		m.Tickers = append(m.Tickers, value)
+	}
+	return nil
+}
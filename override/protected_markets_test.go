@@ -0,0 +1,333 @@
+package override
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/override/update"
+)
+
+type protectedMarketsClient struct {
+	tickers []string
+	err     error
+}
+
+func (c protectedMarketsClient) ProtectedMarkets(context.Context) ([]string, error) {
+	return c.tickers, c.err
+}
+
+func testMarket(t *testing.T, ticker string, providers ...string) types.Market {
+	t.Helper()
+
+	market := types.Market{
+		Ticker: types.Ticker{
+			CurrencyPair:     makeCurrencyPair(t, ticker),
+			Decimals:         8,
+			MinProviderCount: 1,
+			Enabled:          true,
+		},
+	}
+	for _, provider := range providers {
+		market.ProviderConfigs = append(market.ProviderConfigs, types.ProviderConfig{
+			Name:           provider,
+			OffChainTicker: ticker,
+		})
+	}
+	return market
+}
+
+func TestProtectedMarketsOverride(t *testing.T) {
+	// markets that are not on-chain are added disabled
+	newMarket := testMarket(t, "SOL/USD", "binance")
+	newMarket.Ticker.Enabled = false
+
+	actual := types.MarketMap{Markets: map[string]types.Market{
+		"BTC/USD": testMarket(t, "BTC/USD", "coinbase"),
+		"ETH/USD": testMarket(t, "ETH/USD", "coinbase"),
+	}}
+	generated := types.MarketMap{Markets: map[string]types.Market{
+		"BTC/USD": testMarket(t, "BTC/USD", "coinbase", "binance"),
+		"ETH/USD": testMarket(t, "ETH/USD", "coinbase", "binance"),
+		"SOL/USD": testMarket(t, "SOL/USD", "binance"),
+	}}
+	options := update.Options{UpdateEnabled: true, OverwriteProviders: true, DisableDeFiMarketMerging: true}
+
+	tests := []struct {
+		name     string
+		client   protectedMarketsClient
+		expected types.MarketMap
+		wantErr  bool
+	}{
+		{
+			name:   "no protected markets",
+			client: protectedMarketsClient{},
+			expected: types.MarketMap{Markets: map[string]types.Market{
+				"BTC/USD": testMarket(t, "BTC/USD", "coinbase", "binance"),
+				"ETH/USD": testMarket(t, "ETH/USD", "coinbase", "binance"),
+				"SOL/USD": newMarket,
+			}},
+		},
+		{
+			name:   "protected markets on-chain are unchanged",
+			client: protectedMarketsClient{tickers: []string{"btc/usd"}},
+			expected: types.MarketMap{Markets: map[string]types.Market{
+				"BTC/USD": testMarket(t, "BTC/USD", "coinbase"),
+				"ETH/USD": testMarket(t, "ETH/USD", "coinbase", "binance"),
+				"SOL/USD": newMarket,
+			}},
+		},
+		{
+			name:   "protected markets not on-chain are generated",
+			client: protectedMarketsClient{tickers: []string{"SOL/USD", "ETH/USD"}},
+			expected: types.MarketMap{Markets: map[string]types.Market{
+				"BTC/USD": testMarket(t, "BTC/USD", "coinbase", "binance"),
+				"ETH/USD": testMarket(t, "ETH/USD", "coinbase"),
+				"SOL/USD": newMarket,
+			}},
+		},
+		{
+			name:    "invalid protected ticker",
+			client:  protectedMarketsClient{tickers: []string{"BTC"}},
+			wantErr: true,
+		},
+		{
+			name:    "client error",
+			client:  protectedMarketsClient{err: errors.New("unavailable")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mmo, err := NewProtectedMarketsOverride(NewCoreOverride(), tt.client)
+			require.NoError(t, err)
+
+			out, err := mmo.OverrideGeneratedMarkets(context.Background(), zaptest.NewLogger(t), actual, generated, options)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestNewProtectedMarketsOverride(t *testing.T) {
+	_, err := NewProtectedMarketsOverride(nil, protectedMarketsClient{})
+	require.Error(t, err)
+
+	_, err = NewProtectedMarketsOverride(NewCoreOverride(), nil)
+	require.Error(t, err)
+}
+
+func TestProtectedMarketsConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ProtectedMarketsConfig
+		wantErr bool
+	}{
+		{
+			name: "valid rest config",
+			cfg:  ProtectedMarketsConfig{Endpoint: ProtectedMarketsEndpointREST, Path: "/markets", TickersField: "markets"},
+		},
+		{
+			name: "valid grpc config",
+			cfg:  ProtectedMarketsConfig{Endpoint: ProtectedMarketsEndpointGRPC, Path: "/foo.Query/Markets", TickersFieldNumber: 2},
+		},
+		{
+			name:    "invalid endpoint",
+			cfg:     ProtectedMarketsConfig{Endpoint: "websocket", Path: "/markets"},
+			wantErr: true,
+		},
+		{
+			name:    "empty path",
+			cfg:     ProtectedMarketsConfig{Endpoint: ProtectedMarketsEndpointREST},
+			wantErr: true,
+		},
+		{
+			name:    "field number for rest",
+			cfg:     ProtectedMarketsConfig{Endpoint: ProtectedMarketsEndpointREST, Path: "/markets", TickersFieldNumber: 1},
+			wantErr: true,
+		},
+		{
+			name:    "field for grpc",
+			cfg:     ProtectedMarketsConfig{Endpoint: ProtectedMarketsEndpointGRPC, Path: "/foo.Query/Markets", TickersField: "markets"},
+			wantErr: true,
+		},
+		{
+			name:    "negative field number",
+			cfg:     ProtectedMarketsConfig{Endpoint: ProtectedMarketsEndpointGRPC, Path: "/foo.Query/Markets", TickersFieldNumber: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRESTProtectedMarketsClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/list":
+			fmt.Fprint(w, `["BTC/USD","ETH/USD"]`)
+		case "/nested":
+			fmt.Fprint(w, `{"result":{"tickers":["BTC/USD"]},"height":"1"}`)
+		case "/objects":
+			fmt.Fprint(w, `{"tickers":[{"ticker":"BTC/USD"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		field    string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "list response",
+			path:     "/list",
+			expected: []string{"BTC/USD", "ETH/USD"},
+		},
+		{
+			name:     "nested field",
+			path:     "/nested",
+			field:    "result.tickers",
+			expected: []string{"BTC/USD"},
+		},
+		{
+			name:    "missing field",
+			path:    "/nested",
+			field:   "result.markets",
+			wantErr: true,
+		},
+		{
+			name:    "field of a list",
+			path:    "/list",
+			field:   "tickers",
+			wantErr: true,
+		},
+		{
+			name:    "tickers are not strings",
+			path:    "/objects",
+			field:   "tickers",
+			wantErr: true,
+		},
+		{
+			name:    "unexpected status",
+			path:    "/missing",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewRESTProtectedMarketsClient(server.URL+tt.path, tt.field)
+
+			tickers, err := client.ProtectedMarkets(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, tickers)
+		})
+	}
+}
+
+// protectedMarketsConn is a grpc.ClientConnInterface that responds to method with the encoded response.
+type protectedMarketsConn struct {
+	grpc.ClientConnInterface
+	method   string
+	response []byte
+}
+
+func (c protectedMarketsConn) Invoke(_ context.Context, method string, _, reply any, _ ...grpc.CallOption) error {
+	if method != c.method {
+		return fmt.Errorf("unknown method %s", method)
+	}
+	return reply.(*protectedMarketsResponse).Unmarshal(c.response)
+}
+
+func TestGRPCProtectedMarketsClient(t *testing.T) {
+	// a response with the tickers in field 2, and other fields around them
+	var response []byte
+	response = protowire.AppendTag(response, 1, protowire.VarintType)
+	response = protowire.AppendVarint(response, 7)
+	response = protowire.AppendTag(response, 2, protowire.BytesType)
+	response = protowire.AppendString(response, "BTC/USD")
+	response = protowire.AppendTag(response, 3, protowire.BytesType)
+	response = protowire.AppendString(response, "ignored")
+	response = protowire.AppendTag(response, 2, protowire.BytesType)
+	response = protowire.AppendString(response, "ETH/USD")
+
+	conn := protectedMarketsConn{method: "/foo.Query/Markets", response: response}
+
+	tickers, err := NewGRPCProtectedMarketsClient(conn, "/foo.Query/Markets", 2).ProtectedMarkets(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"BTC/USD", "ETH/USD"}, tickers)
+
+	// field 1 is not a string field, so it holds no tickers
+	tickers, err = NewGRPCProtectedMarketsClient(conn, "/foo.Query/Markets", 0).ProtectedMarkets(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, tickers)
+
+	_, err = NewGRPCProtectedMarketsClient(conn, "/foo.Query/Other", 2).ProtectedMarkets(context.Background())
+	require.Error(t, err)
+
+	conn.response = []byte{0xff}
+	_, err = NewGRPCProtectedMarketsClient(conn, "/foo.Query/Markets", 2).ProtectedMarkets(context.Background())
+	require.Error(t, err)
+}
+
+func TestNewProtectedMarketsOverrideFromConfig(t *testing.T) {
+	chainCfg := config.DefaultChainConfig()
+
+	mmo, err := NewProtectedMarketsOverrideFromConfig(map[string]any{
+		"endpoint":      "rest",
+		"path":          "/neutron/markets",
+		"tickers_field": "markets",
+	}, chainCfg)
+	require.NoError(t, err)
+	require.IsType(t, &ProtectedMarketsOverride{}, mmo)
+
+	mmo, err = NewProtectedMarketsOverrideFromConfig(map[string]any{
+		"endpoint":             "grpc",
+		"path":                 "/neutron.Query/Markets",
+		"tickers_field_number": 2,
+	}, chainCfg)
+	require.NoError(t, err)
+	require.IsType(t, &ProtectedMarketsOverride{}, mmo)
+
+	_, err = NewProtectedMarketsOverrideFromConfig(map[string]any{"endpoint": "rest"}, chainCfg)
+	require.Error(t, err)
+
+	_, err = NewProtectedMarketsOverrideFromConfig(nil, chainCfg)
+	require.Error(t, err)
+
+	_, err = NewProtectedMarketsOverrideFromConfig("rest", chainCfg)
+	require.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package override
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/skip-mev/connect-mmu/client/dydx"
+	"github.com/skip-mev/connect-mmu/config"
+)
+
+// Factory creates the MarketMapOverride of a chain type from the override config and chain config of a chain.
+type Factory func(cfg any, chainCfg config.ChainConfig) (MarketMapOverride, error)
+
+// ConfigValidator checks the override config of a chain type without creating the MarketMapOverride.
+type ConfigValidator func(cfg any) error
+
+// Registry manages MarketMapOverride factories, keyed by chain type.
+type Registry struct {
+	mu         sync.RWMutex
+	overrides  map[string]Factory
+	validators map[string]ConfigValidator
+}
+
+var _ config.ChainTypes = (*Registry)(nil)
+
+// NewRegistry creates a new, empty Registry instance.
+func NewRegistry() *Registry {
+	return &Registry{
+		overrides:  make(map[string]Factory),
+		validators: make(map[string]ConfigValidator),
+	}
+}
+
+// NewDefaultRegistry creates a Registry with the overrides of this package registered under their chain types.
+func NewDefaultRegistry() *Registry {
+	return &Registry{
+		overrides: map[string]Factory{
+			config.ChainTypeCore:     newCoreOverrideFromConfig,
+			config.ChainTypeDYDX:     newDyDxOverrideFromConfig,
+			ProtectedMarketsTypeName: NewProtectedMarketsOverrideFromConfig,
+		},
+		validators: map[string]ConfigValidator{
+			config.ChainTypeCore:     validateNoConfig,
+			config.ChainTypeDYDX:     validateNoConfig,
+			ProtectedMarketsTypeName: ValidateProtectedMarketsConfig,
+		},
+	}
+}
+
+func (r *Registry) RegisterOverride(typ string, factory Factory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.overrides[typ]; exists {
+		return errors.New("override type already registered: " + typ)
+	}
+
+	r.overrides[typ] = factory
+	return nil
+}
+
+// RegisterConfigValidator registers the validator of the override config of a registered chain type. The override
+// config of types without a validator is only checked when the override is created.
+func (r *Registry) RegisterConfigValidator(typ string, validate ConfigValidator) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.overrides[typ]; !exists {
+		return errors.New("unknown override type: " + typ)
+	}
+	if _, exists := r.validators[typ]; exists {
+		return errors.New("override config validator already registered: " + typ)
+	}
+
+	r.validators[typ] = validate
+	return nil
+}
+
+// ValidateChainType checks that an override is registered for the type of the given chain, and that the override
+// config of the chain is valid for it.
+func (r *Registry) ValidateChainType(chainCfg config.ChainConfig) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	typ := chainCfg.ChainType()
+	if _, exists := r.overrides[typ]; !exists {
+		return errors.New("unknown override type: " + typ)
+	}
+
+	if validate, exists := r.validators[typ]; exists {
+		if err := validate(chainCfg.Override); err != nil {
+			return fmt.Errorf("invalid override config for type %s: %w", typ, err)
+		}
+	}
+
+	return nil
+}
+
+// CreateOverride creates the MarketMapOverride of the type of the given chain.
+func (r *Registry) CreateOverride(chainCfg config.ChainConfig) (MarketMapOverride, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	typ := chainCfg.ChainType()
+	factory, exists := r.overrides[typ]
+	if !exists {
+		return nil, errors.New("unknown override type: " + typ)
+	}
+
+	return factory(chainCfg.Override, chainCfg)
+}
+
+// validateNoConfig rejects override configs for types that do not take one.
+func validateNoConfig(cfg any) error {
+	if cfg != nil {
+		return errors.New("override config is not supported")
+	}
+	return nil
+}
+
+func newCoreOverrideFromConfig(_ any, _ config.ChainConfig) (MarketMapOverride, error) {
+	return NewCoreOverride(), nil
+}
+
+func newDyDxOverrideFromConfig(_ any, chainCfg config.ChainConfig) (MarketMapOverride, error) {
+	return NewDyDxOverride(dydx.NewHTTPClient(chainCfg.RESTAddress))
+}
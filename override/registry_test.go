@@ -0,0 +1,177 @@
+package override_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/override"
+)
+
+func TestRegistry(t *testing.T) {
+	r := override.NewRegistry()
+
+	chainCfg := config.DefaultChainConfig()
+	chainCfg.Type = "neutron"
+
+	_, err := r.CreateOverride(chainCfg)
+	require.Error(t, err)
+
+	var gotCfg any
+	require.NoError(t, r.RegisterOverride("neutron", func(cfg any, _ config.ChainConfig) (override.MarketMapOverride, error) {
+		gotCfg = cfg
+		return override.NewCoreOverride(), nil
+	}))
+	require.Error(t, r.RegisterOverride("neutron", nil))
+
+	chainCfg.Override = map[string]any{"foo": "bar"}
+	mmo, err := r.CreateOverride(chainCfg)
+	require.NoError(t, err)
+	require.IsType(t, &override.CoreOverride{}, mmo)
+	require.Equal(t, chainCfg.Override, gotCfg)
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	r := override.NewDefaultRegistry()
+
+	tests := []struct {
+		name     string
+		chainCfg func(*config.ChainConfig)
+		expected override.MarketMapOverride
+		wantErr  bool
+	}{
+		{
+			name:     "core",
+			chainCfg: func(*config.ChainConfig) {},
+			expected: &override.CoreOverride{},
+		},
+		{
+			name:     "dydx",
+			chainCfg: func(cfg *config.ChainConfig) { cfg.DYDX = true },
+			expected: &override.DyDxOverride{},
+		},
+		{
+			name: "protected markets",
+			chainCfg: func(cfg *config.ChainConfig) {
+				cfg.Type = override.ProtectedMarketsTypeName
+				cfg.Override = map[string]any{"endpoint": "rest", "path": "/markets"}
+			},
+			expected: &override.ProtectedMarketsOverride{},
+		},
+		{
+			name: "protected markets without config",
+			chainCfg: func(cfg *config.ChainConfig) {
+				cfg.Type = override.ProtectedMarketsTypeName
+			},
+			wantErr: true,
+		},
+		{
+			name:     "unknown type",
+			chainCfg: func(cfg *config.ChainConfig) { cfg.Type = "unknown" },
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainCfg := config.DefaultChainConfig()
+			tt.chainCfg(&chainCfg)
+
+			mmo, err := r.CreateOverride(chainCfg)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.IsType(t, tt.expected, mmo)
+		})
+	}
+}
+
+func TestRegistryValidateChainType(t *testing.T) {
+	r := override.NewDefaultRegistry()
+
+	tests := []struct {
+		name     string
+		chainCfg func(*config.ChainConfig)
+		wantErr  bool
+	}{
+		{
+			name:     "core",
+			chainCfg: func(*config.ChainConfig) {},
+		},
+		{
+			name:     "dydx",
+			chainCfg: func(cfg *config.ChainConfig) { cfg.DYDX = true },
+		},
+		{
+			name: "core with override config",
+			chainCfg: func(cfg *config.ChainConfig) {
+				cfg.Override = map[string]any{"endpoint": "rest"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "protected markets",
+			chainCfg: func(cfg *config.ChainConfig) {
+				cfg.Type = override.ProtectedMarketsTypeName
+				cfg.Override = map[string]any{"endpoint": "grpc", "path": "/example.Query/Markets"}
+			},
+		},
+		{
+			name: "invalid protected markets config",
+			chainCfg: func(cfg *config.ChainConfig) {
+				cfg.Type = override.ProtectedMarketsTypeName
+				cfg.Override = map[string]any{"endpoint": "rest"}
+			},
+			wantErr: true,
+		},
+		{
+			name:     "unknown type",
+			chainCfg: func(cfg *config.ChainConfig) { cfg.Type = "unknown" },
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainCfg := config.DefaultChainConfig()
+			chainCfg.ChainID = "test-1"
+			tt.chainCfg(&chainCfg)
+
+			err := chainCfg.ValidateChainType(r)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("registered config validator", func(t *testing.T) {
+		r := override.NewRegistry()
+		require.Error(t, r.RegisterConfigValidator("neutron", func(any) error { return nil }))
+
+		require.NoError(t, r.RegisterOverride("neutron", func(any, config.ChainConfig) (override.MarketMapOverride, error) {
+			return override.NewCoreOverride(), nil
+		}))
+		chainCfg := config.DefaultChainConfig()
+		chainCfg.ChainID = "neutron-1"
+		chainCfg.Type = "neutron"
+		chainCfg.Override = "invalid"
+
+		// the override config of types without a validator is not checked
+		require.NoError(t, r.ValidateChainType(chainCfg))
+
+		require.NoError(t, r.RegisterConfigValidator("neutron", func(cfg any) error {
+			if cfg == "invalid" {
+				return errors.New("invalid")
+			}
+			return nil
+		}))
+		require.Error(t, r.RegisterConfigValidator("neutron", nil))
+		require.Error(t, r.ValidateChainType(chainCfg))
+	})
+}
@@ -16,6 +16,7 @@ import (
 	authcodec "github.com/cosmos/cosmos-sdk/x/auth/codec"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	gogoproto "github.com/cosmos/gogoproto/proto"
 	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
 	slinkymmtypes "github.com/skip-mev/slinky/x/marketmap/types"
@@ -37,6 +38,7 @@ func Codec(prefix string) (*codec.ProtoCodec, error) {
 	}
 
 	authtypes.RegisterInterfaces(ir)
+	authz.RegisterInterfaces(ir)
 	cryptocodec.RegisterInterfaces(ir)
 	mmtypes.RegisterInterfaces(ir)
 	slinkymmtypes.RegisterInterfaces(ir)
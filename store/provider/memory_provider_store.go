@@ -81,6 +81,7 @@ func NewMemoryStoreFromFile(path string) (*MemoryStore, error) {
 			ReferencePrice:   providerMarket.ReferencePrice,
 			NegativeDepthTwo: providerMarket.NegativeDepthTwo,
 			PositiveDepthTwo: providerMarket.PositiveDepthTwo,
+			TradingStatus:    providerMarket.TradingStatus.OrTrading(),
 		}
 	}
 	store.providerMarketNextID = maxProviderMarketID + 1
@@ -111,6 +112,7 @@ func (w *MemoryStore) AddProviderMarket(_ context.Context, params CreateProvider
 		ReferencePrice:   params.ReferencePrice,
 		NegativeDepthTwo: params.NegativeDepthTwo,
 		PositiveDepthTwo: params.PositiveDepthTwo,
+		TradingStatus:    params.TradingStatus.OrTrading(),
 	}
 
 	w.providerMarketNextID++
@@ -135,6 +137,7 @@ func (w *MemoryStore) updateProviderMarket(params CreateProviderMarketParams, id
 	providerMarket.ReferencePrice = params.ReferencePrice
 	providerMarket.NegativeDepthTwo = params.NegativeDepthTwo
 	providerMarket.PositiveDepthTwo = params.PositiveDepthTwo
+	providerMarket.TradingStatus = params.TradingStatus.OrTrading()
 
 	return *providerMarket, nil
 }
@@ -208,6 +211,7 @@ func (w *MemoryStore) GetProviderMarkets(_ context.Context, params GetFilteredPr
 			ReferencePrice:   providerMarket.ReferencePrice,
 			NegativeDepthTwo: providerMarket.NegativeDepthTwo,
 			PositiveDepthTwo: providerMarket.PositiveDepthTwo,
+			TradingStatus:    providerMarket.TradingStatus,
 			BaseCmcID:        baseAssetInfo.CMCID,
 			QuoteCmcID:       quoteAssetInfo.CMCID,
 			BaseRank:         baseAssetInfo.Rank,
@@ -23,18 +23,19 @@ type AssetInfo struct {
 
 //nolint:revive
 type ProviderMarket struct {
-	ID               int32   `json:"id"`
-	TargetBase       string  `json:"target_base"`
-	TargetQuote      string  `json:"target_quote"`
-	OffChainTicker   string  `json:"off_chain_ticker"`
-	ProviderName     string  `json:"provider_name"`
-	QuoteVolume      float64 `json:"quote_volume"`
-	BaseAssetInfoID  int32   `json:"base_asset_info_id"`
-	QuoteAssetInfoID int32   `json:"quote_asset_info_id"`
-	MetadataJSON     string  `json:"metadata_json"`
-	ReferencePrice   float64 `json:"reference_price"`
-	NegativeDepthTwo float64 `json:"negative_depth_two"`
-	PositiveDepthTwo float64 `json:"positive_depth_two"`
+	ID               int32         `json:"id"`
+	TargetBase       string        `json:"target_base"`
+	TargetQuote      string        `json:"target_quote"`
+	OffChainTicker   string        `json:"off_chain_ticker"`
+	ProviderName     string        `json:"provider_name"`
+	QuoteVolume      float64       `json:"quote_volume"`
+	BaseAssetInfoID  int32         `json:"base_asset_info_id"`
+	QuoteAssetInfoID int32         `json:"quote_asset_info_id"`
+	MetadataJSON     string        `json:"metadata_json"`
+	ReferencePrice   float64       `json:"reference_price"`
+	NegativeDepthTwo float64       `json:"negative_depth_two"`
+	PositiveDepthTwo float64       `json:"positive_depth_two"`
+	TradingStatus    TradingStatus `json:"trading_status,omitempty"`
 }
 
 // CreateProviderMarket wraps generated CreateProviderMarketParams with extra info.
@@ -85,6 +86,12 @@ func (pm *CreateProviderMarketParams) ValidateBasic() error {
 		return fmt.Errorf("quote volume cannot be less than 0")
 	}
 
+	if pm.TradingStatus != "" {
+		if err := pm.TradingStatus.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -107,6 +114,7 @@ type CreateProviderMarketParams struct {
 	ReferencePrice   float64
 	NegativeDepthTwo float64
 	PositiveDepthTwo float64
+	TradingStatus    TradingStatus
 }
 
 type GetFilteredProviderMarketsParams struct {
@@ -123,6 +131,7 @@ type GetFilteredProviderMarketsRow struct {
 	ReferencePrice   float64
 	NegativeDepthTwo float64
 	PositiveDepthTwo float64
+	TradingStatus    TradingStatus
 	BaseCmcID        int64
 	QuoteCmcID       int64
 	BaseRank         int64
@@ -0,0 +1,52 @@
+package provider
+
+import "fmt"
+
+// TradingStatus is the trading status of a provider market, normalized across providers.
+type TradingStatus string
+
+const (
+	// TradingStatusTrading is the status of a market that is trading normally.
+	TradingStatusTrading TradingStatus = "trading"
+	// TradingStatusHalted is the status of a market whose trading is halted, ex. cancel-only or under maintenance.
+	TradingStatusHalted TradingStatus = "halted"
+	// TradingStatusPostOnly is the status of a market that only accepts maker orders, so no trades occur.
+	TradingStatusPostOnly TradingStatus = "post_only"
+	// TradingStatusDelistingScheduled is the status of a market that is trading, but scheduled to be delisted.
+	TradingStatusDelistingScheduled TradingStatus = "delisting_scheduled"
+	// TradingStatusDepositWithdrawSuspended is the status of a market that is trading, but whose assets cannot be
+	// deposited or withdrawn.
+	TradingStatusDepositWithdrawSuspended TradingStatus = "deposit_withdraw_suspended"
+	// TradingStatusUnknown is the status of a market whose provider does not report a trading status for it.
+	TradingStatusUnknown TradingStatus = "unknown"
+)
+
+// TradingStatuses are all valid trading statuses.
+var TradingStatuses = []TradingStatus{
+	TradingStatusTrading,
+	TradingStatusHalted,
+	TradingStatusPostOnly,
+	TradingStatusDelistingScheduled,
+	TradingStatusDepositWithdrawSuspended,
+	TradingStatusUnknown,
+}
+
+// Validate checks if the TradingStatus is valid.
+func (s TradingStatus) Validate() error {
+	for _, status := range TradingStatuses {
+		if s == status {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid trading status %q: must be one of %v", s, TradingStatuses)
+}
+
+// OrTrading returns the status, or TradingStatusTrading if it is not set. Provider data indexed before trading
+// statuses were recorded only contains trading markets.
+func (s TradingStatus) OrTrading() TradingStatus {
+	if s == "" {
+		return TradingStatusTrading
+	}
+	return s
+}
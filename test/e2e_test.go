@@ -196,7 +196,8 @@ func (s *E2ESuite) TestIndexGenerateDispatch() {
 	err = os.WriteFile("upserts.json", bz, 0o600)
 	require.NoError(s.T(), err)
 
-	msgs, err := txgenerator.ConvertUpsertsToMessages(s.logger, s.dispatcherCfg.TxConfig, localChainConfig.Version, upserts)
+	msgs, err := txgenerator.ConvertUpsertsToMessages(s.logger, s.dispatcherCfg.TxConfig,
+		txgenerator.NewMsgBuilder(localChainConfig.Version, localChainConfig.MessageMode, onChainMM), upserts)
 	require.NoError(s.T(), err, "failed to convert upserts to messages")
 
 	txs, err := s.dispatch.GenerateTransactions(ctx, msgs)
@@ -23,3 +23,49 @@ var UsdtUsd = mmtypes.Market{
 		},
 	},
 }
+
+// Option modifies a market built by New.
+type Option func(*mmtypes.Market)
+
+// New returns a disabled BASE/USD market with 8 decimals and a MinProviderCount of 1, modified by the options.
+func New(base string, opts ...Option) mmtypes.Market {
+	market := mmtypes.Market{
+		Ticker: mmtypes.Ticker{
+			CurrencyPair:     connecttypes.NewCurrencyPair(base, "USD"),
+			Decimals:         8,
+			MinProviderCount: 1,
+		},
+	}
+	for _, opt := range opts {
+		opt(&market)
+	}
+	return market
+}
+
+// WithProviders sets the provider configs of the market.
+func WithProviders(providers ...mmtypes.ProviderConfig) Option {
+	return func(market *mmtypes.Market) {
+		market.ProviderConfigs = providers
+	}
+}
+
+// WithMinProviderCount sets the MinProviderCount of the market.
+func WithMinProviderCount(minProviderCount uint64) Option {
+	return func(market *mmtypes.Market) {
+		market.Ticker.MinProviderCount = minProviderCount
+	}
+}
+
+// WithEnabled sets whether the market is enabled.
+func WithEnabled(enabled bool) Option {
+	return func(market *mmtypes.Market) {
+		market.Ticker.Enabled = enabled
+	}
+}
+
+// WithMetadata sets the metadata JSON of the market.
+func WithMetadata(metadataJSON string) Option {
+	return func(market *mmtypes.Market) {
+		market.Ticker.Metadata_JSON = metadataJSON
+	}
+}
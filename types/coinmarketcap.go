@@ -8,6 +8,13 @@ type CoinMarketCapInfo struct {
 
 	BaseRank  int64 `json:"base_rank"`
 	QuoteRank int64 `json:"quote_rank"`
+
+	// BaseNativeID is the ID of the native asset of the base asset if the base asset is a wrapped asset
+	// that may only feed metadata. Zero otherwise.
+	BaseNativeID int64 `json:"base_native_id,omitempty"`
+	// QuoteNativeID is the ID of the native asset of the quote asset if the quote asset is a wrapped asset
+	// that may only feed metadata. Zero otherwise.
+	QuoteNativeID int64 `json:"quote_native_id,omitempty"`
 }
 
 func NewCoinMarketCapInfo(baseID, quoteID, baseRank, quoteRank int64) CoinMarketCapInfo {
@@ -29,6 +36,18 @@ func (c *CoinMarketCapInfo) Invert() {
 	quoteRank := c.QuoteRank
 	c.QuoteRank = baseRank
 	c.BaseRank = quoteRank
+
+	c.BaseNativeID, c.QuoteNativeID = c.QuoteNativeID, c.BaseNativeID
+}
+
+// AggregateBaseID returns the ID of the base asset to use as its aggregate ID. This is the ID of the native asset
+// if the base asset is a metadata-only alias.
+func (c *CoinMarketCapInfo) AggregateBaseID() int64 {
+	if c.BaseNativeID != 0 {
+		return c.BaseNativeID
+	}
+
+	return c.BaseID
 }
 
 func (c *CoinMarketCapInfo) HasRank() bool {
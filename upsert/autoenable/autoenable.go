@@ -0,0 +1,230 @@
+package autoenable
+
+import (
+	"fmt"
+	"sort"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+
+	"github.com/skip-mev/connect-mmu/config"
+	validatortypes "github.com/skip-mev/connect-mmu/validator/types"
+)
+
+const (
+	// ActionEnable is the action of enabling a disabled market.
+	ActionEnable = "ENABLE"
+	// ActionKeepDisabled is the action of leaving a disabled market disabled.
+	ActionKeepDisabled = "KEEP_DISABLED"
+	// ActionRemoveProvider is the action of removing a failing provider from an enabled market.
+	ActionRemoveProvider = "REMOVE_PROVIDER"
+	// ActionKeepProvider is the action of keeping a failing provider of an enabled market.
+	ActionKeepProvider = "KEEP_PROVIDER"
+)
+
+// Decision explains an enable/disable decision made for a market or one of its providers.
+type Decision struct {
+	Market   string `json:"market"`
+	Provider string `json:"provider,omitempty"`
+	Action   string `json:"action"`
+	Reason   string `json:"reason"`
+}
+
+// Apply enables the disabled markets of the market map that were VALID in each of the most recent
+// cfg.RequiredValidRuns validation runs with enough passing providers. If cfg.RemoveFailedProviders is set, providers
+// of enabled markets that failed in each of those runs are removed as long as the market keeps at least its
+// MinProviderCount providers. Runs are ordered from oldest to newest.
+//
+// Decisions are returned for every market that appears in the reports, sorted by market and provider.
+// The given market map is not modified.
+func Apply(cfg config.AutoEnableConfig, mm mmtypes.MarketMap, runs []validatortypes.Reports) (mmtypes.MarketMap, []Decision) {
+	out := mmtypes.MarketMap{Markets: make(map[string]mmtypes.Market, len(mm.Markets))}
+	for ticker, market := range mm.Markets {
+		out.Markets[ticker] = market
+	}
+
+	required := cfg.RequiredValidRuns
+	if len(runs) < required {
+		required = len(runs)
+	}
+	recent := indexRuns(runs[len(runs)-required:])
+
+	decisions := make([]Decision, 0)
+	for ticker, market := range out.Markets {
+		reports := recent[ticker]
+		if len(reports) == 0 {
+			continue
+		}
+
+		if !market.Ticker.Enabled {
+			decision := enableDecision(cfg, market, reports, len(runs))
+			if decision.Action == ActionEnable {
+				market.Ticker.Enabled = true
+				out.Markets[ticker] = market
+			}
+			decisions = append(decisions, decision)
+			continue
+		}
+
+		if cfg.RemoveFailedProviders && len(runs) >= cfg.RequiredValidRuns {
+			var providerDecisions []Decision
+			market, providerDecisions = removeFailedProviders(cfg, market, reports)
+			out.Markets[ticker] = market
+			decisions = append(decisions, providerDecisions...)
+		}
+	}
+
+	sort.Slice(decisions, func(i, j int) bool {
+		if decisions[i].Market != decisions[j].Market {
+			return decisions[i].Market < decisions[j].Market
+		}
+		return decisions[i].Provider < decisions[j].Provider
+	})
+
+	return out, decisions
+}
+
+// indexRuns maps each ticker to its report in each run it was reported in. If a ticker is reported more than once in
+// a run, only its last report in that run is used.
+func indexRuns(runs []validatortypes.Reports) map[string][]validatortypes.Report {
+	index := make(map[string][]validatortypes.Report)
+	for _, run := range runs {
+		latest := make(map[string]validatortypes.Report, len(run.Reports))
+		for _, report := range run.Reports {
+			latest[report.Ticker] = report
+		}
+		for ticker, report := range latest {
+			index[ticker] = append(index[ticker], report)
+		}
+	}
+	return index
+}
+
+// enableDecision decides whether the disabled market is enabled given its reports in the most recent runs.
+func enableDecision(cfg config.AutoEnableConfig, market mmtypes.Market, reports []validatortypes.Report, totalRuns int) Decision {
+	ticker := market.Ticker.String()
+
+	if totalRuns < cfg.RequiredValidRuns {
+		return Decision{
+			Market: ticker,
+			Action: ActionKeepDisabled,
+			Reason: fmt.Sprintf("only %d of %d required validation runs available", totalRuns, cfg.RequiredValidRuns),
+		}
+	}
+
+	if len(reports) < cfg.RequiredValidRuns {
+		return Decision{
+			Market: ticker,
+			Action: ActionKeepDisabled,
+			Reason: fmt.Sprintf("reported in %d of the last %d validation runs", len(reports), cfg.RequiredValidRuns),
+		}
+	}
+
+	minPassing := cfg.MinPassingProviders
+	if market.Ticker.MinProviderCount > minPassing {
+		minPassing = market.Ticker.MinProviderCount
+	}
+
+	for i, report := range reports {
+		if report.Status != validatortypes.StatusValid {
+			return Decision{
+				Market: ticker,
+				Action: ActionKeepDisabled,
+				Reason: fmt.Sprintf("status %s in run %d of the last %d", report.Status, i+1, cfg.RequiredValidRuns),
+			}
+		}
+
+		if passing := passingProviders(market, report); passing < minPassing {
+			return Decision{
+				Market: ticker,
+				Action: ActionKeepDisabled,
+				Reason: fmt.Sprintf("%d of %d required providers passed in run %d of the last %d",
+					passing, minPassing, i+1, cfg.RequiredValidRuns),
+			}
+		}
+	}
+
+	return Decision{
+		Market: ticker,
+		Action: ActionEnable,
+		Reason: fmt.Sprintf("VALID with at least %d passing providers in each of the last %d validation runs",
+			minPassing, cfg.RequiredValidRuns),
+	}
+}
+
+// passingProviders returns the number of providers of the market that passed in the report.
+func passingProviders(market mmtypes.Market, report validatortypes.Report) uint64 {
+	var passing uint64
+	for _, providerReport := range report.ProviderReports {
+		if providerReport.Grade == validatortypes.GradePassed && hasProvider(market, providerReport.Name) {
+			passing++
+		}
+	}
+	return passing
+}
+
+// removeFailedProviders removes the providers of the market that failed in each of the reports, in order of name,
+// until the market would be left with fewer than MinProviderCount providers.
+func removeFailedProviders(
+	cfg config.AutoEnableConfig,
+	market mmtypes.Market,
+	reports []validatortypes.Report,
+) (mmtypes.Market, []Decision) {
+	ticker := market.Ticker.String()
+
+	failedRuns := make(map[string]int)
+	for _, report := range reports {
+		for _, providerReport := range report.ProviderReports {
+			if providerReport.Grade == validatortypes.GradeFailed {
+				failedRuns[providerReport.Name]++
+			}
+		}
+	}
+
+	failed := make([]string, 0)
+	for provider, count := range failedRuns {
+		if count >= cfg.RequiredValidRuns && hasProvider(market, provider) {
+			failed = append(failed, provider)
+		}
+	}
+	sort.Strings(failed)
+
+	decisions := make([]Decision, 0, len(failed))
+	for _, provider := range failed {
+		if uint64(len(market.ProviderConfigs)-1) < market.Ticker.MinProviderCount {
+			decisions = append(decisions, Decision{
+				Market:   ticker,
+				Provider: provider,
+				Action:   ActionKeepProvider,
+				Reason: fmt.Sprintf("failed in each of the last %d validation runs, but removing it would leave fewer than %d providers",
+					cfg.RequiredValidRuns, market.Ticker.MinProviderCount),
+			})
+			continue
+		}
+
+		providers := make([]mmtypes.ProviderConfig, 0, len(market.ProviderConfigs)-1)
+		for _, pc := range market.ProviderConfigs {
+			if pc.Name != provider {
+				providers = append(providers, pc)
+			}
+		}
+		market.ProviderConfigs = providers
+
+		decisions = append(decisions, Decision{
+			Market:   ticker,
+			Provider: provider,
+			Action:   ActionRemoveProvider,
+			Reason:   fmt.Sprintf("failed in each of the last %d validation runs", cfg.RequiredValidRuns),
+		})
+	}
+
+	return market, decisions
+}
+
+func hasProvider(market mmtypes.Market, provider string) bool {
+	for _, pc := range market.ProviderConfigs {
+		if pc.Name == provider {
+			return true
+		}
+	}
+	return false
+}
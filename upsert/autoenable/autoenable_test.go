@@ -0,0 +1,263 @@
+package autoenable_test
+
+import (
+	"testing"
+
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/config"
+	"github.com/skip-mev/connect-mmu/testutil/markets"
+	"github.com/skip-mev/connect-mmu/upsert/autoenable"
+	validatortypes "github.com/skip-mev/connect-mmu/validator/types"
+)
+
+// report builds a report from provider names to grades.
+func report(ticker, status string, grades map[string]string) validatortypes.Report {
+	r := validatortypes.Report{Ticker: ticker, Status: status}
+	for provider, grade := range grades {
+		r.ProviderReports = append(r.ProviderReports, validatortypes.ProviderReport{Name: provider, Grade: grade})
+	}
+	return r
+}
+
+func run(reports ...validatortypes.Report) validatortypes.Reports {
+	return validatortypes.Reports{Reports: reports}
+}
+
+func TestApply(t *testing.T) {
+	const (
+		pass = validatortypes.GradePassed
+		fail = validatortypes.GradeFailed
+	)
+
+	cfg := config.AutoEnableConfig{
+		RequiredValidRuns:     2,
+		MinPassingProviders:   2,
+		RemoveFailedProviders: true,
+	}
+
+	binance := mmtypes.ProviderConfig{Name: "binance_ws", OffChainTicker: "BTCUSD"}
+	coinbase := mmtypes.ProviderConfig{Name: "coinbase_ws", OffChainTicker: "BTCUSD"}
+	okx := mmtypes.ProviderConfig{Name: "okx_ws", OffChainTicker: "BTCUSD"}
+	enabled := markets.WithEnabled(true)
+
+	valid := func(ticker string, providers ...string) validatortypes.Report {
+		grades := make(map[string]string)
+		for _, provider := range providers {
+			grades[provider] = pass
+		}
+		return report(ticker, validatortypes.StatusValid, grades)
+	}
+
+	tests := []struct {
+		name          string
+		cfg           config.AutoEnableConfig
+		market        mmtypes.Market
+		runs          []validatortypes.Reports
+		wantEnabled   bool
+		wantProviders []string
+		wantDecisions []autoenable.Decision
+	}{
+		{
+			name:          "enable market valid in the required runs",
+			cfg:           cfg,
+			market:        markets.New("BTC", markets.WithProviders(binance, coinbase)),
+			runs:          []validatortypes.Reports{run(valid("BTC/USD", "binance_ws", "coinbase_ws")), run(valid("BTC/USD", "binance_ws", "coinbase_ws"))},
+			wantEnabled:   true,
+			wantProviders: []string{"binance_ws", "coinbase_ws"},
+			wantDecisions: []autoenable.Decision{{
+				Market: "BTC/USD",
+				Action: autoenable.ActionEnable,
+				Reason: "VALID with at least 2 passing providers in each of the last 2 validation runs",
+			}},
+		},
+		{
+			name:   "only the most recent runs are considered",
+			cfg:    cfg,
+			market: markets.New("BTC", markets.WithProviders(binance, coinbase)),
+			runs: []validatortypes.Reports{
+				run(report("BTC/USD", validatortypes.StatusFailed, map[string]string{"binance_ws": fail, "coinbase_ws": fail})),
+				run(valid("BTC/USD", "binance_ws", "coinbase_ws")),
+				run(valid("BTC/USD", "binance_ws", "coinbase_ws")),
+			},
+			wantEnabled:   true,
+			wantProviders: []string{"binance_ws", "coinbase_ws"},
+			wantDecisions: []autoenable.Decision{{
+				Market: "BTC/USD",
+				Action: autoenable.ActionEnable,
+				Reason: "VALID with at least 2 passing providers in each of the last 2 validation runs",
+			}},
+		},
+		{
+			name:   "a ticker reported twice in a run is not counted as reported in two runs",
+			cfg:    cfg,
+			market: markets.New("BTC", markets.WithProviders(binance, coinbase)),
+			runs: []validatortypes.Reports{
+				run(valid("ETH/USD", "binance_ws", "coinbase_ws")),
+				run(valid("BTC/USD", "binance_ws", "coinbase_ws"), valid("BTC/USD", "binance_ws", "coinbase_ws")),
+			},
+			wantProviders: []string{"binance_ws", "coinbase_ws"},
+			wantDecisions: []autoenable.Decision{{
+				Market: "BTC/USD",
+				Action: autoenable.ActionKeepDisabled,
+				Reason: "reported in 1 of the last 2 validation runs",
+			}},
+		},
+		{
+			name:          "keep disabled if there are not enough runs",
+			cfg:           cfg,
+			market:        markets.New("BTC", markets.WithProviders(binance, coinbase)),
+			runs:          []validatortypes.Reports{run(valid("BTC/USD", "binance_ws", "coinbase_ws"))},
+			wantProviders: []string{"binance_ws", "coinbase_ws"},
+			wantDecisions: []autoenable.Decision{{
+				Market: "BTC/USD",
+				Action: autoenable.ActionKeepDisabled,
+				Reason: "only 1 of 2 required validation runs available",
+			}},
+		},
+		{
+			name:   "keep disabled if not reported in every run",
+			cfg:    cfg,
+			market: markets.New("BTC", markets.WithProviders(binance, coinbase)),
+			runs: []validatortypes.Reports{
+				run(valid("BTC/USD", "binance_ws", "coinbase_ws")),
+				run(valid("ETH/USD", "binance_ws", "coinbase_ws")),
+			},
+			wantProviders: []string{"binance_ws", "coinbase_ws"},
+			wantDecisions: []autoenable.Decision{{
+				Market: "BTC/USD",
+				Action: autoenable.ActionKeepDisabled,
+				Reason: "reported in 1 of the last 2 validation runs",
+			}},
+		},
+		{
+			name:   "keep disabled if degraded in a run",
+			cfg:    cfg,
+			market: markets.New("BTC", markets.WithProviders(binance, coinbase)),
+			runs: []validatortypes.Reports{
+				run(valid("BTC/USD", "binance_ws", "coinbase_ws")),
+				run(report("BTC/USD", validatortypes.StatusDegraded, map[string]string{"binance_ws": pass, "coinbase_ws": fail})),
+			},
+			wantProviders: []string{"binance_ws", "coinbase_ws"},
+			wantDecisions: []autoenable.Decision{{
+				Market: "BTC/USD",
+				Action: autoenable.ActionKeepDisabled,
+				Reason: "status DEGRADED in run 2 of the last 2",
+			}},
+		},
+		{
+			name:          "keep disabled without enough passing providers",
+			cfg:           cfg,
+			market:        markets.New("BTC", markets.WithProviders(binance)),
+			runs:          []validatortypes.Reports{run(valid("BTC/USD", "binance_ws")), run(valid("BTC/USD", "binance_ws"))},
+			wantProviders: []string{"binance_ws"},
+			wantDecisions: []autoenable.Decision{{
+				Market: "BTC/USD",
+				Action: autoenable.ActionKeepDisabled,
+				Reason: "1 of 2 required providers passed in run 1 of the last 2",
+			}},
+		},
+		{
+			name:   "min provider count of the market takes precedence",
+			cfg:    cfg,
+			market: markets.New("BTC", markets.WithMinProviderCount(3), markets.WithProviders(binance, coinbase, okx)),
+			runs: []validatortypes.Reports{
+				run(valid("BTC/USD", "binance_ws", "coinbase_ws")),
+				run(valid("BTC/USD", "binance_ws", "coinbase_ws")),
+			},
+			wantProviders: []string{"binance_ws", "coinbase_ws", "okx_ws"},
+			wantDecisions: []autoenable.Decision{{
+				Market: "BTC/USD",
+				Action: autoenable.ActionKeepDisabled,
+				Reason: "2 of 3 required providers passed in run 1 of the last 2",
+			}},
+		},
+		{
+			name:   "remove providers of enabled markets failing in every run",
+			cfg:    cfg,
+			market: markets.New("BTC", enabled, markets.WithProviders(binance, coinbase, okx)),
+			runs: []validatortypes.Reports{
+				run(report("BTC/USD", validatortypes.StatusDegraded, map[string]string{"binance_ws": pass, "coinbase_ws": fail, "okx_ws": fail})),
+				run(report("BTC/USD", validatortypes.StatusDegraded, map[string]string{"binance_ws": pass, "coinbase_ws": fail, "okx_ws": pass})),
+			},
+			wantEnabled:   true,
+			wantProviders: []string{"binance_ws", "okx_ws"},
+			wantDecisions: []autoenable.Decision{{
+				Market:   "BTC/USD",
+				Provider: "coinbase_ws",
+				Action:   autoenable.ActionRemoveProvider,
+				Reason:   "failed in each of the last 2 validation runs",
+			}},
+		},
+		{
+			name:   "keep failing providers needed for the min provider count",
+			cfg:    cfg,
+			market: markets.New("BTC", enabled, markets.WithMinProviderCount(2), markets.WithProviders(binance, coinbase, okx)),
+			runs: []validatortypes.Reports{
+				run(report("BTC/USD", validatortypes.StatusDegraded, map[string]string{"binance_ws": pass, "coinbase_ws": fail, "okx_ws": fail})),
+				run(report("BTC/USD", validatortypes.StatusDegraded, map[string]string{"binance_ws": pass, "coinbase_ws": fail, "okx_ws": fail})),
+			},
+			wantEnabled:   true,
+			wantProviders: []string{"binance_ws", "okx_ws"},
+			wantDecisions: []autoenable.Decision{
+				{
+					Market:   "BTC/USD",
+					Provider: "coinbase_ws",
+					Action:   autoenable.ActionRemoveProvider,
+					Reason:   "failed in each of the last 2 validation runs",
+				},
+				{
+					Market:   "BTC/USD",
+					Provider: "okx_ws",
+					Action:   autoenable.ActionKeepProvider,
+					Reason:   "failed in each of the last 2 validation runs, but removing it would leave fewer than 2 providers",
+				},
+			},
+		},
+		{
+			name: "failed providers are kept if removal is disabled",
+			cfg: config.AutoEnableConfig{
+				RequiredValidRuns:   2,
+				MinPassingProviders: 2,
+			},
+			market: markets.New("BTC", enabled, markets.WithProviders(binance, coinbase)),
+			runs: []validatortypes.Reports{
+				run(report("BTC/USD", validatortypes.StatusDegraded, map[string]string{"binance_ws": pass, "coinbase_ws": fail})),
+				run(report("BTC/USD", validatortypes.StatusDegraded, map[string]string{"binance_ws": pass, "coinbase_ws": fail})),
+			},
+			wantEnabled:   true,
+			wantProviders: []string{"binance_ws", "coinbase_ws"},
+			wantDecisions: []autoenable.Decision{},
+		},
+		{
+			name:          "no decisions for unreported markets",
+			cfg:           cfg,
+			market:        markets.New("BTC", markets.WithProviders(binance, coinbase)),
+			runs:          []validatortypes.Reports{run(), run()},
+			wantProviders: []string{"binance_ws", "coinbase_ws"},
+			wantDecisions: []autoenable.Decision{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mm := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{tt.market.Ticker.String(): tt.market}}
+
+			out, decisions := autoenable.Apply(tt.cfg, mm, tt.runs)
+			require.Equal(t, tt.wantDecisions, decisions)
+
+			got := out.Markets[tt.market.Ticker.String()]
+			require.Equal(t, tt.wantEnabled, got.Ticker.Enabled)
+
+			providers := make([]string, 0, len(got.ProviderConfigs))
+			for _, pc := range got.ProviderConfigs {
+				providers = append(providers, pc.Name)
+			}
+			require.Equal(t, tt.wantProviders, providers)
+
+			// the input market map is not modified
+			require.Equal(t, tt.market, mm.Markets[tt.market.Ticker.String()])
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package upsert
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/skip-mev/connect/v2/x/marketmap/types"
+
+	"github.com/skip-mev/connect-mmu/upsert/autoenable"
+)
+
+// Output is the upserts output of the upserts commands, which the dispatch and verify commands read. It is written
+// as the plain list of upserts unless there are auto enable decisions, so that readers of the list keep working.
+type Output struct {
+	// Upserts are the markets to be updated or inserted, in the order they are dispatched.
+	Upserts []types.Market `json:"upserts"`
+
+	// Decisions explain each enable/disable decision made from validation reports when auto enabling is configured.
+	Decisions []autoenable.Decision `json:"auto_enable_decisions,omitempty"`
+}
+
+// MarshalJSON marshals the Output as the plain list of upserts if it has no decisions, and as an object otherwise.
+func (o Output) MarshalJSON() ([]byte, error) {
+	if len(o.Decisions) == 0 {
+		return json.Marshal(o.Upserts)
+	}
+
+	type output Output
+	return json.Marshal(output(o))
+}
+
+// UnmarshalJSON unmarshals an Output, also accepting the plain list of upserts written by earlier versions.
+func (o *Output) UnmarshalJSON(bz []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(bz), []byte("[")) {
+		*o = Output{}
+		return json.Unmarshal(bz, &o.Upserts)
+	}
+
+	type output Output
+	var out output
+	if err := json.Unmarshal(bz, &out); err != nil {
+		return err
+	}
+	*o = Output(out)
+	return nil
+}
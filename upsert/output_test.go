@@ -0,0 +1,49 @@
+package upsert_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/upsert"
+	"github.com/skip-mev/connect-mmu/upsert/autoenable"
+)
+
+func TestOutputJSON(t *testing.T) {
+	output := upsert.Output{
+		Upserts: []mmtypes.Market{{
+			Ticker: mmtypes.Ticker{CurrencyPair: connecttypes.NewCurrencyPair("BTC", "USD"), Decimals: 8, MinProviderCount: 1},
+			ProviderConfigs: []mmtypes.ProviderConfig{
+				{Name: "coinbase_ws", OffChainTicker: "BTC-USD"},
+			},
+		}},
+		Decisions: []autoenable.Decision{{Market: "BTC/USD", Action: autoenable.ActionEnable, Reason: "valid"}},
+	}
+
+	bz, err := json.Marshal(output)
+	require.NoError(t, err)
+
+	var got upsert.Output
+	require.NoError(t, json.Unmarshal(bz, &got))
+	require.Equal(t, output, got)
+
+	// a plain list of upserts is read as an output without decisions
+	bz, err = json.Marshal(output.Upserts)
+	require.NoError(t, err)
+
+	got = upsert.Output{}
+	require.NoError(t, json.Unmarshal(bz, &got))
+	require.Equal(t, upsert.Output{Upserts: output.Upserts}, got)
+
+	// an output without decisions is written as the plain list of upserts
+	bz, err = json.Marshal(upsert.Output{Upserts: output.Upserts})
+	require.NoError(t, err)
+	expected, err := json.Marshal(output.Upserts)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(bz))
+
+	require.Error(t, json.Unmarshal([]byte(`{"upserts":1}`), &got))
+}
@@ -0,0 +1,156 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+)
+
+// Mismatch is a field of an upserted market whose on-chain value differs from the upserted value.
+type Mismatch struct {
+	// Field is the path of the field, ex. ticker.decimals or provider_configs[okx_ws].off_chain_ticker.
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// MarketResult is the verification result of a single upserted market.
+type MarketResult struct {
+	Market     string     `json:"market"`
+	Passed     bool       `json:"passed"`
+	Mismatches []Mismatch `json:"mismatches,omitempty"`
+}
+
+// Report is the result of verifying the upserts against the on-chain market map.
+type Report struct {
+	Passed  bool           `json:"passed"`
+	Checked int            `json:"checked"`
+	Failed  int            `json:"failed"`
+	Markets []MarketResult `json:"markets"`
+}
+
+// FailedMarkets returns the tickers of the markets that failed verification.
+func (r Report) FailedMarkets() []string {
+	failed := make([]string, 0, r.Failed)
+	for _, market := range r.Markets {
+		if !market.Passed {
+			failed = append(failed, market.Market)
+		}
+	}
+	return failed
+}
+
+// Verify compares every upserted market field by field against the on-chain market map. A market passes if it exists
+// on chain, and its ticker and provider configs are equal to the upsert. Metadata JSON is compared semantically,
+// since the chain may re-encode it.
+func Verify(upserts []mmtypes.Market, onChain mmtypes.MarketMap) Report {
+	report := Report{
+		Passed:  true,
+		Checked: len(upserts),
+		Markets: make([]MarketResult, 0, len(upserts)),
+	}
+
+	for _, expected := range upserts {
+		ticker := expected.Ticker.String()
+		result := MarketResult{Market: ticker}
+
+		actual, found := onChain.Markets[ticker]
+		if found {
+			result.Mismatches = compareMarkets(expected, actual)
+		} else {
+			result.Mismatches = []Mismatch{{Field: "market", Expected: "present", Actual: "missing"}}
+		}
+
+		result.Passed = len(result.Mismatches) == 0
+		if !result.Passed {
+			report.Passed = false
+			report.Failed++
+		}
+		report.Markets = append(report.Markets, result)
+	}
+
+	sort.SliceStable(report.Markets, func(i, j int) bool {
+		return report.Markets[i].Market < report.Markets[j].Market
+	})
+
+	return report
+}
+
+// compareMarkets returns the mismatches of the actual market against the expected market.
+func compareMarkets(expected, actual mmtypes.Market) []Mismatch {
+	var mismatches []Mismatch
+	add := func(field, expected, actual string) {
+		if expected != actual {
+			mismatches = append(mismatches, Mismatch{Field: field, Expected: expected, Actual: actual})
+		}
+	}
+
+	add("ticker.decimals", strconv.FormatUint(expected.Ticker.Decimals, 10), strconv.FormatUint(actual.Ticker.Decimals, 10))
+	add("ticker.min_provider_count", strconv.FormatUint(expected.Ticker.MinProviderCount, 10),
+		strconv.FormatUint(actual.Ticker.MinProviderCount, 10))
+	add("ticker.enabled", strconv.FormatBool(expected.Ticker.Enabled), strconv.FormatBool(actual.Ticker.Enabled))
+	if !jsonEqual(expected.Ticker.Metadata_JSON, actual.Ticker.Metadata_JSON) {
+		add("ticker.metadata_JSON", expected.Ticker.Metadata_JSON, actual.Ticker.Metadata_JSON)
+	}
+
+	actualProviders := make(map[string]mmtypes.ProviderConfig, len(actual.ProviderConfigs))
+	for _, pc := range actual.ProviderConfigs {
+		actualProviders[pc.Name] = pc
+	}
+
+	for _, expectedPC := range expected.ProviderConfigs {
+		field := fmt.Sprintf("provider_configs[%s]", expectedPC.Name)
+		actualPC, found := actualProviders[expectedPC.Name]
+		if !found {
+			add(field, "present", "missing")
+			continue
+		}
+		delete(actualProviders, expectedPC.Name)
+
+		add(field+".off_chain_ticker", expectedPC.OffChainTicker, actualPC.OffChainTicker)
+		add(field+".normalize_by_pair", pairString(expectedPC.NormalizeByPair), pairString(actualPC.NormalizeByPair))
+		add(field+".invert", strconv.FormatBool(expectedPC.Invert), strconv.FormatBool(actualPC.Invert))
+		if !jsonEqual(expectedPC.Metadata_JSON, actualPC.Metadata_JSON) {
+			add(field+".metadata_JSON", expectedPC.Metadata_JSON, actualPC.Metadata_JSON)
+		}
+	}
+
+	unexpected := make([]string, 0, len(actualProviders))
+	for name := range actualProviders {
+		unexpected = append(unexpected, name)
+	}
+	sort.Strings(unexpected)
+	for _, name := range unexpected {
+		add(fmt.Sprintf("provider_configs[%s]", name), "missing", "present")
+	}
+
+	return mismatches
+}
+
+func pairString(cp *connecttypes.CurrencyPair) string {
+	if cp == nil {
+		return ""
+	}
+	return cp.String()
+}
+
+// jsonEqual returns true if a and b are equal, or are JSON documents that decode to equal values.
+func jsonEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+
+	ea, errA := json.Marshal(va)
+	eb, errB := json.Marshal(vb)
+	return errA == nil && errB == nil && bytes.Equal(ea, eb)
+}
@@ -0,0 +1,72 @@
+package verify_test
+
+import (
+	"testing"
+
+	connecttypes "github.com/skip-mev/connect/v2/pkg/types"
+	mmtypes "github.com/skip-mev/connect/v2/x/marketmap/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skip-mev/connect-mmu/testutil/markets"
+	"github.com/skip-mev/connect-mmu/verify"
+)
+
+func TestVerify(t *testing.T) {
+	usdt := connecttypes.NewCurrencyPair("USDT", "USD")
+	binance := mmtypes.ProviderConfig{Name: "binance_ws", OffChainTicker: "BTCUSDT", NormalizeByPair: &usdt}
+	coinbase := mmtypes.ProviderConfig{Name: "coinbase_ws", OffChainTicker: "BTC-USD"}
+	enabled := markets.WithEnabled(true)
+	metadata := markets.WithMetadata(`{"reference_price":1,"liquidity":2}`)
+
+	t.Run("pass if all markets match", func(t *testing.T) {
+		upserts := []mmtypes.Market{markets.New("BTC", enabled, metadata, markets.WithProviders(binance, coinbase))}
+
+		onChainMarket := markets.New("BTC", enabled, metadata, markets.WithProviders(coinbase, binance))
+		// metadata is compared semantically
+		onChainMarket.Ticker.Metadata_JSON = `{"liquidity": 2, "reference_price": 1}`
+		onChain := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{"BTC/USD": onChainMarket}}
+
+		report := verify.Verify(upserts, onChain)
+		require.True(t, report.Passed)
+		require.Equal(t, 1, report.Checked)
+		require.Equal(t, 0, report.Failed)
+		require.Empty(t, report.FailedMarkets())
+	})
+
+	t.Run("fail for missing markets", func(t *testing.T) {
+		upserts := []mmtypes.Market{markets.New("BTC", enabled, metadata, markets.WithProviders(binance))}
+		report := verify.Verify(upserts, mmtypes.MarketMap{})
+		require.False(t, report.Passed)
+		require.Equal(t, []string{"BTC/USD"}, report.FailedMarkets())
+		require.Equal(t, []verify.Mismatch{{Field: "market", Expected: "present", Actual: "missing"}},
+			report.Markets[0].Mismatches)
+	})
+
+	t.Run("report each mismatching field", func(t *testing.T) {
+		okx := mmtypes.ProviderConfig{Name: "okx_ws", OffChainTicker: "BTC-USDT"}
+		onChainMarket := markets.New("BTC", enabled, metadata, markets.WithProviders(coinbase, okx))
+		onChainMarket.Ticker.Enabled = false
+		onChainMarket.ProviderConfigs[0].OffChainTicker = "BTC-USDC"
+		onChain := mmtypes.MarketMap{Markets: map[string]mmtypes.Market{
+			"BTC/USD": onChainMarket,
+			"ETH/USD": markets.New("ETH", enabled, metadata, markets.WithProviders(coinbase)),
+		}}
+
+		upserts := []mmtypes.Market{
+			markets.New("BTC", enabled, metadata, markets.WithProviders(binance, coinbase)),
+			markets.New("ETH", enabled, metadata, markets.WithProviders(coinbase)),
+		}
+		report := verify.Verify(upserts, onChain)
+		require.False(t, report.Passed)
+		require.Equal(t, 2, report.Checked)
+		require.Equal(t, 1, report.Failed)
+		require.Equal(t, []string{"BTC/USD"}, report.FailedMarkets())
+		require.True(t, report.Markets[1].Passed)
+		require.Equal(t, []verify.Mismatch{
+			{Field: "ticker.enabled", Expected: "true", Actual: "false"},
+			{Field: "provider_configs[binance_ws]", Expected: "present", Actual: "missing"},
+			{Field: "provider_configs[coinbase_ws].off_chain_ticker", Expected: "BTC-USD", Actual: "BTC-USDC"},
+			{Field: "provider_configs[okx_ws]", Expected: "missing", Actual: "present"},
+		}, report.Markets[0].Mismatches)
+	})
+}